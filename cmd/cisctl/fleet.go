@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rancher/wrangler/pkg/signals"
+	"github.com/urfave/cli"
+	"k8s.io/client-go/tools/clientcmd"
+
+	cisclient "github.com/rancher/cis-operator/pkg/client"
+)
+
+func runFleetScan(c *cli.Context) error {
+	scan, err := buildScan(c)
+	if err != nil {
+		return err
+	}
+	return runFleet(c, func(ctx context.Context, kubeContext string, client *cisclient.Client) cisclient.ClusterResult {
+		created, err := client.CreateScan(scan.DeepCopy())
+		if err != nil {
+			return cisclient.ClusterResult{Context: kubeContext, Err: fmt.Errorf("error creating ClusterScan: %w", err)}
+		}
+		fmt.Fprintf(os.Stderr, "[%v] Created ClusterScan %v\n", kubeContext, created.Name)
+		return waitForFleetScan(ctx, kubeContext, client, created.Name)
+	})
+}
+
+func waitFleetScan(c *cli.Context) error {
+	scanName := c.Args().First()
+	if scanName == "" {
+		return cli.NewExitError("the name of the scan is required", exitCodeError)
+	}
+	return runFleet(c, func(ctx context.Context, kubeContext string, client *cisclient.Client) cisclient.ClusterResult {
+		return waitForFleetScan(ctx, kubeContext, client, scanName)
+	})
+}
+
+// runFleet runs the scan function on the clusters of the --contexts, --parallel of them at once, within the --timeout
+// of the command, then prints their fleet report. It exits with exitCodeError if any cluster could not be scanned,
+// else with exitCodeChecksFailed if any cluster failed checks.
+func runFleet(c *cli.Context, scanCluster func(ctx context.Context, kubeContext string, client *cisclient.Client) cisclient.ClusterResult) error {
+	format := c.String("output")
+	if format != cisclient.FormatJSON && format != cisclient.FormatText {
+		return cli.NewExitError(fmt.Sprintf("unsupported fleet report format %q, must be json or text", format), exitCodeError)
+	}
+	parallel := c.Int("parallel")
+	if parallel < 1 {
+		return cli.NewExitError("--parallel must be at least 1", exitCodeError)
+	}
+	kubeContexts, err := getFleetContexts(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(signals.SetupSignalContext(), c.Duration("timeout"))
+	defer cancel()
+	results := make([]cisclient.ClusterResult, len(kubeContexts))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, kubeContext := range kubeContexts {
+		wg.Add(1)
+		go func(i int, kubeContext string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			client, err := newContextClient(c, kubeContext)
+			if err != nil {
+				results[i] = cisclient.ClusterResult{Context: kubeContext, Err: err}
+			} else {
+				results[i] = scanCluster(ctx, kubeContext, client)
+			}
+			if results[i].Err != nil {
+				fmt.Fprintf(os.Stderr, "[%v] %v\n", kubeContext, results[i].Err)
+			}
+		}(i, kubeContext)
+	}
+	wg.Wait()
+
+	fleet := cisclient.NewFleetReport(results, time.Now())
+	var out bytes.Buffer
+	if err := cisclient.RenderFleetReport(&out, fleet, format); err != nil {
+		return cli.NewExitError(err.Error(), exitCodeError)
+	}
+	if outputFile := c.String("output-file"); outputFile != "" {
+		if err := os.WriteFile(outputFile, out.Bytes(), 0644); err != nil {
+			return cli.NewExitError(fmt.Sprintf("Error writing fleet report: %v", err), exitCodeError)
+		}
+	} else if _, err := out.WriteTo(os.Stdout); err != nil {
+		return err
+	}
+
+	if fleet.Summary.Errors > 0 {
+		return cli.NewExitError(fmt.Sprintf("%d of %d clusters could not be scanned", fleet.Summary.Errors, fleet.Summary.Clusters), exitCodeError)
+	}
+	if failed := fleet.Summary.Clusters - fleet.Summary.Passed; failed > 0 {
+		return cli.NewExitError(fmt.Sprintf("%d of %d clusters failed checks", failed, fleet.Summary.Clusters), exitCodeChecksFailed)
+	}
+	return nil
+}
+
+// waitForFleetScan waits for the scan of the cluster to complete and fetches its report
+func waitForFleetScan(ctx context.Context, kubeContext string, client *cisclient.Client, scanName string) cisclient.ClusterResult {
+	result := cisclient.ClusterResult{Context: kubeContext, ScanName: scanName}
+	fmt.Fprintf(os.Stderr, "[%v] Waiting for ClusterScan %v to complete\n", kubeContext, scanName)
+	if _, result.Err = client.WaitForScanCompletion(ctx, scanName); result.Err != nil {
+		return result
+	}
+	result.Report, result.Err = client.FetchReport(scanName)
+	if result.Err == nil {
+		fmt.Fprintf(os.Stderr, "[%v] ClusterScan %v completed\n", kubeContext, scanName)
+	}
+	return result
+}
+
+// getFleetContexts returns the contexts of the --contexts flag, or every context of the kubeconfig with --all-contexts
+func getFleetContexts(c *cli.Context) ([]string, error) {
+	var kubeContexts []string
+	for _, kubeContext := range strings.Split(c.String("contexts"), ",") {
+		if kubeContext = strings.TrimSpace(kubeContext); kubeContext != "" {
+			kubeContexts = append(kubeContexts, kubeContext)
+		}
+	}
+	if c.Bool("all-contexts") {
+		if len(kubeContexts) > 0 {
+			return nil, cli.NewExitError("--contexts and --all-contexts are mutually exclusive", exitCodeError)
+		}
+		config, err := getLoadingRules(c).Load()
+		if err != nil {
+			return nil, cli.NewExitError("failed to load kubeconfig: "+err.Error(), exitCodeError)
+		}
+		for kubeContext := range config.Contexts {
+			kubeContexts = append(kubeContexts, kubeContext)
+		}
+		sort.Strings(kubeContexts)
+	}
+	if len(kubeContexts) == 0 {
+		return nil, cli.NewExitError("the clusters are required, with --contexts or --all-contexts", exitCodeError)
+	}
+	return kubeContexts, nil
+}
+
+// newContextClient builds the cis client of a context of the kubeconfig of the global --kubeconfig flag
+func newContextClient(c *cli.Context, kubeContext string) (*cisclient.Client, error) {
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: kubeContext}
+	cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(getLoadingRules(c), overrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find kubeconfig context: %w", err)
+	}
+	return cisclient.NewForConfig(cfg)
+}
+
+// getLoadingRules loads the kubeconfig files of the global --kubeconfig flag, a list as in KUBECONFIG, or the default
+// kubeconfig
+func getLoadingRules(c *cli.Context) *clientcmd.ClientConfigLoadingRules {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig := c.GlobalString("kubeconfig"); kubeconfig != "" {
+		rules.Precedence = filepath.SplitList(kubeconfig)
+	}
+	return rules
+}
@@ -0,0 +1,207 @@
+// cisctl drives CIS scans from CI pipelines and runbooks: it creates ClusterScans, waits for them
+// and fetches their reports through the cis.cattle.io resources, the operator runs the scans.
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/rancher/wrangler/pkg/kubeconfig"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+
+	cisclient "github.com/rancher/cis-operator/pkg/client"
+)
+
+var (
+	Version   = "v0.0.0-dev"
+	GitCommit = "HEAD"
+)
+
+const (
+	// exit codes, the same as run-once, so that pipelines can tell a failing benchmark from a broken run
+	exitCodeChecksFailed = 1
+	exitCodeError        = 2
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "cisctl"
+	app.Version = Version + " (" + GitCommit + ")"
+	app.Usage = "run CIS scans and fetch their reports through the cis-operator"
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:   "kubeconfig",
+			EnvVar: "KUBECONFIG",
+		},
+	}
+	timeoutFlag := cli.DurationFlag{
+		Name:  "timeout",
+		Usage: "maximum time to wait for the scan to complete",
+		Value: time.Hour,
+	}
+	outputFlag := cli.StringFlag{
+		Name:  "output, o",
+		Usage: "format of the report: json, text, sarif or ocsf",
+		Value: cisclient.FormatText,
+	}
+	// flags of the ClusterScans created, see buildScan
+	scanFlags := []cli.Flag{
+		cli.StringFlag{
+			Name:  "profile",
+			Usage: "name of the ClusterScanProfile to run, the operator picks the provider default if empty",
+		},
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "name of the ClusterScan, generated if empty",
+		},
+		cli.StringFlag{
+			Name:  "node-selector",
+			Usage: "limit the scan to the nodes with these labels, e.g. node-role.kubernetes.io/worker=true",
+		},
+		cli.StringFlag{
+			Name:  "labels",
+			Usage: "labels of the ClusterScan, e.g. team=platform,env=prod",
+		},
+		cli.IntFlag{
+			Name:  "max-nodes",
+			Usage: "run a canary scan of at most this many nodes, sampled across the node roles",
+		},
+		cli.StringFlag{
+			Name:  "node-names",
+			Usage: "run a canary scan of these nodes only, e.g. node-1,node-2",
+		},
+		cli.StringFlag{
+			Name:  "kubelet-version-range",
+			Usage: "scan only the nodes whose kubelet version is in this semver range, e.g. <1.27.0",
+		},
+		cli.StringFlag{
+			Name:  "os-images",
+			Usage: "scan only the nodes whose OS image contains one of these, e.g. amzn2-ami-eks-node-1.26",
+		},
+		cli.StringFlag{
+			Name:  "include-checks",
+			Usage: "limit the results to these check IDs or sections, e.g. 4.2.6,1.1",
+		},
+	}
+	// flags of the commands run on several clusters
+	fleetFlags := []cli.Flag{
+		cli.StringFlag{
+			Name:  "contexts",
+			Usage: "kubeconfig contexts of the clusters, e.g. prod-eu,prod-us",
+		},
+		cli.BoolFlag{
+			Name:  "all-contexts",
+			Usage: "run on every context of the kubeconfig",
+		},
+		cli.IntFlag{
+			Name:  "parallel",
+			Usage: "number of clusters scanned at once",
+			Value: 5,
+		},
+		timeoutFlag,
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "format of the fleet report: json or text",
+			Value: cisclient.FormatText,
+		},
+		cli.StringFlag{
+			Name:  "output-file",
+			Usage: "write the fleet report to this file instead of stdout",
+		},
+	}
+	app.Commands = []cli.Command{
+		{
+			Name:  "scan",
+			Usage: "run and follow ClusterScans",
+			Subcommands: []cli.Command{
+				{
+					Name:      "run",
+					Usage:     "create a ClusterScan, optionally waiting for its report",
+					ArgsUsage: " ",
+					Action:    runScan,
+					Flags: append(append([]cli.Flag{}, scanFlags...),
+						cli.BoolFlag{
+							Name:  "wait",
+							Usage: "wait for the scan to complete, print its report and exit with its verdict",
+						},
+						timeoutFlag,
+						outputFlag,
+					),
+				},
+				{
+					Name:      "wait",
+					Usage:     "wait for a ClusterScan to complete and exit with its verdict",
+					ArgsUsage: "<scan>",
+					Action:    waitScan,
+					Flags:     []cli.Flag{timeoutFlag},
+				},
+				{
+					Name:   "list",
+					Usage:  "list the ClusterScans and their state",
+					Action: listScans,
+				},
+			},
+		},
+		{
+			Name:  "report",
+			Usage: "fetch ClusterScanReports",
+			Subcommands: []cli.Command{
+				{
+					Name:      "get",
+					Usage:     "print the latest report of a ClusterScan",
+					ArgsUsage: "<scan>",
+					Action:    getReport,
+					Flags: []cli.Flag{
+						outputFlag,
+						cli.StringFlag{
+							Name:  "output-file",
+							Usage: "write the report to this file instead of stdout",
+						},
+						cli.StringFlag{
+							Name:  "verify-key",
+							Usage: "PEM public key file to verify the attestation of the report with, exit 2 if it does not verify",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:  "fleet",
+			Usage: "run and follow ClusterScans on several clusters, merging their reports into a fleet report",
+			Subcommands: []cli.Command{
+				{
+					Name:      "run",
+					Usage:     "create a ClusterScan on every cluster, wait for them and print the fleet report",
+					ArgsUsage: " ",
+					Action:    runFleetScan,
+					Flags:     append(append([]cli.Flag{}, fleetFlags...), scanFlags...),
+				},
+				{
+					Name:      "wait",
+					Usage:     "wait for the ClusterScan of this name on every cluster and print the fleet report",
+					ArgsUsage: "<scan>",
+					Action:    waitFleetScan,
+					Flags:     fleetFlags,
+				},
+			},
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+// newClient builds the cis client from the global --kubeconfig flag, or the in-cluster config
+func newClient(c *cli.Context) (*cisclient.Client, error) {
+	cfg, err := kubeconfig.GetNonInteractiveClientConfig(c.GlobalString("kubeconfig")).ClientConfig()
+	if err != nil {
+		return nil, cli.NewExitError("failed to find kubeconfig: "+err.Error(), exitCodeError)
+	}
+	client, err := cisclient.NewForConfig(cfg)
+	if err != nil {
+		return nil, cli.NewExitError(err.Error(), exitCodeError)
+	}
+	return client, nil
+}
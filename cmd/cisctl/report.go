@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	cisclient "github.com/rancher/cis-operator/pkg/client"
+)
+
+func getReport(c *cli.Context) error {
+	scanName := c.Args().First()
+	if scanName == "" {
+		return cli.NewExitError("the name of the scan is required", exitCodeError)
+	}
+	client, err := newClient(c)
+	if err != nil {
+		return err
+	}
+	report, err := client.FetchReport(scanName)
+	if err != nil {
+		return cli.NewExitError(err.Error(), exitCodeError)
+	}
+	if verifyKey := c.String("verify-key"); verifyKey != "" {
+		key, err := os.ReadFile(verifyKey)
+		if err != nil {
+			return cli.NewExitError(fmt.Sprintf("Error reading verify key: %v", err), exitCodeError)
+		}
+		if _, err := cisclient.VerifyReportAttestation(report, key); err != nil {
+			return cli.NewExitError(err.Error(), exitCodeError)
+		}
+	}
+	var out bytes.Buffer
+	if err := cisclient.RenderReport(&out, report, c.String("output")); err != nil {
+		return cli.NewExitError(err.Error(), exitCodeError)
+	}
+	if outputFile := c.String("output-file"); outputFile != "" {
+		if err := os.WriteFile(outputFile, out.Bytes(), 0644); err != nil {
+			return cli.NewExitError(fmt.Sprintf("Error writing report: %v", err), exitCodeError)
+		}
+		return nil
+	}
+	_, err = out.WriteTo(os.Stdout)
+	return err
+}
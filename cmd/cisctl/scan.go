@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rancher/wrangler/pkg/signals"
+	"github.com/urfave/cli"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	cisclient "github.com/rancher/cis-operator/pkg/client"
+)
+
+func runScan(c *cli.Context) error {
+	client, err := newClient(c)
+	if err != nil {
+		return err
+	}
+	scan, err := buildScan(c)
+	if err != nil {
+		return err
+	}
+	scan, err = client.CreateScan(scan)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("Error creating ClusterScan: %v", err), exitCodeError)
+	}
+	fmt.Fprintf(os.Stderr, "Created ClusterScan %v\n", scan.Name)
+	if !c.Bool("wait") {
+		fmt.Println(scan.Name)
+		return nil
+	}
+
+	scan, err = waitForScan(c, client, scan.Name)
+	if err != nil {
+		return err
+	}
+	report, err := client.FetchReport(scan.Name)
+	if err != nil {
+		return cli.NewExitError(err.Error(), exitCodeError)
+	}
+	if err := cisclient.RenderReport(os.Stdout, report, c.String("output")); err != nil {
+		return cli.NewExitError(err.Error(), exitCodeError)
+	}
+	return getVerdict(scan)
+}
+
+// buildScan builds the ClusterScan from the scan flags of the command
+func buildScan(c *cli.Context) (*v1.ClusterScan, error) {
+	builder := cisclient.NewScan().WithProfile(c.String("profile"))
+	if name := c.String("name"); name != "" {
+		builder.WithName(name)
+	}
+	if selector := c.String("node-selector"); selector != "" {
+		nodeSelector, err := labels.ConvertSelectorToLabelsMap(selector)
+		if err != nil {
+			return nil, cli.NewExitError(fmt.Sprintf("invalid --node-selector: %v", err), exitCodeError)
+		}
+		builder.WithNodeSelector(nodeSelector)
+	}
+	if maxNodes := c.Int("max-nodes"); maxNodes > 0 {
+		builder.WithMaxNodes(maxNodes)
+	}
+	if nodeNames := c.String("node-names"); nodeNames != "" {
+		builder.WithNodeNames(strings.Split(nodeNames, ","))
+	}
+	if kubeletVersionRange := c.String("kubelet-version-range"); kubeletVersionRange != "" {
+		builder.WithKubeletVersionRange(kubeletVersionRange)
+	}
+	if osImages := c.String("os-images"); osImages != "" {
+		builder.WithOSImages(strings.Split(osImages, ","))
+	}
+	if includeChecks := c.String("include-checks"); includeChecks != "" {
+		builder.WithIncludeChecks(strings.Split(includeChecks, ","))
+	}
+	if scanLabels := c.String("labels"); scanLabels != "" {
+		labelMap, err := labels.ConvertSelectorToLabelsMap(scanLabels)
+		if err != nil {
+			return nil, cli.NewExitError(fmt.Sprintf("invalid --labels: %v", err), exitCodeError)
+		}
+		builder.WithLabels(labelMap)
+	}
+	scan, err := builder.Build()
+	if err != nil {
+		return nil, cli.NewExitError(fmt.Sprintf("invalid scan: %v", err), exitCodeError)
+	}
+	return scan, nil
+}
+
+func waitScan(c *cli.Context) error {
+	scanName := c.Args().First()
+	if scanName == "" {
+		return cli.NewExitError("the name of the scan is required", exitCodeError)
+	}
+	client, err := newClient(c)
+	if err != nil {
+		return err
+	}
+	scan, err := waitForScan(c, client, scanName)
+	if err != nil {
+		return err
+	}
+	if summary := scan.Status.Summary; summary != nil {
+		fmt.Printf("Total: %d Pass: %d Fail: %d Skip: %d Warn: %d N/A: %d\n", summary.Total, summary.Pass, summary.Fail, summary.Skip, summary.Warn, summary.NotApplicable)
+	}
+	return getVerdict(scan)
+}
+
+// waitForScan waits for the scan to complete within the --timeout of the command
+func waitForScan(c *cli.Context, client *cisclient.Client, scanName string) (*v1.ClusterScan, error) {
+	ctx, cancel := context.WithTimeout(signals.SetupSignalContext(), c.Duration("timeout"))
+	defer cancel()
+	fmt.Fprintf(os.Stderr, "Waiting for ClusterScan %v to complete\n", scanName)
+	scan, err := client.WaitForScanCompletion(ctx, scanName)
+	if err != nil {
+		return nil, cli.NewExitError(err.Error(), exitCodeError)
+	}
+	return scan, nil
+}
+
+// getVerdict fails with exitCodeChecksFailed when the scan failed checks
+func getVerdict(scan *v1.ClusterScan) error {
+	if scan.Status.Summary != nil && scan.Status.Summary.Fail > 0 {
+		return cli.NewExitError(fmt.Sprintf("scan %v failed %d checks", scan.Name, scan.Status.Summary.Fail), exitCodeChecksFailed)
+	}
+	return nil
+}
+
+func listScans(c *cli.Context) error {
+	client, err := newClient(c)
+	if err != nil {
+		return err
+	}
+	scans, err := client.Scans.List(metav1.ListOptions{})
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("Error listing ClusterScans: %v", err), exitCodeError)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tPROFILE\tSTATE\tLAST RUN\tFAIL")
+	for _, scan := range scans.Items {
+		profile := scan.Spec.ScanProfileName
+		if scan.Status.LastRunScanProfileName != "" {
+			profile = scan.Status.LastRunScanProfileName
+		}
+		state := ""
+		if scan.Status.Display != nil {
+			state = scan.Status.Display.State
+		}
+		fail := ""
+		if scan.Status.Summary != nil {
+			fail = fmt.Sprint(scan.Status.Summary.Fail)
+		}
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\n", scan.Name, profile, state, scan.Status.LastRunTimestamp, fail)
+	}
+	return tw.Flush()
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/rancher/wrangler/pkg/kubeconfig"
@@ -23,7 +24,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	cisoperatorapiv1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	cisdebug "github.com/rancher/cis-operator/pkg/debug"
+	"github.com/rancher/cis-operator/pkg/fips"
+	"github.com/rancher/cis-operator/pkg/health"
+	"github.com/rancher/cis-operator/pkg/logging"
 	cisoperator "github.com/rancher/cis-operator/pkg/securityscan"
+	"github.com/rancher/cis-operator/pkg/tracing"
 
 	// Automatically sets fallback trusted x509 roots, in case they are
 	// not available at runtime. This is required to establish trust
@@ -46,12 +52,55 @@ var (
 	metricsPort                   string
 	alertSeverity                 string
 	debug                         bool
+	debugAddress                  string
+	logFormat                     string
+	logLevel                      string
 	securityScanImage             string
 	securityScanImageTag          string
+	windowsSecurityScanImage      string
+	windowsSecurityScanImageTag   string
 	sonobuoyImage                 string
 	sonobuoyImageTag              string
 	clusterName                   string
 	securityScanJobTolerationsVal string
+	securityScanJobAffinityVal    string
+	securityScanJobTopologyVal    string
+	securityScanJobResourcesVal   string
+	securityScanJobPodSecCtxVal   string
+	securityScanJobSecCtxVal      string
+	securityScanJobPriorityClass  string
+	imageRegistry                 string
+	imagePullSecrets              string
+	imageVerificationKeyFile      string
+	reportSigningKeyFile          string
+	reportAPIConfig               reportAPIOptions
+	webhookConfig                 webhookOptions
+	proxyConfig                   cisoperatorapiv1.ProxyConfig
+	reportBaseURL                 string
+	subscriptionNamespaces        string
+	checkOwnersConfigMap          string
+	defaultSkipsConfigMap         string
+	defaultProfilesConfigMap      string
+	maxConcurrentScans            int
+	shardCount                    int
+	shardIndex                    int
+	upgradeScanName               string
+	upgradeCheckInterval          time.Duration
+	reportRetentionDays           int
+	reportCompressionThreshold    int
+	reportShardSize               int
+	trendWindow                   int
+	scanEventLogPath              string
+	scanEventLogMaxSizeMB         int
+	scanEventLogMaxBackups        int
+	otlpEndpoint                  string
+	otlpHeaders                   string
+	scanHostPathAllowlist         string
+	reportPlugins                 string
+	fleetNamespaces               string
+	reportPluginTimeout           time.Duration
+	faultInjectionFailureRate     float64
+	faultInjectionMaxDelay        time.Duration
 )
 
 func main() {
@@ -87,8 +136,49 @@ func main() {
 			Name:        "security-scan-image-tag",
 			EnvVar:      "SECURITY_SCAN_IMAGE_TAG",
 			Value:       "latest",
+			Usage:       "image tag, or sha256:<digest> to pin the image by digest",
 			Destination: &securityScanImageTag,
 		},
+		cli.StringFlag{
+			Name:        "windows-security-scan-image",
+			EnvVar:      "WINDOWS_SECURITY_SCAN_IMAGE",
+			Value:       "",
+			Destination: &windowsSecurityScanImage,
+		},
+		cli.StringFlag{
+			Name:        "windows-security-scan-image-tag",
+			EnvVar:      "WINDOWS_SECURITY_SCAN_IMAGE_TAG",
+			Value:       "latest",
+			Usage:       "image tag, or sha256:<digest> to pin the image by digest",
+			Destination: &windowsSecurityScanImageTag,
+		},
+		cli.StringFlag{
+			Name:        "image-registry",
+			EnvVar:      "CIS_IMAGE_REGISTRY",
+			Value:       "",
+			Destination: &imageRegistry,
+		},
+		cli.StringFlag{
+			Name:        "image-pull-secrets",
+			EnvVar:      "CIS_IMAGE_PULL_SECRETS",
+			Value:       "",
+			Usage:       "comma separated list of secrets in the scan namespace used to pull the scan images",
+			Destination: &imagePullSecrets,
+		},
+		cli.StringFlag{
+			Name:        "image-verification-key",
+			EnvVar:      "CIS_IMAGE_VERIFICATION_KEY",
+			Value:       "",
+			Usage:       "path to a cosign public key, the scan images must then be pinned by digest and signed with it",
+			Destination: &imageVerificationKeyFile,
+		},
+		cli.StringFlag{
+			Name:        "report-signing-key",
+			EnvVar:      "CIS_REPORT_SIGNING_KEY",
+			Value:       "",
+			Usage:       "path to an unencrypted PEM private key the attestations of the reports are signed with",
+			Destination: &reportSigningKeyFile,
+		},
 		cli.StringFlag{
 			Name:        "sonobuoy-image",
 			EnvVar:      "SONOBUOY_IMAGE",
@@ -99,12 +189,14 @@ func main() {
 			Name:        "sonobuoy-image-tag",
 			EnvVar:      "SONOBUOY_IMAGE_TAG",
 			Value:       "latest",
+			Usage:       "image tag, or sha256:<digest> to pin the image by digest",
 			Destination: &sonobuoyImageTag,
 		},
 		cli.StringFlag{
 			Name:        "cis_metrics_port",
 			EnvVar:      "CIS_METRICS_PORT",
 			Value:       "8080",
+			Usage:       "port serving /metrics, /healthz and /readyz",
 			Destination: &metricsPort,
 		},
 		cli.BoolFlag{
@@ -112,6 +204,26 @@ func main() {
 			EnvVar:      "CIS_OPERATOR_DEBUG",
 			Destination: &debug,
 		},
+		cli.StringFlag{
+			Name:        "log-format",
+			EnvVar:      "CIS_LOG_FORMAT",
+			Value:       logging.FormatText,
+			Usage:       "format of the logs, text or json",
+			Destination: &logFormat,
+		},
+		cli.StringFlag{
+			Name:        "log-level",
+			EnvVar:      "CIS_LOG_LEVEL",
+			Value:       "info",
+			Usage:       "level of the logs, e.g. debug, overridden by the OperatorConfig and changed at runtime with SIGUSR1 and SIGUSR2",
+			Destination: &logLevel,
+		},
+		cli.StringFlag{
+			Name:        "debug-address",
+			EnvVar:      "CIS_DEBUG_ADDRESS",
+			Usage:       "address of the debug listener serving pprof, goroutine dumps and workqueue stats, e.g. 127.0.0.1:6060, disabled if empty",
+			Destination: &debugAddress,
+		},
 		cli.StringFlag{
 			Name:        "alertSeverity",
 			EnvVar:      "CIS_ALERTS_SEVERITY",
@@ -130,12 +242,303 @@ func main() {
 			Value:       "",
 			Destination: &securityScanJobTolerationsVal,
 		},
+		cli.StringFlag{
+			Name:        "security-scan-job-node-affinity",
+			EnvVar:      "SECURITY_SCAN_JOB_NODE_AFFINITY",
+			Value:       "",
+			Destination: &securityScanJobAffinityVal,
+		},
+		cli.StringFlag{
+			Name:        "security-scan-job-topology-spread-constraints",
+			EnvVar:      "SECURITY_SCAN_JOB_TOPOLOGY_SPREAD_CONSTRAINTS",
+			Value:       "",
+			Destination: &securityScanJobTopologyVal,
+		},
+		cli.StringFlag{
+			Name:        "security-scan-job-resources",
+			EnvVar:      "SECURITY_SCAN_JOB_RESOURCES",
+			Value:       "",
+			Destination: &securityScanJobResourcesVal,
+		},
+		cli.StringFlag{
+			Name:        "security-scan-job-pod-security-context",
+			EnvVar:      "SECURITY_SCAN_JOB_POD_SECURITY_CONTEXT",
+			Value:       "",
+			Usage:       "JSON pod security context of the scan aggregator pod, overrides the hardened default",
+			Destination: &securityScanJobPodSecCtxVal,
+		},
+		cli.StringFlag{
+			Name:        "security-scan-job-security-context",
+			EnvVar:      "SECURITY_SCAN_JOB_SECURITY_CONTEXT",
+			Value:       "",
+			Usage:       "JSON security context of the scan aggregator container, overrides the hardened default",
+			Destination: &securityScanJobSecCtxVal,
+		},
+		cli.StringFlag{
+			Name:        "security-scan-job-priority-class-name",
+			EnvVar:      "SECURITY_SCAN_JOB_PRIORITY_CLASS_NAME",
+			Value:       "",
+			Destination: &securityScanJobPriorityClass,
+		},
+		cli.StringFlag{
+			Name:        "security-scan-job-host-path-allowlist",
+			EnvVar:      "SECURITY_SCAN_JOB_HOST_PATH_ALLOWLIST",
+			Value:       "",
+			Usage:       "comma separated host paths the ClusterScan volumes may mount, or paths under them",
+			Destination: &scanHostPathAllowlist,
+		},
+		cli.StringFlag{
+			Name:        "http-proxy",
+			EnvVar:      "CIS_HTTP_PROXY",
+			Value:       "",
+			Usage:       "proxy used by the scan pods and the operator for http requests",
+			Destination: &proxyConfig.HTTPProxy,
+		},
+		cli.StringFlag{
+			Name:        "https-proxy",
+			EnvVar:      "CIS_HTTPS_PROXY",
+			Value:       "",
+			Usage:       "proxy used by the scan pods and the operator for https requests",
+			Destination: &proxyConfig.HTTPSProxy,
+		},
+		cli.StringFlag{
+			Name:        "no-proxy",
+			EnvVar:      "CIS_NO_PROXY",
+			Value:       "",
+			Usage:       "hosts not to proxy, should include the cluster service CIDR so that the scans reach the API server",
+			Destination: &proxyConfig.NoProxy,
+		},
+		cli.StringFlag{
+			Name:        "report-base-url",
+			EnvVar:      "CIS_REPORT_BASE_URL",
+			Value:       "",
+			Usage:       "external URL of the report API, alerts and notifications link to the reports when set",
+			Destination: &reportBaseURL,
+		},
+		cli.StringFlag{
+			Name:        "subscription-configmap-namespaces",
+			EnvVar:      "CIS_SUBSCRIPTION_CONFIGMAP_NAMESPACES",
+			Value:       "",
+			Usage:       "comma separated namespaces besides the operator one the ConfigMap targets of the ScanSubscriptions may be written to",
+			Destination: &subscriptionNamespaces,
+		},
+		cli.StringFlag{
+			Name:        "check-owners-configmap",
+			EnvVar:      "CIS_CHECK_OWNERS_CONFIGMAP",
+			Value:       "",
+			Usage:       "ConfigMap in the operator namespace mapping teams to the check IDs and sections they own",
+			Destination: &checkOwnersConfigMap,
+		},
+		cli.StringFlag{
+			Name:        "default-skips-configmap",
+			EnvVar:      "CIS_DEFAULT_SKIPS_CONFIGMAP",
+			Value:       "",
+			Usage:       "ConfigMap in the operator namespace listing the tests skipped by default per benchmark version, synced into the profiles with followDefaults",
+			Destination: &defaultSkipsConfigMap,
+		},
+		cli.StringFlag{
+			Name:        "default-profiles-configmap",
+			EnvVar:      "CIS_DEFAULT_PROFILES_CONFIGMAP",
+			Value:       cisoperatorapiv1.DefaultClusterScanProfileConfigMap,
+			Usage:       "ConfigMap in the operator namespace mapping the cluster providers to the ClusterScanProfile scans without a profile run",
+			Destination: &defaultProfilesConfigMap,
+		},
+		cli.IntFlag{
+			Name:        "max-concurrent-scans",
+			EnvVar:      "CIS_MAX_CONCURRENT_SCANS",
+			Value:       1,
+			Usage:       "maximum number of scans running at once, the other scans are queued and launched in creation order",
+			Destination: &maxConcurrentScans,
+		},
+		cli.IntFlag{
+			Name:        "shard-count",
+			EnvVar:      "CIS_SHARD_COUNT",
+			Value:       1,
+			Usage:       "number of replicas of the operator the scans are sharded across by the hash of their name",
+			Destination: &shardCount,
+		},
+		cli.IntFlag{
+			Name:        "shard-index",
+			EnvVar:      "CIS_SHARD_INDEX",
+			Value:       0,
+			Usage:       "shard of the scans handled by this replica, from 0 to shard-count - 1",
+			Destination: &shardIndex,
+		},
+		cli.StringFlag{
+			Name:        "upgrade-scan-name",
+			EnvVar:      "CIS_UPGRADE_SCAN_NAME",
+			Value:       "",
+			Usage:       "ClusterScan to run again when the Kubernetes version of the cluster changes",
+			Destination: &upgradeScanName,
+		},
+		cli.DurationFlag{
+			Name:        "upgrade-check-interval",
+			EnvVar:      "CIS_UPGRADE_CHECK_INTERVAL",
+			Value:       5 * time.Minute,
+			Usage:       "how often the Kubernetes version of the cluster is checked for upgrades, with --upgrade-scan-name",
+			Destination: &upgradeCheckInterval,
+		},
+		cli.IntFlag{
+			Name:        "report-retention-days",
+			EnvVar:      "CIS_REPORT_RETENTION_DAYS",
+			Value:       0,
+			Usage:       "delete the reports this many days after their creation, unless their scan sets retentionDays, 0 keeps them",
+			Destination: &reportRetentionDays,
+		},
+		cli.IntFlag{
+			Name:        "report-compression-threshold",
+			EnvVar:      "CIS_REPORT_COMPRESSION_THRESHOLD",
+			Value:       cisoperatorapiv1.DefaultReportCompressionThreshold,
+			Usage:       "store the report JSON gzip compressed when larger than this many bytes, 0 compresses all reports, -1 none",
+			Destination: &reportCompressionThreshold,
+		},
+		cli.IntFlag{
+			Name:        "report-shard-size",
+			EnvVar:      "CIS_REPORT_SHARD_SIZE",
+			Value:       cisoperatorapiv1.DefaultReportShardSize,
+			Usage:       "shard the nodes of the reports covering more nodes than this in ClusterScanReportShards of this many nodes, 0 never shards",
+			Destination: &reportShardSize,
+		},
+		cli.IntFlag{
+			Name:        "trend-window",
+			EnvVar:      "CIS_TREND_WINDOW",
+			Value:       cisoperatorapiv1.DefaultTrendWindow,
+			Usage:       "number of runs kept in the trend of the ClusterScan status and metrics, 0 disables the trend",
+			Destination: &trendWindow,
+		},
+		cli.StringFlag{
+			Name:        "scan-event-log-path",
+			EnvVar:      "CIS_SCAN_EVENT_LOG_PATH",
+			Value:       "",
+			Usage:       "file the scan lifecycle and result summaries are written to as Kubernetes audit events in JSON lines, e.g. on a mounted PVC",
+			Destination: &scanEventLogPath,
+		},
+		cli.IntFlag{
+			Name:        "scan-event-log-max-size",
+			EnvVar:      "CIS_SCAN_EVENT_LOG_MAX_SIZE",
+			Value:       cisoperatorapiv1.DefaultScanEventLogMaxSizeMB,
+			Usage:       "size in megabytes the scan event log is rotated at",
+			Destination: &scanEventLogMaxSizeMB,
+		},
+		cli.IntFlag{
+			Name:        "scan-event-log-max-backups",
+			EnvVar:      "CIS_SCAN_EVENT_LOG_MAX_BACKUPS",
+			Value:       cisoperatorapiv1.DefaultScanEventLogMaxBackups,
+			Usage:       "number of rotated scan event log files kept",
+			Destination: &scanEventLogMaxBackups,
+		},
+		cli.StringFlag{
+			Name:        "otlp-endpoint",
+			EnvVar:      "CIS_OTLP_ENDPOINT,OTEL_EXPORTER_OTLP_ENDPOINT",
+			Value:       "",
+			Usage:       "OTLP/HTTP endpoint of the collector the spans of the scan runs are exported to, e.g. http://otel-collector:4318, disabled if empty",
+			Destination: &otlpEndpoint,
+		},
+		cli.StringFlag{
+			Name:        "otlp-headers",
+			EnvVar:      "CIS_OTLP_HEADERS,OTEL_EXPORTER_OTLP_HEADERS",
+			Value:       "",
+			Usage:       "comma separated key=value headers of the requests to the OTLP endpoint, e.g. to authenticate",
+			Destination: &otlpHeaders,
+		},
+		cli.StringFlag{
+			Name:        "report-plugins",
+			EnvVar:      "CIS_REPORT_PLUGINS",
+			Value:       "",
+			Usage:       "comma separated list of executables the report JSON is piped through, in order, before the reports are stored",
+			Destination: &reportPlugins,
+		},
+		cli.DurationFlag{
+			Name:        "report-plugin-timeout",
+			EnvVar:      "CIS_REPORT_PLUGIN_TIMEOUT",
+			Value:       30 * time.Second,
+			Usage:       "how long a report plugin may run on a report",
+			Destination: &reportPluginTimeout,
+		},
+		cli.Float64Flag{
+			Name:        "fault-injection-failure-rate",
+			EnvVar:      "CIS_FAULT_INJECTION_FAILURE_RATE",
+			Usage:       "test only: rate, from 0 to 1, at which reading the scan results and delivering to the sinks fail",
+			Destination: &faultInjectionFailureRate,
+		},
+		cli.DurationFlag{
+			Name:        "fault-injection-max-delay",
+			EnvVar:      "CIS_FAULT_INJECTION_MAX_DELAY",
+			Usage:       "test only: maximum random delay of reading the scan results and delivering to the sinks",
+			Destination: &faultInjectionMaxDelay,
+		},
+		cli.BoolFlag{
+			Name:   "air-gapped",
+			EnvVar: "CIS_AIR_GAPPED",
+			Usage:  "disable all requests leaving the cluster network",
+		},
+		cli.BoolFlag{
+			Name:   "fips",
+			EnvVar: "CIS_FIPS_MODE",
+			Usage:  "refuse the TLS settings and signature keys that are not FIPS approved, requires a build-fips binary",
+		},
+		cli.BoolFlag{
+			Name:   "self-check",
+			EnvVar: "CIS_SELF_CHECK",
+			Usage:  "check the operator deployment, RBAC and scan workloads against hardening rules and add the findings to the reports",
+		},
+		cli.BoolFlag{
+			Name:   "omit-remediations",
+			EnvVar: "CIS_OMIT_REMEDIATIONS",
+			Usage:  "leave the remediation texts out of the reports to keep them small",
+		},
+		cli.BoolFlag{
+			Name:   "remediation-enabled",
+			EnvVar: "CIS_REMEDIATION_ENABLED",
+			Usage:  "launch the workloads of the ClusterScanRemediations for the checks failing in the scans",
+		},
+		cli.BoolFlag{
+			Name:   "node-actions-enabled",
+			EnvVar: "CIS_NODE_ACTIONS_ENABLED",
+			Usage:  "taint or label the nodes failing the critical checks of the scans with a nodeAction",
+		},
+		cli.BoolFlag{
+			Name:   "fleet-hub",
+			EnvVar: "CIS_FLEET_HUB",
+			Usage:  "sync the ClusterScanTemplates to the downstream clusters registered with Fleet",
+		},
+		cli.StringFlag{
+			Name:        "fleet-namespaces",
+			EnvVar:      "CIS_FLEET_NAMESPACES",
+			Value:       cisoperatorapiv1.DefaultFleetNamespace,
+			Usage:       "comma separated namespaces of the Fleet Clusters and their kubeconfig secrets, with --fleet-hub",
+			Destination: &fleetNamespaces,
+		},
 		cli.BoolFlag{
 			Name:   "alertEnabled",
 			EnvVar: "CIS_ALERTS_ENABLED",
 		},
 	}
+	app.Flags = append(app.Flags, reportAPIFlags(&reportAPIConfig)...)
+	app.Flags = append(app.Flags, webhookFlags(&webhookConfig)...)
 	app.Action = run
+	app.Commands = []cli.Command{
+		{
+			Name:   "run-once",
+			Usage:  "run a single scan and exit with its verdict, without the operator",
+			Action: runOnce,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "profile",
+					Usage: "name of the ClusterScanProfile to run",
+				},
+				cli.DurationFlag{
+					Name:  "timeout",
+					Usage: "maximum time to wait for the scan to complete",
+					Value: time.Hour,
+				},
+				cli.StringFlag{
+					Name:  "report-file",
+					Usage: "write the JSON report to this file",
+				},
+			},
+		},
+	}
 
 	if err := app.Run(os.Args); err != nil {
 		logrus.Fatal(err)
@@ -143,6 +546,12 @@ func main() {
 }
 
 func run(c *cli.Context) {
+	if debug {
+		logLevel = logrus.DebugLevel.String()
+	}
+	if err := logging.Configure(logFormat, logLevel); err != nil {
+		logrus.Fatalf("Error configuring the logs: %v", err)
+	}
 	logrus.Info("Starting CIS-Operator")
 
 	ctx := context.Background()
@@ -152,61 +561,135 @@ func run(c *cli.Context) {
 		ctx.Done()
 	}()
 
-	if debug {
-		logrus.SetLevel(logrus.DebugLevel)
+	logging.HandleSignals(handler)
+	if c.Bool("fips") {
+		if err := fips.Enable(); err != nil {
+			logrus.Fatalf("Error enabling the FIPS mode: %v", err)
+		}
+		logrus.Info("Running in FIPS mode")
 	}
 	kubeConfig = c.String("kubeconfig")
 	threads = c.Int("threads")
 	securityScanImage = c.String("security-scan-image")
 	securityScanImageTag = c.String("security-scan-image-tag")
+	windowsSecurityScanImage = c.String("windows-security-scan-image")
+	windowsSecurityScanImageTag = c.String("windows-security-scan-image-tag")
 	sonobuoyImage = c.String("sonobuoy-image")
 	sonobuoyImageTag = c.String("sonobuoy-image-tag")
 	name = c.String("name")
 
-	securityScanJobTolerations := []corev1.Toleration{{
-		Operator: corev1.TolerationOpExists,
-	}}
-
-	securityScanJobTolerationsVal = c.String("security-scan-job-tolerations")
-
-	if securityScanJobTolerationsVal != "" {
-		err := json.Unmarshal([]byte(securityScanJobTolerationsVal), &securityScanJobTolerations)
-		if err != nil {
-			logrus.Fatalf("invalid value received for security-scan-job-tolerations flag:%s", err.Error())
-		}
-	}
+	scanPodConfig := getScanPodConfig()
 
 	kubeConfig, err := kubeconfig.GetNonInteractiveClientConfig(kubeConfig).ClientConfig()
 	if err != nil {
 		logrus.Fatalf("failed to find kubeconfig: %v", err)
 	}
 
-	imgConfig := &cisoperatorapiv1.ScanImageConfig{
-		SecurityScanImage:    securityScanImage,
-		SecurityScanImageTag: securityScanImageTag,
-		SonobuoyImage:        sonobuoyImage,
-		SonobuoyImageTag:     sonobuoyImageTag,
-		AlertSeverity:        alertSeverity,
-		ClusterName:          clusterName,
-		AlertEnabled:         c.Bool("alertEnabled"),
-	}
+	imgConfig := getScanImageConfig(c.Bool("alertEnabled"))
+	imgConfig.AirGapped = c.Bool("air-gapped")
+	imgConfig.SelfCheck = c.Bool("self-check")
+	imgConfig.OmitRemediations = c.Bool("omit-remediations")
+	imgConfig.RemediationEnabled = c.Bool("remediation-enabled")
+	imgConfig.NodeActionsEnabled = c.Bool("node-actions-enabled")
+	imgConfig.FleetHub = c.Bool("fleet-hub")
+	imgConfig.FleetNamespaces = splitList(fleetNamespaces)
 
 	if err := validateConfig(imgConfig); err != nil {
 		logrus.Fatalf("Error starting CIS-Operator: %v", err)
 	}
+	if imgConfig.FaultInjectionFailureRate > 0 || imgConfig.FaultInjectionMaxDelay > 0 {
+		logrus.Warnf("Fault injection enabled, failure rate %v and max delay %v: not for production use", imgConfig.FaultInjectionFailureRate, imgConfig.FaultInjectionMaxDelay)
+	}
+	if imgConfig.ShardCount > 1 {
+		logrus.Infof("Handling shard %v of %v of the scans", imgConfig.ShardIndex, imgConfig.ShardCount)
+	}
+
+	if imageVerificationKeyFile != "" {
+		key, err := os.ReadFile(imageVerificationKeyFile)
+		if err != nil {
+			logrus.Fatalf("Error reading image verification key: %v", err)
+		}
+		imgConfig.ImageVerificationKey = string(key)
+	}
+	if reportSigningKeyFile != "" {
+		key, err := os.ReadFile(reportSigningKeyFile)
+		if err != nil {
+			logrus.Fatalf("Error reading report signing key: %v", err)
+		}
+		imgConfig.ReportSigningKey = string(key)
+	}
 
-	ctl, err := cisoperator.NewController(ctx, kubeConfig, cisoperatorapiv1.ClusterScanNS, name, imgConfig, securityScanJobTolerations)
+	ctl, err := cisoperator.NewController(ctx, kubeConfig, cisoperatorapiv1.ClusterScanNS, name, imgConfig, scanPodConfig)
 	if err != nil {
 		logrus.Fatalf("Error building controller: %s", err.Error())
 	}
 
+	if reportAPIConfig.port != "" {
+		reportAPI, err := newReportAPIServer(&reportAPIConfig, ctl, scanPodConfig.Proxy)
+		if err != nil {
+			logrus.Fatalf("Error building report API: %v", err)
+		}
+		go func() {
+			if err := serveReportAPI(reportAPI); err != nil {
+				logrus.Fatalf("Error serving report API: %v", err)
+			}
+		}()
+	}
+
+	var webhookServer, externalDataServer *http.Server
+	if webhookConfig.port != "" {
+		webhookServer, externalDataServer, err = newWebhookServer(&webhookConfig, ctl)
+		if err != nil {
+			logrus.Fatalf("Error building webhook: %v", err)
+		}
+	}
+
+	// served while the caches sync, /readyz fails until they are
+	healthz, readyz := &health.Checks{}, &health.Checks{}
+	healthz.Add("ping", func() error { return nil })
+	readyz.Add("informers", ctl.CheckCachesSynced)
+	readyz.Add("crds", ctl.CheckCRDs)
+	mux := http.NewServeMux()
+	health.Register(mux, healthz, readyz)
+	mux.Handle("/metrics", promhttp.Handler())
+	go func() {
+		if err := http.ListenAndServe(":"+metricsPort, mux); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	if debugAddress != "" {
+		// the workqueues are created when the controllers start
+		queues := &cisdebug.WorkqueueProvider{}
+		if !queues.Register() {
+			logrus.Warnf("Another workqueue metrics provider is set, %v serves no workqueue stats", cisdebug.WorkqueuesPath)
+		}
+		debugServer := cisdebug.NewServer(debugAddress, queues)
+		logrus.Infof("Serving the debug endpoints on %v", debugAddress)
+		go func() {
+			if err := debugServer.ListenAndServe(); err != nil {
+				logrus.Fatalf("Error serving debug endpoints: %v", err)
+			}
+		}()
+	}
+
 	if err := ctl.Start(ctx, threads, 2*time.Hour); err != nil {
 		logrus.Fatalf("Error starting: %v", err)
 	}
 
-	http.Handle("/metrics", promhttp.Handler())
-	if err := http.ListenAndServe(":"+metricsPort, nil); err != nil {
-		log.Fatal(err)
+	if webhookServer != nil {
+		go func() {
+			if err := webhookServer.ListenAndServeTLS("", ""); err != nil {
+				logrus.Fatalf("Error serving webhook: %v", err)
+			}
+		}()
+	}
+	if externalDataServer != nil {
+		go func() {
+			if err := externalDataServer.ListenAndServeTLS("", ""); err != nil {
+				logrus.Fatalf("Error serving external data provider: %v", err)
+			}
+		}()
 	}
 
 	<-handler
@@ -214,6 +697,112 @@ func run(c *cli.Context) {
 	logrus.Info("Registered CIS controller")
 }
 
+func getScanImageConfig(alertEnabled bool) *cisoperatorapiv1.ScanImageConfig {
+	return &cisoperatorapiv1.ScanImageConfig{
+		SecurityScanImage:           securityScanImage,
+		SecurityScanImageTag:        securityScanImageTag,
+		WindowsSecurityScanImage:    windowsSecurityScanImage,
+		WindowsSecurityScanImageTag: windowsSecurityScanImageTag,
+		SonobuoyImage:               sonobuoyImage,
+		SonobuoyImageTag:            sonobuoyImageTag,
+		AlertSeverity:               alertSeverity,
+		LogLevel:                    logLevel,
+		ClusterName:                 clusterName,
+		AlertEnabled:                alertEnabled,
+		Registry:                    imageRegistry,
+		ImagePullSecrets:            splitList(imagePullSecrets),
+		ReportBaseURL:               reportBaseURL,
+		SubscriptionNamespaces:      splitList(subscriptionNamespaces),
+		CheckOwnersConfigMap:        checkOwnersConfigMap,
+		DefaultSkipsConfigMap:       defaultSkipsConfigMap,
+		DefaultProfilesConfigMap:    defaultProfilesConfigMap,
+		MaxConcurrentScans:          maxConcurrentScans,
+		ShardCount:                  shardCount,
+		ShardIndex:                  shardIndex,
+		UpgradeScanName:             upgradeScanName,
+		UpgradeCheckInterval:        upgradeCheckInterval,
+		ReportRetentionDays:         reportRetentionDays,
+		ReportCompressionThreshold:  reportCompressionThreshold,
+		ReportShardSize:             reportShardSize,
+		TrendWindow:                 trendWindow,
+		ScanEventLogPath:            scanEventLogPath,
+		ScanEventLogMaxSizeMB:       scanEventLogMaxSizeMB,
+		ScanEventLogMaxBackups:      scanEventLogMaxBackups,
+		OTLPEndpoint:                otlpEndpoint,
+		OTLPHeaders:                 otlpHeaders,
+		ReportPlugins:               splitList(reportPlugins),
+		ReportPluginTimeout:         reportPluginTimeout,
+		FaultInjectionFailureRate:   faultInjectionFailureRate,
+		FaultInjectionMaxDelay:      faultInjectionMaxDelay,
+	}
+}
+
+func splitList(val string) []string {
+	var list []string
+	for _, item := range strings.Split(val, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func getScanPodConfig() *cisoperatorapiv1.ScanPodConfig {
+	securityScanJobTolerations := []corev1.Toleration{{
+		Operator: corev1.TolerationOpExists,
+	}}
+
+	if securityScanJobTolerationsVal != "" {
+		err := json.Unmarshal([]byte(securityScanJobTolerationsVal), &securityScanJobTolerations)
+		if err != nil {
+			logrus.Fatalf("invalid value received for security-scan-job-tolerations flag:%s", err.Error())
+		}
+	}
+
+	scanPodConfig := &cisoperatorapiv1.ScanPodConfig{
+		Tolerations:       securityScanJobTolerations,
+		PriorityClassName: securityScanJobPriorityClass,
+		Proxy:             proxyConfig,
+		HostPathAllowlist: splitList(scanHostPathAllowlist),
+	}
+
+	if securityScanJobAffinityVal != "" {
+		err := json.Unmarshal([]byte(securityScanJobAffinityVal), &scanPodConfig.NodeAffinity)
+		if err != nil {
+			logrus.Fatalf("invalid value received for security-scan-job-node-affinity flag:%s", err.Error())
+		}
+	}
+
+	if securityScanJobTopologyVal != "" {
+		err := json.Unmarshal([]byte(securityScanJobTopologyVal), &scanPodConfig.TopologySpreadConstraints)
+		if err != nil {
+			logrus.Fatalf("invalid value received for security-scan-job-topology-spread-constraints flag:%s", err.Error())
+		}
+	}
+
+	if securityScanJobResourcesVal != "" {
+		err := json.Unmarshal([]byte(securityScanJobResourcesVal), &scanPodConfig.Resources)
+		if err != nil {
+			logrus.Fatalf("invalid value received for security-scan-job-resources flag:%s", err.Error())
+		}
+	}
+
+	if securityScanJobPodSecCtxVal != "" {
+		err := json.Unmarshal([]byte(securityScanJobPodSecCtxVal), &scanPodConfig.AggregatorPodSecurityContext)
+		if err != nil {
+			logrus.Fatalf("invalid value received for security-scan-job-pod-security-context flag:%s", err.Error())
+		}
+	}
+
+	if securityScanJobSecCtxVal != "" {
+		err := json.Unmarshal([]byte(securityScanJobSecCtxVal), &scanPodConfig.AggregatorSecurityContext)
+		if err != nil {
+			logrus.Fatalf("invalid value received for security-scan-job-security-context flag:%s", err.Error())
+		}
+	}
+	return scanPodConfig
+}
+
 func validateConfig(imgConfig *cisoperatorapiv1.ScanImageConfig) error {
 	if imgConfig.SecurityScanImage == "" {
 		return errors.New("No Security-Scan Image specified")
@@ -221,5 +810,59 @@ func validateConfig(imgConfig *cisoperatorapiv1.ScanImageConfig) error {
 	if imgConfig.SonobuoyImage == "" {
 		return errors.New("No Sonobuoy tool Image specified")
 	}
+	if imgConfig.DefaultProfilesConfigMap == "" {
+		return errors.New("No default profiles ConfigMap specified")
+	}
+	if imgConfig.MaxConcurrentScans < 1 {
+		return errors.New("The maximum number of concurrent scans must be at least 1")
+	}
+	if imgConfig.ShardCount < 1 {
+		return errors.New("The shard count must be at least 1")
+	}
+	if imgConfig.ShardIndex < 0 || imgConfig.ShardIndex >= imgConfig.ShardCount {
+		return errors.New("The shard index must be between 0 and the shard count - 1")
+	}
+	if imgConfig.UpgradeScanName != "" && imgConfig.UpgradeCheckInterval <= 0 {
+		return errors.New("The upgrade check interval must be positive")
+	}
+	if imgConfig.ReportRetentionDays < 0 {
+		return errors.New("The report retention days must not be negative")
+	}
+	if imgConfig.ReportShardSize < 0 {
+		return errors.New("The report shard size must not be negative")
+	}
+	if imgConfig.TrendWindow < 0 {
+		return errors.New("The trend window must not be negative")
+	}
+	if imgConfig.ScanEventLogPath != "" && imgConfig.ScanEventLogMaxSizeMB < 1 {
+		return errors.New("The scan event log max size must be at least 1 megabyte")
+	}
+	if imgConfig.ScanEventLogMaxBackups < 0 {
+		return errors.New("The scan event log max backups must not be negative")
+	}
+	if imgConfig.OTLPEndpoint != "" && !strings.HasPrefix(imgConfig.OTLPEndpoint, "http://") && !strings.HasPrefix(imgConfig.OTLPEndpoint, "https://") {
+		return errors.New("The OTLP endpoint must be an http or https URL")
+	}
+	if _, err := tracing.ParseHeaders(imgConfig.OTLPHeaders); err != nil {
+		return err
+	}
+	if len(imgConfig.ReportPlugins) > 0 && imgConfig.ReportPluginTimeout <= 0 {
+		return errors.New("The report plugin timeout must be positive")
+	}
+	if imgConfig.AirGapped && imgConfig.OTLPEndpoint != "" {
+		return errors.New("The OTLP exporter sends the spans out of the operator, which is disabled in air-gapped mode: unset the OTLP endpoint")
+	}
+	if imgConfig.AirGapped && imgConfig.FleetHub {
+		return errors.New("The Fleet hub reaches the downstream clusters, which is disabled in air-gapped mode")
+	}
+	if imgConfig.FleetHub && len(imgConfig.FleetNamespaces) == 0 {
+		return errors.New("The Fleet namespaces must be set with the Fleet hub")
+	}
+	if imgConfig.FaultInjectionFailureRate < 0 || imgConfig.FaultInjectionFailureRate > 1 {
+		return errors.New("The fault injection failure rate must be between 0 and 1")
+	}
+	if imgConfig.FaultInjectionMaxDelay < 0 {
+		return errors.New("The fault injection max delay must not be negative")
+	}
 	return nil
 }
@@ -0,0 +1,64 @@
+package v1
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+// ReportEncodingGzip marks a reportJSON holding the gzip compressed report JSON, base64 encoded
+const ReportEncodingGzip = "gzip+base64"
+
+// DefaultReportShardSize is the number of nodes per ClusterScanReportShard of the sharded reports,
+// and the number of nodes above which the reports are sharded, by default
+const DefaultReportShardSize = 200
+
+// DefaultReportCompressionThreshold is the size of the report JSON, in bytes, above which the
+// reports are compressed by default
+const DefaultReportCompressionThreshold = 256 * 1024
+
+// SetReportJSON stores the report JSON, gzip compressed and base64 encoded if compress is set.
+func (s *ClusterScanReportSpec) SetReportJSON(data []byte, compress bool) error {
+	if !compress {
+		s.ReportJSON = string(data)
+		s.ReportEncoding = ""
+		return nil
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	s.ReportJSON = base64.StdEncoding.EncodeToString(buf.Bytes())
+	s.ReportEncoding = ReportEncodingGzip
+	return nil
+}
+
+// GetReportJSON returns the report JSON, decoded according to its reportEncoding.
+func (s *ClusterScanReportSpec) GetReportJSON() ([]byte, error) {
+	switch s.ReportEncoding {
+	case "":
+		return []byte(s.ReportJSON), nil
+	case ReportEncodingGzip:
+		compressed, err := base64.StdEncoding.DecodeString(s.ReportJSON)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding report: %w", err)
+		}
+		zr, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing report: %w", err)
+		}
+		defer zr.Close()
+		data, err := io.ReadAll(zr)
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing report: %w", err)
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("unsupported report encoding %q", s.ReportEncoding)
+}
@@ -1,8 +1,15 @@
 package v1
 
 import (
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
 	condition "github.com/rancher/cis-operator/pkg/condition"
 	"github.com/rancher/wrangler/pkg/genericcondition"
+	"github.com/rancher/wrangler/pkg/name"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -24,9 +31,25 @@ const (
 	DefaultScanOutputFileName          = "output.json"
 	DefaultRetention                   = 3
 	DefaultCronSchedule                = "0 0 * * *"
+	DefaultContinuousIntervalMinutes   = 15
+	DefaultRetryBackoffSeconds         = 60
+	DefaultRollupPeriodDays            = 7
+	DefaultTrendWindow                 = 30
+	DefaultFleetNamespace              = "fleet-default"
+	DefaultScanEventLogMaxSizeMB       = 100
+	DefaultScanEventLogMaxBackups      = 5
+	MaxRemediationExecutions           = 20
+	MaxProfileRenames                  = 10
+	MaxCampaignBurnDownPoints          = 100
 	CustomBenchmarkBaseDir             = "/etc/kbs/custombenchmark/cfg"
 	CustomBenchmarkConfigMap           = "cis-bmark-cm"
 
+	// set to "true" on a complete ClusterScan to launch a new run of it, the operator then removes it
+	ClusterScanRerunAnnotation = "cis.cattle.io/rerun"
+	// set on a ClusterScanProfile or ClusterScanBenchmark due for removal, to the message shown on the scans using it,
+	// e.g. "replaced by cis-1.8"
+	DeprecatedAnnotation = "cis.cattle.io/deprecated"
+
 	ClusterScanConditionCreated      = condition.Cond("Created")
 	ClusterScanConditionPending      = condition.Cond("Pending")
 	ClusterScanConditionRunCompleted = condition.Cond("RunCompleted")
@@ -35,11 +58,74 @@ const (
 	ClusterScanConditionAlerted      = condition.Cond("Alerted")
 	ClusterScanConditionReconciling  = condition.Cond("Reconciling")
 	ClusterScanConditionStalled      = condition.Cond("Stalled")
+	ClusterScanConditionCancelled    = condition.Cond("Cancelled")
+	ClusterScanConditionSuspended    = condition.Cond("Suspended")
+	// standard conditions summarizing the state of the scan for generic tooling, Reconciling follows Progressing
+	ClusterScanConditionReady       = condition.Cond("Ready")
+	ClusterScanConditionProgressing = condition.Cond("Progressing")
+	// set after every run of a scan with a baseline report, true when checks passing in the baseline fail
+	ClusterScanConditionRegressionDetected = condition.Cond("RegressionDetected")
+	// set after every run of a scan with a regression budget, true when more checks newly fail than allowed
+	ClusterScanConditionRegressionBudgetExceeded = condition.Cond("RegressionBudgetExceeded")
+	// set after every run of a continuous scan, true when checks passing in its reference fail
+	ClusterScanConditionPostureDrifted = condition.Cond("PostureDrifted")
+	// set when no benchmark supports the Kubernetes version of the cluster, with the supported ranges
+	ClusterScanConditionUnsupportedVersion = condition.Cond("UnsupportedVersion")
+	// true with the Deprecated reason when the scan uses deprecated fields, profiles or benchmarks, listed in its message
+	ClusterScanConditionWarning = condition.Cond("Warning")
+
+	ScanSubscriptionConditionDelivered = condition.Cond("Delivered")
+
+	// true once all the target checks of a campaign pass, and when its deadline passed before
+	ClusterScanCampaignConditionCompleted = condition.Cond("Completed")
+	ClusterScanCampaignConditionOverdue   = condition.Cond("Overdue")
+
+	// true when the ClusterScan of a template is in sync in all the downstream clusters it selects
+	ClusterScanTemplateConditionSynced = condition.Cond("Synced")
+
+	// true when the settings of the OperatorConfig are applied by the operator
+	OperatorConfigConditionApplied = condition.Cond("Applied")
+	// name of the only OperatorConfig the operator applies
+	OperatorConfigName = "cis-operator"
+
+	ClusterScanReasonTimeout                  = "Timeout"
+	ClusterScanReasonRegressionBudgetExceeded = "RegressionBudgetExceeded"
+	// the job of the run is gone without results, e.g. deleted while the operator was down
+	ClusterScanReasonJobLost = "JobLost"
 
 	ClusterScanFailOnWarning = "fail"
 	ClusterScanPassOnWarning = "pass"
+
+	// evaluation methods of the checks: on the nodes by the node workers, or against the static pods
+	// read through the API when the scan has staticPodFallback set
+	CheckEvaluationHost         = "host"
+	CheckEvaluationStaticPodAPI = "staticPodAPI"
+
+	// workloads of the ClusterScanRemediations
+	RemediationWorkloadJob       = "Job"
+	RemediationWorkloadDaemonSet = "DaemonSet"
+
+	// actions on the nodes failing the critical checks of a scan, see ClusterScanNodeAction
+	NodeActionTaint      = "Taint"
+	NodeActionLabel      = "Label"
+	DefaultNodeActionKey = "cis.cattle.io/non-compliant"
+	// prefix of the keys of the node actions, so that they never change the taints and labels of other components
+	NodeActionKeyPrefix = "cis.cattle.io/"
+
+	// phases of a running scan, see ClusterScanProgress
+	ClusterScanPhaseLaunching     = "Launching"
+	ClusterScanPhaseScanningNodes = "ScanningNodes"
+	ClusterScanPhaseReporting     = "Reporting"
 )
 
+// DefaultNodeGroupLabels are the nodepool labels of the managed node groups of EKS, GKE and AKS, and the zone label
+var DefaultNodeGroupLabels = []string{
+	"eks.amazonaws.com/nodegroup",
+	"cloud.google.com/gke-nodepool",
+	"kubernetes.azure.com/agentpool",
+	"topology.kubernetes.io/zone",
+}
+
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -59,6 +145,123 @@ type ClusterScanSpec struct {
 	ScheduledScanConfig *ScheduledScanConfig `yaml:"scheduled_scan_config" json:"scheduledScanConfig,omitempty"`
 	// Specify if tests with "warn" output should be counted towards scan failure
 	ScoreWarning string `yaml:"score_warning" json:"scoreWarning,omitempty"`
+	// limit the scan to the nodes matching these labels
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// canary scan of at most this many of the nodes in scope, sampled across the node roles, for quick feedback
+	// in large clusters, its reports are marked as partial coverage
+	MaxNodes int `json:"maxNodes,omitempty"`
+	// canary scan of these nodes only, among the nodes in scope, capped by maxNodes when set too
+	NodeNames []string `json:"nodeNames,omitempty"`
+	// scan only the nodes in scope whose kubelet version is in this semver range, e.g. "<1.27.0" for the node pools
+	// not rotated yet, its reports are marked as partial coverage
+	KubeletVersionRange string `json:"kubeletVersionRange,omitempty"`
+	// scan only the nodes in scope whose OS image contains one of these, e.g. the name of an old node image
+	OSImages []string `json:"osImages,omitempty"`
+	// tolerations added to the scan pods, e.g. to reach tainted control-plane or dedicated nodes
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// node affinity of the scan pods, overrides the operator default
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
+	// topology spread constraints of the scan runner pod, overrides the operator default
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// resource requests and limits of the scan containers, overrides the operator default
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// priority class of the scan pods, overrides the operator default
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// pre-created service account in the scan namespace used by the scan pods instead of cis-serviceaccount,
+	// it needs the same RBAC as the default one
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// fail the scan and clean it up if it has not completed after this many seconds, no timeout if 0
+	ScanTimeoutSeconds int64 `json:"scanTimeoutSeconds,omitempty"`
+	// retry the failed runs of the scan, e.g. after an image pull error or a node reboot
+	RetryPolicy *ClusterScanRetryPolicy `json:"retryPolicy,omitempty"`
+	// cancel the running or queued run of the scan and don't start new ones while set
+	Cancel bool `json:"cancel,omitempty"`
+	// pause a scheduled scan: the running scan completes but no new run starts until it is unset
+	Suspend bool `json:"suspend,omitempty"`
+	// node labels the results are aggregated by in the report, e.g. a nodepool or zone label,
+	// defaults to DefaultNodeGroupLabels when no nodeGroupDimensions are set either
+	NodeGroupLabels []string `json:"nodeGroupLabels,omitempty"`
+	// custom dimensions the results are aggregated by in the report, built from the node labels and annotations
+	NodeGroupDimensions []NodeGroupDimension `json:"nodeGroupDimensions,omitempty"`
+	// additional host paths mounted read-only in the scan pods, e.g. for the custom benchmarks auditing
+	// non-standard config locations, they must be under a path allowed by the operator
+	Volumes []ClusterScanHostPathVolume `json:"volumes,omitempty"`
+	// DNS policy of the scan pods, the node workers default to ClusterFirstWithHostNet, e.g. None with a
+	// dnsConfig in clusters where the service name of the scan does not resolve otherwise
+	DNSPolicy corev1.DNSPolicy     `json:"dnsPolicy,omitempty"`
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+	// entries added to the hosts file of the scan pods
+	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+	// ClusterScanReport of the scan its later runs are compared against, the checks passing in it and
+	// failing in a later report are regressions, see the RegressionDetected condition
+	BaselineReportName string `json:"baselineReportName,omitempty"`
+	// run the benchmark nearest to the Kubernetes version of the cluster when its profile, or the default
+	// one, does not support it, instead of failing the scan with the UnsupportedVersion condition
+	AllowClosestMatch bool `json:"allowClosestMatch,omitempty"`
+	// evaluate the control plane checks that did not pass on the nodes, e.g. when admission policies keep the
+	// node workers from mounting the host, against the mirror pods of the static control plane pods instead
+	StaticPodFallback bool `json:"staticPodFallback,omitempty"`
+	// run the scan at a short interval instead of a cron schedule, to notice posture drift between the full
+	// scans, see ClusterScanContinuousConfig
+	Continuous *ClusterScanContinuousConfig `json:"continuous,omitempty"`
+	// limit the results of the scan to these check IDs, or to the checks of these sections, e.g. to verify a
+	// single control again after its remediation. The checks left out are not reported.
+	IncludeChecks []string `json:"includeChecks,omitempty"`
+	// also report for these profiles, which must run the benchmark of scanProfileName: the node checks run once,
+	// skipping only the tests all the profiles skip, and a report is derived per profile by applying its skips
+	AdditionalProfileNames []string `json:"additionalProfileNames,omitempty"`
+	// taint or label the nodes failing some critical checks after each run, when node actions are enabled on the
+	// operator, see ClusterScanNodeAction
+	NodeAction *ClusterScanNodeAction `json:"nodeAction,omitempty"`
+}
+
+// ClusterScanNodeAction taints or labels the scanned nodes failing any of its checks, so that the sensitive workloads
+// are kept off them until they are remediated, and removes the taint or label from the scanned nodes passing them
+// again. The scans sharing a key undo each other's action, give each scan its own key.
+type ClusterScanNodeAction struct {
+	// critical checks whose failure on a node triggers the action
+	TestIDs []string `json:"testIDs"`
+	// Taint, the default, or Label
+	Action string `json:"action,omitempty"`
+	// key of the taint or label, prefixed with NodeActionKeyPrefix, its value is "true", defaults to DefaultNodeActionKey
+	Key string `json:"key,omitempty"`
+	// effect of the taint, defaults to NoSchedule
+	Effect corev1.TaintEffect `json:"effect,omitempty"`
+}
+
+// ClusterScanContinuousConfig runs a scan, typically with a profile skipping all but a fast subset of the node
+// checks, every few minutes. The checks passing in the last report of the reference scan and failing in a run,
+// or newly failing since the previous run of the scan without a reference, are the posture drift, see the
+// PostureDrifted condition.
+type ClusterScanContinuousConfig struct {
+	// minutes between the runs, defaults to DefaultContinuousIntervalMinutes
+	IntervalMinutes int `json:"intervalMinutes,omitempty"`
+	// scan whose last report the runs are compared against, e.g. the full scheduled scan
+	ReferenceScanName string `json:"referenceScanName,omitempty"`
+}
+
+type ClusterScanHostPathVolume struct {
+	// DNS label naming the volume
+	Name     string `json:"name"`
+	HostPath string `json:"hostPath"`
+	// where the host path is mounted in the scan container, defaults to hostPath
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+type NodeGroupDimension struct {
+	// name of the dimension, set as the label of its node groups in the report
+	Name string `json:"name"`
+	// Go template rendered for every node to the group it belongs to, with the node .Name, .Labels and
+	// .Annotations, e.g. {{ index .Labels "topology.kubernetes.io/zone" }}/{{ index .Labels "node.kubernetes.io/instance-type" }}.
+	// The nodes it renders empty for are left out of the dimension.
+	Template string `json:"template"`
+}
+
+type ClusterScanRetryPolicy struct {
+	// maximum number of runs, the first one included, before the scan is left failed
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+	// delay before the first retry, doubled for every following one, defaults to 60 seconds
+	BackoffSeconds int64 `json:"backoffSeconds,omitempty"`
 }
 
 type ClusterScanStatus struct {
@@ -70,6 +273,115 @@ type ClusterScanStatus struct {
 	Conditions             []genericcondition.GenericCondition `json:"conditions,omitempty"`
 	NextScanAt             string                              `json:"NextScanAt"`
 	ScanAlertingRuleName   string                              `json:"ScanAlertingRuleName"`
+	// expected duration and completion time of the current run, estimated from previous reports
+	EstimatedDurationSeconds     int64  `json:"estimatedDurationSeconds,omitempty"`
+	EstimatedCompletionTimestamp string `json:"estimatedCompletionTimestamp,omitempty"`
+	// summary of the last run per team owning the checks, when a check ownership ConfigMap is set
+	TeamSummaries map[string]ClusterScanSummary `json:"teamSummaries,omitempty"`
+	// runs made for the current scan, retries included, and when the next retry is due
+	Attempts    int    `json:"attempts,omitempty"`
+	NextRetryAt string `json:"nextRetryAt,omitempty"`
+	// checks failing in the last report, with the time they started failing, kept across runs
+	FailingChecks map[string]string `json:"failingChecks,omitempty"`
+	// checks that failed then passed again, with the time it took to remediate them
+	RemediatedChecks map[string]CheckRemediation `json:"remediatedChecks,omitempty"`
+	// when the scan was queued waiting for a free slot, the queued scans are launched oldest first
+	QueuedAt string `json:"queuedAt,omitempty"`
+	// number of checks of the last report whose result differs across nodes of the same role, by role
+	DriftedChecks map[string]int `json:"driftedChecks,omitempty"`
+	// checks passing in the baseline report and failing in the last report
+	Regressions []string `json:"regressions,omitempty"`
+	// summary of the last runs, oldest first, up to the trend window of the operator
+	Trend []ClusterScanTrendPoint `json:"trend,omitempty"`
+	// compliance score of the last report
+	ComplianceScore *ClusterScanComplianceScore `json:"complianceScore,omitempty"`
+	// checks of the last run of a continuous scan drifting from its reference, see ClusterScanContinuousConfig
+	PostureDrift []string `json:"postureDrift,omitempty"`
+	// nodes the current run of a canary scan was sampled to, see maxNodes and nodeNames
+	SampledNodes []string `json:"sampledNodes,omitempty"`
+	// profiles the scheduled scan ran on before, e.g. when its profile was renamed, most recent last, up to
+	// MaxProfileRenames; its metric series moved to the new profile name
+	ProfileRenames []ClusterScanProfileRename `json:"profileRenames,omitempty"`
+	// nodes tainted or labelled by the node action of the scan, it is undone from them once the action is removed
+	NodeAction *ClusterScanNodeActionStatus `json:"nodeAction,omitempty"`
+	// operational cost of the last completed run
+	Cost *ClusterScanCost `json:"cost,omitempty"`
+	// progress of the current run, cleared once it completes
+	Progress *ClusterScanProgress `json:"progress,omitempty"`
+}
+
+// ClusterScanProfileRename records a run of a scheduled scan on another profile than its previous run.
+type ClusterScanProfileRename struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Timestamp string `json:"timestamp"`
+}
+
+// ClusterScanCost is the operational cost of a scan run, from the accounting of the operator: the requests it issued
+// to the API server for the scan and the objects of the run, the pods the run created and how long it took. A run
+// the operator restarted during is only accounted from the restart on, and is Partial.
+type ClusterScanCost struct {
+	APIRequests int `json:"apiRequests"`
+	// the requests by verb: get, list, watch, create, update, patch, delete and deletecollection
+	APIRequestsByVerb map[string]int `json:"apiRequestsByVerb,omitempty"`
+	Pods              int            `json:"pods"`
+	DurationSeconds   int64          `json:"durationSeconds"`
+	Partial           bool           `json:"partial,omitempty"`
+}
+
+type ClusterScanNodeActionStatus struct {
+	Action string             `json:"action"`
+	Key    string             `json:"key"`
+	Effect corev1.TaintEffect `json:"effect,omitempty"`
+	// nodes the action applies to, with the critical checks failing on them
+	Nodes map[string][]string `json:"nodes,omitempty"`
+}
+
+// ClusterScanProgress is the progress of a running scan: Launching until its node workers report to the aggregator,
+// ScanningNodes until they all completed, then Reporting while its report is built.
+type ClusterScanProgress struct {
+	Phase string `json:"phase"`
+	// when the run entered its current phase
+	PhaseStartedAt string `json:"phaseStartedAt"`
+	// node workers done, out of the total reported by the aggregator, the failed ones are counted as done too
+	NodesCompleted int `json:"nodesCompleted"`
+	NodesFailed    int `json:"nodesFailed,omitempty"`
+	NodesTotal     int `json:"nodesTotal"`
+}
+
+type ClusterScanCheckRemediation struct {
+	TestID      string `json:"testID"`
+	State       string `json:"state"`
+	Description string `json:"description,omitempty"`
+	Remediation string `json:"remediation"`
+}
+
+// ClusterScanComplianceScore weighs the scored checks only: the percentage of the scored checks passing out of
+// those passing or failing, the warnings, skipped and not applicable checks are left out. 100 when none is left.
+type ClusterScanComplianceScore struct {
+	Score float64 `json:"score"`
+	// scored checks passing, and passing or failing
+	Passed int `json:"passed"`
+	Total  int `json:"total"`
+}
+
+type ClusterScanTrendPoint struct {
+	Timestamp  string `json:"timestamp"`
+	ReportName string `json:"reportName,omitempty"`
+	// percentage of the evaluated checks passing, see ClusterScanSummary.Score
+	Score float64 `json:"score"`
+	Total int     `json:"total"`
+	Pass  int     `json:"pass"`
+	Fail  int     `json:"fail"`
+}
+
+type CheckRemediation struct {
+	// number of times the check was remediated and their total duration, from the first failure to the first pass
+	Count        int   `json:"count"`
+	TotalSeconds int64 `json:"totalSeconds"`
+	// first failure and first pass of the last remediation
+	LastFailedAt     string `json:"lastFailedAt,omitempty"`
+	LastRemediatedAt string `json:"lastRemediatedAt,omitempty"`
 }
 
 type ClusterScanStatusDisplay struct {
@@ -88,13 +400,33 @@ type ClusterScanSummary struct {
 	NotApplicable int `json:"notApplicable"`
 }
 
+// Score returns the percentage of the passing checks out of those passing or failing, 100 when none was evaluated
+func (s *ClusterScanSummary) Score() float64 {
+	if s.Pass+s.Fail == 0 {
+		return 100
+	}
+	return float64(s.Pass) * 100 / float64(s.Pass+s.Fail)
+}
+
 type ScheduledScanConfig struct {
-	// Cron Expression for Schedule
+	// Cron Expression for Schedule, optionally prefixed by CRON_TZ=<timezone>, deprecated in favor of timezone
 	CronSchedule string `yaml:"cron_schedule" json:"cronSchedule,omitempty"`
+	// IANA timezone the cron expression is evaluated in, e.g. Europe/Paris, defaults to UTC
+	Timezone string `json:"timezone,omitempty"`
+	// delay every run by a random duration of up to this many seconds after its scheduled time,
+	// so that the clusters sharing a schedule don't all scan at the same minute
+	JitterSeconds int64 `json:"jitterSeconds,omitempty"`
 	// Number of past scans to keep
 	RetentionCount int `yaml:"retentionCount" json:"retentionCount,omitempty"`
+	// delete the reports of the scan this many days after their creation, defaults to the
+	// operator --report-retention-days
+	RetentionDays int `json:"retentionDays,omitempty"`
 	//configure the alerts to be sent out
 	ScanAlertRule *ClusterScanAlertRule `json:"scanAlertRule,omitempty"`
+	// maximum number of checks newly failing since the previous run, e.g. during a progressive hardening
+	// campaign: a run exceeding it is marked failed, one within it only warns, see the RegressionBudgetExceeded
+	// condition. No budget when unset, 0 allows no new failure.
+	RegressionBudget *int `json:"regressionBudget,omitempty"`
 }
 
 type ClusterScanAlertRule struct {
@@ -136,6 +468,51 @@ type ClusterScanProfile struct {
 type ClusterScanProfileSpec struct {
 	BenchmarkVersion string   `json:"benchmarkVersion,omitempty"`
 	SkipTests        []string `json:"skipTests,omitempty"`
+	// tests skipped until their expiry, with the owner and reason of the exemption
+	Exemptions []ClusterScanExemption `json:"exemptions,omitempty"`
+	// keep defaultSkipTests in sync with the default skips of the benchmark, from the default skips ConfigMap
+	// of the operator, e.g. the checks not applicable to the distribution
+	FollowDefaults bool `json:"followDefaults,omitempty"`
+	// tests skipped by default for the benchmark, set by the operator when followDefaults is set
+	DefaultSkipTests []string `json:"defaultSkipTests,omitempty"`
+}
+
+type ClusterScanExemption struct {
+	TestID string `json:"testID"`
+	Owner  string `json:"owner,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	// date (2006-01-02) or RFC3339 time after which the test is no longer skipped, never if empty
+	Expiry string `json:"expiry,omitempty"`
+}
+
+// ParseExemptionExpiry returns the time an exemption expires at, a date expires at the end of that day (UTC).
+func ParseExemptionExpiry(expiry string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", expiry); err == nil {
+		return t.AddDate(0, 0, 1), nil
+	}
+	return time.Parse(time.RFC3339, expiry)
+}
+
+// Expired returns whether the exemption has expired at the given time, exemptions with an invalid expiry are expired.
+func (e ClusterScanExemption) Expired(now time.Time) bool {
+	if e.Expiry == "" {
+		return false
+	}
+	expiry, err := ParseExemptionExpiry(e.Expiry)
+	return err != nil || !now.Before(expiry)
+}
+
+// ActiveSkipTests returns the tests skipped by the profile at the given time, its skipTests, defaultSkipTests and
+// unexpired exemptions.
+func (s *ClusterScanProfileSpec) ActiveSkipTests(now time.Time) []string {
+	skip := append([]string{}, s.SkipTests...)
+	skip = append(skip, s.DefaultSkipTests...)
+	for _, exemption := range s.Exemptions {
+		if !exemption.Expired(now) {
+			skip = append(skip, exemption.TestID)
+		}
+	}
+	return skip
 }
 
 // +genclient
@@ -146,21 +523,620 @@ type ClusterScanReport struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec ClusterScanReportSpec `json:"spec"`
+	Spec   ClusterScanReportSpec   `json:"spec"`
+	Status ClusterScanReportStatus `json:"status,omitempty"`
+}
+
+type ClusterScanReportStatus struct {
+	// shards holding the nodes of the report, when the report of a large cluster is sharded
+	Shards []ClusterScanReportShardStatus `json:"shards,omitempty"`
+}
+
+type ClusterScanReportShardStatus struct {
+	Name      string `json:"name"`
+	Index     int    `json:"index"`
+	NodeCount int    `json:"nodeCount"`
+	// checks failing on at least one node of the shard
+	FailingChecks int `json:"failingChecks"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterScanReportShard holds the nodes of a chunk of the nodes of a sharded ClusterScanReport,
+// which is their owner.
+type ClusterScanReportShard struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterScanReportShardSpec `json:"spec"`
+}
+
+type ClusterScanReportShardSpec struct {
+	// name of the sharded ClusterScanReport, and position of the shard in it
+	ReportName string `json:"reportName"`
+	Index      int    `json:"index"`
+	// nodes of the shard by node type, as in the nodes of the report
+	Nodes map[string][]string `json:"nodes,omitempty"`
+	// nodes of the shard listed by each check, by check ID
+	CheckNodes map[string][]string `json:"checkNodes,omitempty"`
 }
 
 type ClusterScanReportSpec struct {
 	BenchmarkVersion string `json:"benchmarkVersion,omitempty"`
 	LastRunTimestamp string `yaml:"last_run_timestamp" json:"lastRunTimestamp"`
 	ReportJSON       string `json:"reportJSON"`
+	// empty for a plain reportJSON, gzip+base64 for a compressed one, see GetReportJSON
+	ReportEncoding string `json:"reportEncoding,omitempty"`
+	// the nodes are left out of the reportJSON of a sharded report, they are in its ClusterScanReportShards
+	Sharded bool `json:"sharded,omitempty"`
+	// node selector the scan was limited to, and the nodes it matched
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	NodesInScope []string          `json:"nodesInScope,omitempty"`
+	// set for the report of a canary scan, which only ran on the sampled nodesInScope out of the
+	// totalNodeCount nodes matching its node selector
+	PartialCoverage bool `json:"partialCoverage,omitempty"`
+	TotalNodeCount  int  `json:"totalNodeCount,omitempty"`
+	// profile the report applies, and whether it is one of the additionalProfileNames of the scan, derived from the
+	// run of its scanProfileName
+	ScanProfileName   string `json:"scanProfileName,omitempty"`
+	AdditionalProfile bool   `json:"additionalProfile,omitempty"`
+	// how long the scan took and on how many nodes, used to estimate the duration of later scans
+	DurationSeconds int64 `json:"durationSeconds,omitempty"`
+	NodeCount       int   `json:"nodeCount,omitempty"`
+	// summary per team owning the checks, when a check ownership ConfigMap is set
+	TeamSummaries map[string]ClusterScanSummary `json:"teamSummaries,omitempty"`
+	// inventory of the tests skipped by the profile during the scan, for exception reviews
+	Exemptions []ClusterScanExemption `json:"exemptions,omitempty"`
+	// checks whose result differs across nodes of the same role, hinting at configuration drift
+	Drift []ClusterScanDrift `json:"drift,omitempty"`
+	// summary per group of nodes sharing the value of a node group label
+	NodeGroups []ClusterScanNodeGroup `json:"nodeGroups,omitempty"`
+	// hardening findings of the operator's own deployment, RBAC and scan workloads, with self-check enabled
+	SelfCheck []SelfCheckFinding `json:"selfCheck,omitempty"`
+	// checks whose state changed since the previous report of the scan, nil for its first report
+	Diff *ClusterScanReportDiff `json:"diff,omitempty"`
+	// DSSE envelope of the in-toto statement signed by the operator, with a report signing key, whose
+	// subject is the sha256 digest of the report JSON, decompressed and with its shards merged
+	Attestation string `json:"attestation,omitempty"`
+	// compliance score of the report
+	ComplianceScore *ClusterScanComplianceScore `json:"complianceScore,omitempty"`
+	// remediation of every failing check, readable without decoding the report JSON, unless the operator
+	// omits the remediations
+	Remediations []ClusterScanCheckRemediation `json:"remediations,omitempty"`
+	// how each check was evaluated, by check ID, when the scan has staticPodFallback set:
+	// CheckEvaluationHost or CheckEvaluationStaticPodAPI
+	EvaluationMethods map[string]string `json:"evaluationMethods,omitempty"`
+	// includeChecks of the scan the report is limited to, empty for a report of the whole benchmark
+	IncludedChecks []string `json:"includedChecks,omitempty"`
+	// report plugins of the operator the reportJSON went through, and the errors of those that failed, whose
+	// output was discarded
+	ProcessedBy  []string `json:"processedBy,omitempty"`
+	PluginErrors []string `json:"pluginErrors,omitempty"`
+}
+
+type ClusterScanReportDiff struct {
+	// name of the previous report of the scan the report is compared against
+	PreviousReport string `json:"previousReport"`
+	// checks failing in the report that did not fail in the previous one
+	NewlyFailing []string `json:"newlyFailing,omitempty"`
+	// checks passing in the report that failed in the previous one
+	NewlyPassing []string `json:"newlyPassing,omitempty"`
+	// the other checks whose state changed, added and removed checks included
+	Changed []ClusterScanCheckChange `json:"changed,omitempty"`
+}
+
+type ClusterScanCheckChange struct {
+	TestID string `json:"testID"`
+	// states of the check in the previous report and in the report, empty when it is missing from one of them
+	PreviousState string `json:"previousState,omitempty"`
+	State         string `json:"state,omitempty"`
+}
+
+type SelfCheckFinding struct {
+	// hardening rule the object breaks, e.g. read-only-root-filesystem
+	Rule string `json:"rule"`
+	// kind, namespace and name of the object, e.g. Deployment/cis-operator-system/cis-operator
+	Object  string `json:"object"`
+	Message string `json:"message"`
+}
+
+type ClusterScanNodeGroup struct {
+	// node label, or name of the node group dimension, and value shared by the nodes of the group,
+	// e.g. topology.kubernetes.io/zone=eu-west-1a
+	Label   string             `json:"label"`
+	Value   string             `json:"value"`
+	Nodes   []string           `json:"nodes"`
+	Summary ClusterScanSummary `json:"summary"`
+}
+
+type ClusterScanDrift struct {
+	TestID string `json:"testID"`
+	// role of the nodes, as in the node types of the report: etcd, master or node
+	NodeType string `json:"nodeType"`
+	// nodes failing the check while the other nodes of the role pass it, out of NodeCount nodes of the role
+	FailingNodes []string `json:"failingNodes"`
+	NodeCount    int      `json:"nodeCount"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ScanSubscription struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ScanSubscriptionSpec   `json:"spec"`
+	Status ScanSubscriptionStatus `json:"status,omitempty"`
+}
+
+type ScanSubscriptionSpec struct {
+	// select the ClusterScans to be notified about, all scans if empty
+	ScanSelector *metav1.LabelSelector `json:"scanSelector,omitempty"`
+	// where to deliver the completion notification
+	Target ScanSubscriptionTarget `json:"target,omitempty"`
+	// teams whose rollup is included in the notification, all teams if empty
+	Teams []string `json:"teams,omitempty"`
+	// escalation: only notify about the scans with checks failing for at least this many days
+	FailureAgeDays int `json:"failureAgeDays,omitempty"`
+	// deliver a periodic rollup of the selected scans instead of a notification per scan
+	Rollup *ScanRollupConfig `json:"rollup,omitempty"`
+}
+
+type ScanRollupConfig struct {
+	// Cron Expression for the delivery of the rollups, e.g. "0 8 * * 1" for weekly ones, optionally prefixed by CRON_TZ=<timezone>
+	CronSchedule string `json:"cronSchedule"`
+	// days of reports aggregated in a rollup, defaults to 7
+	PeriodDays int `json:"periodDays,omitempty"`
+}
+
+type ScanSubscriptionTarget struct {
+	// ConfigMap updated with the details of every completed scan
+	ConfigMapName      string `json:"configMapName,omitempty"`
+	ConfigMapNamespace string `json:"configMapNamespace,omitempty"`
+	// PersistentVolumeClaim the notification and the report of every completed scan are written to, for
+	// the clusters without egress whose backup system picks the files up
+	PersistentVolumeClaim *ScanSubscriptionPVCTarget `json:"persistentVolumeClaim,omitempty"`
+}
+
+type ScanSubscriptionPVCTarget struct {
+	// claim in the operator namespace, created ReadWriteOnce with storageClassName and size if missing
+	ClaimName        string `json:"claimName"`
+	StorageClassName string `json:"storageClassName,omitempty"`
+	// size of the created claim, defaults to 1Gi
+	Size string `json:"size,omitempty"`
+	// Go template of the directory the files of a report are written to, relative to the root of the volume,
+	// rendered with .ScanName, .ScanProfileName, .ReportName and .Timestamp, defaults to {{.ScanName}}/{{.ReportName}}
+	PathTemplate string `json:"pathTemplate,omitempty"`
+}
+
+type ScanSubscriptionStatus struct {
+	LastNotifiedScan      string                              `json:"lastNotifiedScan,omitempty"`
+	LastNotifiedReport    string                              `json:"lastNotifiedReport,omitempty"`
+	LastNotifiedState     string                              `json:"lastNotifiedState,omitempty"`
+	LastNotifiedTimestamp string                              `json:"lastNotifiedTimestamp,omitempty"`
+	Conditions            []genericcondition.GenericCondition `json:"conditions,omitempty"`
+	// when the next rollup is due, for the rollup subscriptions
+	NextRollupAt string `json:"nextRollupAt,omitempty"`
+	// completed scan runs still to be delivered, one per scan, retried until they succeed or run out of attempts
+	PendingDeliveries []ScanSubscriptionDelivery `json:"pendingDeliveries,omitempty"`
+}
+
+// ScanSubscriptionDelivery is the delivery of a completed scan run to a subscription
+type ScanSubscriptionDelivery struct {
+	ScanName   string `json:"scanName"`
+	ReportName string `json:"reportName,omitempty"`
+	// LastRunTimestamp of the run, the delivery is dropped once the scan runs again
+	LastRunTimestamp string `json:"lastRunTimestamp,omitempty"`
+	// failed attempts so far, and when the next one is due
+	Attempts      int    `json:"attempts,omitempty"`
+	NextAttemptAt string `json:"nextAttemptAt,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterScanRemediation maps failing checks to a workload the operator launches after the scans they fail in,
+// when remediations are enabled on the operator.
+type ClusterScanRemediation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterScanRemediationSpec   `json:"spec"`
+	Status ClusterScanRemediationStatus `json:"status,omitempty"`
+}
+
+type ClusterScanRemediationSpec struct {
+	// checks remediated by the workload, it is launched once after a scan when any of them fails
+	TestIDs []string `json:"testIDs"`
+	// select the ClusterScans whose failures are remediated, all scans if empty
+	ScanSelector *metav1.LabelSelector `json:"scanSelector,omitempty"`
+	// Job, the default, or DaemonSet, to remediate every node
+	Workload string `json:"workload,omitempty"`
+	// pod template of the workload, launched in the operator namespace
+	Template corev1.PodTemplateSpec `json:"template"`
+	// only record the executions, without launching the workload
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+type ClusterScanRemediationStatus struct {
+	// last executions, most recent last, up to MaxRemediationExecutions
+	Executions []ClusterScanRemediationExecution `json:"executions,omitempty"`
+}
+
+// ClusterScanRemediationExecution records a launch of the workload of a remediation, or what a dry run would launch.
+type ClusterScanRemediationExecution struct {
+	Timestamp  string `json:"timestamp"`
+	ScanName   string `json:"scanName"`
+	ReportName string `json:"reportName,omitempty"`
+	// failing checks of the scan remediated by the workload
+	TestIDs []string `json:"testIDs"`
+	// kind and name of the workload launched, not set by dry runs
+	Workload     string `json:"workload"`
+	WorkloadName string `json:"workloadName,omitempty"`
+	// images of the containers of the workload
+	Images []string `json:"images,omitempty"`
+	DryRun bool     `json:"dryRun,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterScanCampaign is a progressive hardening campaign: a set of checks to fix by a deadline, whose progress
+// the operator tracks across the runs of the scans.
+type ClusterScanCampaign struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterScanCampaignSpec   `json:"spec"`
+	Status ClusterScanCampaignStatus `json:"status,omitempty"`
+}
+
+type ClusterScanCampaignSpec struct {
+	// checks to fix, a check is fixed once it passes in the last report of the selected scans it is in
+	TestIDs []string `json:"testIDs"`
+	// date the checks are to be fixed by, as YYYY-MM-DD or RFC3339
+	Deadline string `json:"deadline"`
+	// select the ClusterScans whose reports track the campaign, all scans if empty
+	ScanSelector *metav1.LabelSelector `json:"scanSelector,omitempty"`
+}
+
+type ClusterScanCampaignStatus struct {
+	// target checks, fixed ones and percentage fixed
+	Total                int     `json:"total"`
+	Fixed                int     `json:"fixed"`
+	CompletionPercentage float64 `json:"completionPercentage"`
+	// target checks not fixed yet, with their state in the last report they are in, not run if in none
+	Remaining map[string]string `json:"remaining,omitempty"`
+	// last report the progress was computed with
+	LastReportName string `json:"lastReportName,omitempty"`
+	// remaining checks after every new report, oldest first, up to MaxCampaignBurnDownPoints
+	BurnDown   []ClusterScanCampaignBurnDownPoint  `json:"burnDown,omitempty"`
+	Conditions []genericcondition.GenericCondition `json:"conditions,omitempty"`
+}
+
+type ClusterScanCampaignBurnDownPoint struct {
+	Timestamp  string `json:"timestamp"`
+	ReportName string `json:"reportName"`
+	Remaining  int    `json:"remaining"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterScanTemplate is a template of a ClusterScan, typically a scheduled one, that an operator running with
+// --fleet-hub creates, and keeps as declared, in every downstream cluster registered with Fleet it selects.
+type ClusterScanTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterScanTemplateSpec   `json:"spec"`
+	Status ClusterScanTemplateStatus `json:"status,omitempty"`
+}
+
+type ClusterScanTemplateSpec struct {
+	// select the Fleet Clusters by their labels, all the registered clusters if empty
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	// name of the ClusterScan in the downstream clusters, the name of the template if empty
+	ScanName string `json:"scanName,omitempty"`
+	// spec of the downstream ClusterScans, e.g. a scanProfileName and a scheduledScanConfig
+	Template ClusterScanSpec `json:"template"`
+}
+
+type ClusterScanTemplateStatus struct {
+	// downstream clusters selected by the template, at the last sync of the observed generation
+	Clusters           []ClusterScanTemplateCluster        `json:"clusters,omitempty"`
+	LastSyncTimestamp  string                              `json:"lastSyncTimestamp,omitempty"`
+	ObservedGeneration int64                               `json:"observedGeneration,omitempty"`
+	Conditions         []genericcondition.GenericCondition `json:"conditions,omitempty"`
+}
+
+type ClusterScanTemplateCluster struct {
+	// namespace/name of the Fleet Cluster
+	Name string `json:"name"`
+	// what the last sync did to the downstream ClusterScan: created, updated or none, empty on error
+	Action string `json:"action,omitempty"`
+	// how the downstream API server was reached: rancher-proxy, through the Rancher proxy and the cluster-agent
+	// tunnel, or direct
+	Route string `json:"route,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OperatorConfig overrides the settings of the operator flags while it runs, for a GitOps managed configuration. Only
+// the OperatorConfig named OperatorConfigName is applied, the flags apply again once it is deleted.
+type OperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OperatorConfigSpec   `json:"spec"`
+	Status OperatorConfigStatus `json:"status,omitempty"`
+}
+
+// OperatorConfigSpec holds the settings overriding those of the flags, the unset ones keep the value of their flag
+type OperatorConfigSpec struct {
+	// maximum number of scans running at once, see --max-concurrent-scans
+	MaxConcurrentScans int                            `json:"maxConcurrentScans,omitempty"`
+	Images             *OperatorConfigImages          `json:"images,omitempty"`
+	Sinks              *OperatorConfigSinks           `json:"sinks,omitempty"`
+	Metrics            *OperatorConfigMetrics         `json:"metrics,omitempty"`
+	DefaultProfiles    *OperatorConfigDefaultProfiles `json:"defaultProfiles,omitempty"`
+	Reports            *OperatorConfigReports         `json:"reports,omitempty"`
+	// level of the logs of the operator, e.g. debug, see --log-level
+	LogLevel string `json:"logLevel,omitempty"`
+}
+
+// OperatorConfigImages are the images the scans run, used by the runs launched after they are applied
+type OperatorConfigImages struct {
+	SecurityScanImage           string `json:"securityScanImage,omitempty"`
+	SecurityScanImageTag        string `json:"securityScanImageTag,omitempty"`
+	WindowsSecurityScanImage    string `json:"windowsSecurityScanImage,omitempty"`
+	WindowsSecurityScanImageTag string `json:"windowsSecurityScanImageTag,omitempty"`
+	SonobuoyImage               string `json:"sonobuoyImage,omitempty"`
+	SonobuoyImageTag            string `json:"sonobuoyImageTag,omitempty"`
+	// registry the scan images are pulled from instead of the one in their name
+	Registry string `json:"registry,omitempty"`
+	// secrets in the operator namespace used to pull the scan images
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+}
+
+// OperatorConfigSinks are the defaults of the deliveries to the ScanSubscriptions
+type OperatorConfigSinks struct {
+	// external URL of the report API the notifications link to, see --report-base-url
+	ReportBaseURL string `json:"reportBaseURL,omitempty"`
+	// days covered by the rollups of the subscriptions without periodDays
+	RollupPeriodDays int `json:"rollupPeriodDays,omitempty"`
+}
+
+// OperatorConfigMetrics are the options of the metrics and the alerts of the scans
+type OperatorConfigMetrics struct {
+	// severity of the alerts of the scans, see --alertSeverity
+	AlertSeverity string `json:"alertSeverity,omitempty"`
+	// number of runs kept in the trend of the ClusterScan status, see --trend-window
+	TrendWindow *int `json:"trendWindow,omitempty"`
+}
+
+// OperatorConfigDefaultProfiles are the ClusterScanProfiles run by the scans without a scanProfileName
+type OperatorConfigDefaultProfiles struct {
+	// ConfigMap in the operator namespace mapping the cluster providers to their default ClusterScanProfile, see
+	// --default-profiles-configmap
+	ConfigMap string `json:"configMap,omitempty"`
+	// entries taking precedence over those of the ConfigMap, in the same format: a ClusterScanProfile, or lines of
+	// k8sRange:profile, by cluster provider, and for the other providers under default
+	Profiles map[string]string `json:"profiles,omitempty"`
+}
+
+// OperatorConfigReports are the retention and storage of the ClusterScanReports
+type OperatorConfigReports struct {
+	// days the reports are kept, unless their scan sets retentionDays, 0 keeps them, see --report-retention-days
+	RetentionDays *int `json:"retentionDays,omitempty"`
+	// size in bytes past which the report JSON is stored compressed, see --report-compression-threshold
+	CompressionThreshold *int `json:"compressionThreshold,omitempty"`
+	// number of nodes per ClusterScanReportShard, see --report-shard-size
+	ShardSize *int `json:"shardSize,omitempty"`
+}
+
+type OperatorConfigStatus struct {
+	ObservedGeneration int64                               `json:"observedGeneration,omitempty"`
+	Conditions         []genericcondition.GenericCondition `json:"conditions,omitempty"`
 }
 
 type ScanImageConfig struct {
-	SecurityScanImage    string
-	SecurityScanImageTag string
-	SonobuoyImage        string
-	SonobuoyImageTag     string
-	AlertSeverity        string
-	ClusterName          string
-	AlertEnabled         bool
+	SecurityScanImage           string
+	SecurityScanImageTag        string
+	WindowsSecurityScanImage    string
+	WindowsSecurityScanImageTag string
+	SonobuoyImage               string
+	SonobuoyImageTag            string
+	AlertSeverity               string
+	// level of the logs, see --log-level
+	LogLevel     string
+	ClusterName  string
+	AlertEnabled bool
+	// registry the scan images are pulled from instead of the one in their name
+	Registry string
+	// secrets in the scan namespace used to pull the scan images
+	ImagePullSecrets []string
+	// PEM public key the cosign signatures of the scan images are verified with, verification is off if empty
+	ImageVerificationKey string
+	// PEM private key the attestations of the reports are signed with, the reports are not signed if empty
+	ReportSigningKey string
+	// no request leaves the cluster network, the images come from Registry or are already on the nodes
+	AirGapped bool
+	// external URL of the report API, used to link alerts and notifications to the reports
+	ReportBaseURL string
+	// ConfigMap in the operator namespace mapping the checks to their owning teams
+	CheckOwnersConfigMap string
+	// ConfigMap in the operator namespace listing the tests skipped by default per benchmark version
+	DefaultSkipsConfigMap string
+	// ConfigMap in the operator namespace mapping the cluster providers to their default ClusterScanProfile, and the
+	// entries of the OperatorConfig taking precedence over it
+	DefaultProfilesConfigMap string
+	DefaultProfiles          map[string]string
+	// maximum number of scans running at once, the other ones are queued
+	MaxConcurrentScans int
+	// number of replicas of the operator the scans are sharded across by the hash of their name, and shard of this one
+	ShardCount int
+	ShardIndex int
+	// ClusterScan run again when the Kubernetes version of the cluster changes, checked every UpgradeCheckInterval
+	UpgradeScanName      string
+	UpgradeCheckInterval time.Duration
+	// reports are deleted this many days after their creation, unless their scan sets its own retentionDays, kept forever if 0
+	ReportRetentionDays int
+	// reports whose JSON is larger than this many bytes are stored compressed, all of them if 0, none if negative
+	ReportCompressionThreshold int
+	// the reports of scans covering more nodes than this are sharded in ClusterScanReportShards of this
+	// many nodes, never if 0
+	ReportShardSize int
+	// number of runs kept in the trend of the ClusterScan status, none if 0
+	TrendWindow int
+	// file the stages of the scan runs are written to as audit events, rotated past ScanEventLogMaxSizeMB
+	// into up to ScanEventLogMaxBackups files, no event is written if empty
+	ScanEventLogPath       string
+	ScanEventLogMaxSizeMB  int
+	ScanEventLogMaxBackups int
+	// OTLP/HTTP endpoint of the collector the spans of the scan runs are exported to, with the comma separated
+	// key=value OTLPHeaders, no span is recorded if empty
+	OTLPEndpoint string
+	OTLPHeaders  string
+	// days covered by the rollups of the ScanSubscriptions without periodDays, DefaultRollupPeriodDays if 0
+	RollupPeriodDays int
+	// executables the report JSON is piped through, in order, before the reports are stored, each one given
+	// ReportPluginTimeout to write the transformed report on its output
+	ReportPlugins       []string
+	ReportPluginTimeout time.Duration
+	// test only: reading the results of the scan runs and delivering to the sinks are delayed by up to
+	// FaultInjectionMaxDelay then fail at FaultInjectionFailureRate, from 0 to 1, to exercise the retries
+	FaultInjectionFailureRate float64
+	FaultInjectionMaxDelay    time.Duration
+	// the ClusterScanRemediations launch their workloads after the scans, none is launched otherwise
+	RemediationEnabled bool
+	// the scans with a nodeAction taint or label the nodes failing their critical checks, the nodes are not changed
+	// otherwise
+	NodeActionsEnabled bool
+	// namespaces besides the operator one the ConfigMap targets of the ScanSubscriptions may be written to
+	SubscriptionNamespaces []string
+	// the ClusterScanTemplates are synced to the downstream clusters registered with Fleet, none is otherwise. The
+	// Fleet Clusters and their kubeConfigSecrets are only read in FleetNamespaces.
+	FleetHub        bool
+	FleetNamespaces []string
+	// the remediation texts are left out of the reports, from their JSON and their remediations, to keep them small
+	OmitRemediations bool
+	// the operator checks its own deployment, RBAC and scan workloads and adds the findings to the reports
+	SelfCheck bool
+}
+
+// ScanReportsURL returns the link to the viewer listing the reports of a scan, empty without ReportBaseURL
+func (c *ScanImageConfig) ScanReportsURL(scanName string) string {
+	if c.ReportBaseURL == "" {
+		return ""
+	}
+	return strings.TrimSuffix(c.ReportBaseURL, "/") + "/ui/?scan=" + url.QueryEscape(scanName)
+}
+
+// ReportURL returns the link to the viewer showing a report, empty without ReportBaseURL
+func (c *ScanImageConfig) ReportURL(reportName string) string {
+	if c.ReportBaseURL == "" {
+		return ""
+	}
+	return strings.TrimSuffix(c.ReportBaseURL, "/") + "/ui/?report=" + url.QueryEscape(reportName)
+}
+
+// ReportAPIURL returns the link to the JSON of a report, empty without ReportBaseURL
+func (c *ScanImageConfig) ReportAPIURL(reportName string) string {
+	if c.ReportBaseURL == "" {
+		return ""
+	}
+	return strings.TrimSuffix(c.ReportBaseURL, "/") + "/v1/reports/" + url.PathEscape(reportName)
+}
+
+// ImageRef returns the reference of the given image to pull, moved to the Registry override if set.
+// A tag in the sha256:<digest> form pins the image by digest.
+func (c *ScanImageConfig) ImageRef(image, tag string) string {
+	ref := image + ":" + tag
+	if strings.HasPrefix(tag, "sha256:") {
+		ref = image + "@" + tag
+	}
+	if c.Registry == "" {
+		return ref
+	}
+	// strip the registry host of the image, if any, as docker would parse it
+	if i := strings.Index(ref, "/"); i > 0 {
+		if host := ref[:i]; host == "localhost" || strings.ContainsAny(host, ".:") {
+			ref = ref[i+1:]
+		}
+	}
+	return strings.TrimSuffix(c.Registry, "/") + "/" + ref
+}
+
+// ImagePullSecretRefs returns the ImagePullSecrets as referenced from a pod spec
+func (c *ScanImageConfig) ImagePullSecretRefs() []corev1.LocalObjectReference {
+	var refs []corev1.LocalObjectReference
+	for _, secret := range c.ImagePullSecrets {
+		refs = append(refs, corev1.LocalObjectReference{Name: secret})
+	}
+	return refs
+}
+
+// ScanPodConfig holds the operator-wide defaults for the pods running a scan
+type ScanPodConfig struct {
+	Tolerations               []corev1.Toleration
+	NodeAffinity              *corev1.NodeAffinity
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint
+	Resources                 *corev1.ResourceRequirements
+	PriorityClassName         string
+	Proxy                     ProxyConfig
+	// host paths, and the paths under them, the ClusterScans are allowed to mount, none if empty
+	HostPathAllowlist []string
+	// security context of the aggregator pod running the scan and of its container, hardened if nil
+	AggregatorPodSecurityContext *corev1.PodSecurityContext
+	AggregatorSecurityContext    *corev1.SecurityContext
+}
+
+// ClusterScanServiceName returns the name of the service the workers of a scan report to,
+// one per scan so that several scans can run at once
+func ClusterScanServiceName(scanName string) string {
+	return name.SafeConcatName(ClusterScanService, scanName)
+}
+
+// ProxyConfig holds the proxy settings of the scan pods and of the operator outbound requests
+type ProxyConfig struct {
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+}
+
+// EnvVars returns the proxy environment variables of the containers of a scan. The scan service is always
+// excluded from the proxy, so that the scan workers can reach the scan runner.
+func (p *ProxyConfig) EnvVars(scanName string) []corev1.EnvVar {
+	if p.HTTPProxy == "" && p.HTTPSProxy == "" {
+		return nil
+	}
+	noProxy := strings.Join([]string{ClusterScanServiceName(scanName), ".svc", ".cluster.local"}, ",")
+	if p.NoProxy != "" {
+		noProxy = p.NoProxy + "," + noProxy
+	}
+	var env []corev1.EnvVar
+	for name, value := range map[string]string{
+		"HTTP_PROXY":  p.HTTPProxy,
+		"HTTPS_PROXY": p.HTTPSProxy,
+		"NO_PROXY":    noProxy,
+	} {
+		if value == "" {
+			continue
+		}
+		// both cases are set since tools disagree on which one to read
+		env = append(env, corev1.EnvVar{Name: name, Value: value}, corev1.EnvVar{Name: strings.ToLower(name), Value: value})
+	}
+	sort.Slice(env, func(i, j int) bool { return env[i].Name < env[j].Name })
+	return env
 }
@@ -23,9 +23,27 @@ package v1
 
 import (
 	genericcondition "github.com/rancher/wrangler/pkg/genericcondition"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckRemediation) DeepCopyInto(out *CheckRemediation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckRemediation.
+func (in *CheckRemediation) DeepCopy() *CheckRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterScan) DeepCopyInto(out *ClusterScan) {
 	*out = *in
@@ -147,32 +165,27 @@ func (in *ClusterScanBenchmarkSpec) DeepCopy() *ClusterScanBenchmarkSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterScanList) DeepCopyInto(out *ClusterScanList) {
+func (in *ClusterScanCampaign) DeepCopyInto(out *ClusterScanCampaign) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterScan, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanList.
-func (in *ClusterScanList) DeepCopy() *ClusterScanList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanCampaign.
+func (in *ClusterScanCampaign) DeepCopy() *ClusterScanCampaign {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterScanList)
+	out := new(ClusterScanCampaign)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterScanList) DeepCopyObject() runtime.Object {
+func (in *ClusterScanCampaign) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -180,40 +193,29 @@ func (in *ClusterScanList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterScanProfile) DeepCopyInto(out *ClusterScanProfile) {
+func (in *ClusterScanCampaignBurnDownPoint) DeepCopyInto(out *ClusterScanCampaignBurnDownPoint) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanProfile.
-func (in *ClusterScanProfile) DeepCopy() *ClusterScanProfile {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanCampaignBurnDownPoint.
+func (in *ClusterScanCampaignBurnDownPoint) DeepCopy() *ClusterScanCampaignBurnDownPoint {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterScanProfile)
+	out := new(ClusterScanCampaignBurnDownPoint)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterScanProfile) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterScanProfileList) DeepCopyInto(out *ClusterScanProfileList) {
+func (in *ClusterScanCampaignList) DeepCopyInto(out *ClusterScanCampaignList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterScanProfile, len(*in))
+		*out = make([]ClusterScanCampaign, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -221,18 +223,18 @@ func (in *ClusterScanProfileList) DeepCopyInto(out *ClusterScanProfileList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanProfileList.
-func (in *ClusterScanProfileList) DeepCopy() *ClusterScanProfileList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanCampaignList.
+func (in *ClusterScanCampaignList) DeepCopy() *ClusterScanCampaignList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterScanProfileList)
+	out := new(ClusterScanCampaignList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterScanProfileList) DeepCopyObject() runtime.Object {
+func (in *ClusterScanCampaignList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -240,219 +242,1891 @@ func (in *ClusterScanProfileList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterScanProfileSpec) DeepCopyInto(out *ClusterScanProfileSpec) {
+func (in *ClusterScanCampaignSpec) DeepCopyInto(out *ClusterScanCampaignSpec) {
 	*out = *in
-	if in.SkipTests != nil {
-		in, out := &in.SkipTests, &out.SkipTests
+	if in.TestIDs != nil {
+		in, out := &in.TestIDs, &out.TestIDs
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ScanSelector != nil {
+		in, out := &in.ScanSelector, &out.ScanSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanProfileSpec.
-func (in *ClusterScanProfileSpec) DeepCopy() *ClusterScanProfileSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanCampaignSpec.
+func (in *ClusterScanCampaignSpec) DeepCopy() *ClusterScanCampaignSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterScanProfileSpec)
+	out := new(ClusterScanCampaignSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterScanReport) DeepCopyInto(out *ClusterScanReport) {
+func (in *ClusterScanCampaignStatus) DeepCopyInto(out *ClusterScanCampaignStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	if in.Remaining != nil {
+		in, out := &in.Remaining, &out.Remaining
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.BurnDown != nil {
+		in, out := &in.BurnDown, &out.BurnDown
+		*out = make([]ClusterScanCampaignBurnDownPoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanReport.
-func (in *ClusterScanReport) DeepCopy() *ClusterScanReport {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanCampaignStatus.
+func (in *ClusterScanCampaignStatus) DeepCopy() *ClusterScanCampaignStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterScanReport)
+	out := new(ClusterScanCampaignStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterScanReport) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanCheckChange) DeepCopyInto(out *ClusterScanCheckChange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanCheckChange.
+func (in *ClusterScanCheckChange) DeepCopy() *ClusterScanCheckChange {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(ClusterScanCheckChange)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterScanReportList) DeepCopyInto(out *ClusterScanReportList) {
+func (in *ClusterScanCheckRemediation) DeepCopyInto(out *ClusterScanCheckRemediation) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterScanReport, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanReportList.
-func (in *ClusterScanReportList) DeepCopy() *ClusterScanReportList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanCheckRemediation.
+func (in *ClusterScanCheckRemediation) DeepCopy() *ClusterScanCheckRemediation {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterScanReportList)
+	out := new(ClusterScanCheckRemediation)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterScanReportList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanComplianceScore) DeepCopyInto(out *ClusterScanComplianceScore) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanComplianceScore.
+func (in *ClusterScanComplianceScore) DeepCopy() *ClusterScanComplianceScore {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(ClusterScanComplianceScore)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterScanReportSpec) DeepCopyInto(out *ClusterScanReportSpec) {
+func (in *ClusterScanContinuousConfig) DeepCopyInto(out *ClusterScanContinuousConfig) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanReportSpec.
-func (in *ClusterScanReportSpec) DeepCopy() *ClusterScanReportSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanContinuousConfig.
+func (in *ClusterScanContinuousConfig) DeepCopy() *ClusterScanContinuousConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterScanReportSpec)
+	out := new(ClusterScanContinuousConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterScanSpec) DeepCopyInto(out *ClusterScanSpec) {
+func (in *ClusterScanCost) DeepCopyInto(out *ClusterScanCost) {
 	*out = *in
-	if in.ScheduledScanConfig != nil {
-		in, out := &in.ScheduledScanConfig, &out.ScheduledScanConfig
-		*out = new(ScheduledScanConfig)
-		(*in).DeepCopyInto(*out)
+	if in.APIRequestsByVerb != nil {
+		in, out := &in.APIRequestsByVerb, &out.APIRequestsByVerb
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanSpec.
-func (in *ClusterScanSpec) DeepCopy() *ClusterScanSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanCost.
+func (in *ClusterScanCost) DeepCopy() *ClusterScanCost {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterScanSpec)
+	out := new(ClusterScanCost)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterScanStatus) DeepCopyInto(out *ClusterScanStatus) {
+func (in *ClusterScanDrift) DeepCopyInto(out *ClusterScanDrift) {
 	*out = *in
-	if in.Display != nil {
-		in, out := &in.Display, &out.Display
-		*out = new(ClusterScanStatusDisplay)
-		**out = **in
+	if in.FailingNodes != nil {
+		in, out := &in.FailingNodes, &out.FailingNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Summary != nil {
-		in, out := &in.Summary, &out.Summary
-		*out = new(ClusterScanSummary)
-		**out = **in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanDrift.
+func (in *ClusterScanDrift) DeepCopy() *ClusterScanDrift {
+	if in == nil {
+		return nil
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]genericcondition.GenericCondition, len(*in))
-		copy(*out, *in)
+	out := new(ClusterScanDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanExemption) DeepCopyInto(out *ClusterScanExemption) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanExemption.
+func (in *ClusterScanExemption) DeepCopy() *ClusterScanExemption {
+	if in == nil {
+		return nil
 	}
+	out := new(ClusterScanExemption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanHostPathVolume) DeepCopyInto(out *ClusterScanHostPathVolume) {
+	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanStatus.
-func (in *ClusterScanStatus) DeepCopy() *ClusterScanStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanHostPathVolume.
+func (in *ClusterScanHostPathVolume) DeepCopy() *ClusterScanHostPathVolume {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterScanStatus)
+	out := new(ClusterScanHostPathVolume)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterScanStatusDisplay) DeepCopyInto(out *ClusterScanStatusDisplay) {
+func (in *ClusterScanList) DeepCopyInto(out *ClusterScanList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterScan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanStatusDisplay.
-func (in *ClusterScanStatusDisplay) DeepCopy() *ClusterScanStatusDisplay {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanList.
+func (in *ClusterScanList) DeepCopy() *ClusterScanList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterScanStatusDisplay)
+	out := new(ClusterScanList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterScanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterScanSummary) DeepCopyInto(out *ClusterScanSummary) {
+func (in *ClusterScanNodeAction) DeepCopyInto(out *ClusterScanNodeAction) {
 	*out = *in
+	if in.TestIDs != nil {
+		in, out := &in.TestIDs, &out.TestIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanSummary.
-func (in *ClusterScanSummary) DeepCopy() *ClusterScanSummary {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanNodeAction.
+func (in *ClusterScanNodeAction) DeepCopy() *ClusterScanNodeAction {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterScanSummary)
+	out := new(ClusterScanNodeAction)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScanImageConfig) DeepCopyInto(out *ScanImageConfig) {
+func (in *ClusterScanNodeActionStatus) DeepCopyInto(out *ClusterScanNodeActionStatus) {
 	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanImageConfig.
-func (in *ScanImageConfig) DeepCopy() *ScanImageConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanNodeActionStatus.
+func (in *ClusterScanNodeActionStatus) DeepCopy() *ClusterScanNodeActionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ScanImageConfig)
+	out := new(ClusterScanNodeActionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduledScanConfig) DeepCopyInto(out *ScheduledScanConfig) {
+func (in *ClusterScanNodeGroup) DeepCopyInto(out *ClusterScanNodeGroup) {
 	*out = *in
-	if in.ScanAlertRule != nil {
-		in, out := &in.ScanAlertRule, &out.ScanAlertRule
-		*out = new(ClusterScanAlertRule)
-		**out = **in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
+	out.Summary = in.Summary
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledScanConfig.
-func (in *ScheduledScanConfig) DeepCopy() *ScheduledScanConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanNodeGroup.
+func (in *ClusterScanNodeGroup) DeepCopy() *ClusterScanNodeGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduledScanConfig)
+	out := new(ClusterScanNodeGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanProfile) DeepCopyInto(out *ClusterScanProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanProfile.
+func (in *ClusterScanProfile) DeepCopy() *ClusterScanProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterScanProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanProfileList) DeepCopyInto(out *ClusterScanProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterScanProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanProfileList.
+func (in *ClusterScanProfileList) DeepCopy() *ClusterScanProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterScanProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanProfileRename) DeepCopyInto(out *ClusterScanProfileRename) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanProfileRename.
+func (in *ClusterScanProfileRename) DeepCopy() *ClusterScanProfileRename {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanProfileRename)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanProfileSpec) DeepCopyInto(out *ClusterScanProfileSpec) {
+	*out = *in
+	if in.SkipTests != nil {
+		in, out := &in.SkipTests, &out.SkipTests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exemptions != nil {
+		in, out := &in.Exemptions, &out.Exemptions
+		*out = make([]ClusterScanExemption, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultSkipTests != nil {
+		in, out := &in.DefaultSkipTests, &out.DefaultSkipTests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanProfileSpec.
+func (in *ClusterScanProfileSpec) DeepCopy() *ClusterScanProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanProgress) DeepCopyInto(out *ClusterScanProgress) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanProgress.
+func (in *ClusterScanProgress) DeepCopy() *ClusterScanProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanRemediation) DeepCopyInto(out *ClusterScanRemediation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanRemediation.
+func (in *ClusterScanRemediation) DeepCopy() *ClusterScanRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterScanRemediation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanRemediationExecution) DeepCopyInto(out *ClusterScanRemediationExecution) {
+	*out = *in
+	if in.TestIDs != nil {
+		in, out := &in.TestIDs, &out.TestIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanRemediationExecution.
+func (in *ClusterScanRemediationExecution) DeepCopy() *ClusterScanRemediationExecution {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanRemediationExecution)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanRemediationList) DeepCopyInto(out *ClusterScanRemediationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterScanRemediation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanRemediationList.
+func (in *ClusterScanRemediationList) DeepCopy() *ClusterScanRemediationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanRemediationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterScanRemediationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanRemediationSpec) DeepCopyInto(out *ClusterScanRemediationSpec) {
+	*out = *in
+	if in.TestIDs != nil {
+		in, out := &in.TestIDs, &out.TestIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScanSelector != nil {
+		in, out := &in.ScanSelector, &out.ScanSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Template.DeepCopyInto(&out.Template)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanRemediationSpec.
+func (in *ClusterScanRemediationSpec) DeepCopy() *ClusterScanRemediationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanRemediationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanRemediationStatus) DeepCopyInto(out *ClusterScanRemediationStatus) {
+	*out = *in
+	if in.Executions != nil {
+		in, out := &in.Executions, &out.Executions
+		*out = make([]ClusterScanRemediationExecution, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanRemediationStatus.
+func (in *ClusterScanRemediationStatus) DeepCopy() *ClusterScanRemediationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanRemediationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanReport) DeepCopyInto(out *ClusterScanReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanReport.
+func (in *ClusterScanReport) DeepCopy() *ClusterScanReport {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterScanReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanReportDiff) DeepCopyInto(out *ClusterScanReportDiff) {
+	*out = *in
+	if in.NewlyFailing != nil {
+		in, out := &in.NewlyFailing, &out.NewlyFailing
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NewlyPassing != nil {
+		in, out := &in.NewlyPassing, &out.NewlyPassing
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Changed != nil {
+		in, out := &in.Changed, &out.Changed
+		*out = make([]ClusterScanCheckChange, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanReportDiff.
+func (in *ClusterScanReportDiff) DeepCopy() *ClusterScanReportDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanReportDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanReportList) DeepCopyInto(out *ClusterScanReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterScanReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanReportList.
+func (in *ClusterScanReportList) DeepCopy() *ClusterScanReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterScanReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanReportShard) DeepCopyInto(out *ClusterScanReportShard) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanReportShard.
+func (in *ClusterScanReportShard) DeepCopy() *ClusterScanReportShard {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanReportShard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterScanReportShard) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanReportShardList) DeepCopyInto(out *ClusterScanReportShardList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterScanReportShard, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanReportShardList.
+func (in *ClusterScanReportShardList) DeepCopy() *ClusterScanReportShardList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanReportShardList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterScanReportShardList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanReportShardSpec) DeepCopyInto(out *ClusterScanReportShardSpec) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.CheckNodes != nil {
+		in, out := &in.CheckNodes, &out.CheckNodes
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanReportShardSpec.
+func (in *ClusterScanReportShardSpec) DeepCopy() *ClusterScanReportShardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanReportShardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanReportShardStatus) DeepCopyInto(out *ClusterScanReportShardStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanReportShardStatus.
+func (in *ClusterScanReportShardStatus) DeepCopy() *ClusterScanReportShardStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanReportShardStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanReportSpec) DeepCopyInto(out *ClusterScanReportSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodesInScope != nil {
+		in, out := &in.NodesInScope, &out.NodesInScope
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TeamSummaries != nil {
+		in, out := &in.TeamSummaries, &out.TeamSummaries
+		*out = make(map[string]ClusterScanSummary, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Exemptions != nil {
+		in, out := &in.Exemptions, &out.Exemptions
+		*out = make([]ClusterScanExemption, len(*in))
+		copy(*out, *in)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]ClusterScanDrift, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeGroups != nil {
+		in, out := &in.NodeGroups, &out.NodeGroups
+		*out = make([]ClusterScanNodeGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SelfCheck != nil {
+		in, out := &in.SelfCheck, &out.SelfCheck
+		*out = make([]SelfCheckFinding, len(*in))
+		copy(*out, *in)
+	}
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = new(ClusterScanReportDiff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ComplianceScore != nil {
+		in, out := &in.ComplianceScore, &out.ComplianceScore
+		*out = new(ClusterScanComplianceScore)
+		**out = **in
+	}
+	if in.Remediations != nil {
+		in, out := &in.Remediations, &out.Remediations
+		*out = make([]ClusterScanCheckRemediation, len(*in))
+		copy(*out, *in)
+	}
+	if in.EvaluationMethods != nil {
+		in, out := &in.EvaluationMethods, &out.EvaluationMethods
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IncludedChecks != nil {
+		in, out := &in.IncludedChecks, &out.IncludedChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProcessedBy != nil {
+		in, out := &in.ProcessedBy, &out.ProcessedBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PluginErrors != nil {
+		in, out := &in.PluginErrors, &out.PluginErrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanReportSpec.
+func (in *ClusterScanReportSpec) DeepCopy() *ClusterScanReportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanReportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanReportStatus) DeepCopyInto(out *ClusterScanReportStatus) {
+	*out = *in
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = make([]ClusterScanReportShardStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanReportStatus.
+func (in *ClusterScanReportStatus) DeepCopy() *ClusterScanReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanRetryPolicy) DeepCopyInto(out *ClusterScanRetryPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanRetryPolicy.
+func (in *ClusterScanRetryPolicy) DeepCopy() *ClusterScanRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanSpec) DeepCopyInto(out *ClusterScanSpec) {
+	*out = *in
+	if in.ScheduledScanConfig != nil {
+		in, out := &in.ScheduledScanConfig, &out.ScheduledScanConfig
+		*out = new(ScheduledScanConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeNames != nil {
+		in, out := &in.NodeNames, &out.NodeNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OSImages != nil {
+		in, out := &in.OSImages, &out.OSImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(ClusterScanRetryPolicy)
+		**out = **in
+	}
+	if in.NodeGroupLabels != nil {
+		in, out := &in.NodeGroupLabels, &out.NodeGroupLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeGroupDimensions != nil {
+		in, out := &in.NodeGroupDimensions, &out.NodeGroupDimensions
+		*out = make([]NodeGroupDimension, len(*in))
+		copy(*out, *in)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]ClusterScanHostPathVolume, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]corev1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Continuous != nil {
+		in, out := &in.Continuous, &out.Continuous
+		*out = new(ClusterScanContinuousConfig)
+		**out = **in
+	}
+	if in.IncludeChecks != nil {
+		in, out := &in.IncludeChecks, &out.IncludeChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalProfileNames != nil {
+		in, out := &in.AdditionalProfileNames, &out.AdditionalProfileNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeAction != nil {
+		in, out := &in.NodeAction, &out.NodeAction
+		*out = new(ClusterScanNodeAction)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanSpec.
+func (in *ClusterScanSpec) DeepCopy() *ClusterScanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanStatus) DeepCopyInto(out *ClusterScanStatus) {
+	*out = *in
+	if in.Display != nil {
+		in, out := &in.Display, &out.Display
+		*out = new(ClusterScanStatusDisplay)
+		**out = **in
+	}
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = new(ClusterScanSummary)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.TeamSummaries != nil {
+		in, out := &in.TeamSummaries, &out.TeamSummaries
+		*out = make(map[string]ClusterScanSummary, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FailingChecks != nil {
+		in, out := &in.FailingChecks, &out.FailingChecks
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RemediatedChecks != nil {
+		in, out := &in.RemediatedChecks, &out.RemediatedChecks
+		*out = make(map[string]CheckRemediation, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DriftedChecks != nil {
+		in, out := &in.DriftedChecks, &out.DriftedChecks
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Regressions != nil {
+		in, out := &in.Regressions, &out.Regressions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Trend != nil {
+		in, out := &in.Trend, &out.Trend
+		*out = make([]ClusterScanTrendPoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.ComplianceScore != nil {
+		in, out := &in.ComplianceScore, &out.ComplianceScore
+		*out = new(ClusterScanComplianceScore)
+		**out = **in
+	}
+	if in.PostureDrift != nil {
+		in, out := &in.PostureDrift, &out.PostureDrift
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SampledNodes != nil {
+		in, out := &in.SampledNodes, &out.SampledNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProfileRenames != nil {
+		in, out := &in.ProfileRenames, &out.ProfileRenames
+		*out = make([]ClusterScanProfileRename, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeAction != nil {
+		in, out := &in.NodeAction, &out.NodeAction
+		*out = new(ClusterScanNodeActionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cost != nil {
+		in, out := &in.Cost, &out.Cost
+		*out = new(ClusterScanCost)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(ClusterScanProgress)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanStatus.
+func (in *ClusterScanStatus) DeepCopy() *ClusterScanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanStatusDisplay) DeepCopyInto(out *ClusterScanStatusDisplay) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanStatusDisplay.
+func (in *ClusterScanStatusDisplay) DeepCopy() *ClusterScanStatusDisplay {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanStatusDisplay)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanSummary) DeepCopyInto(out *ClusterScanSummary) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanSummary.
+func (in *ClusterScanSummary) DeepCopy() *ClusterScanSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanTemplate) DeepCopyInto(out *ClusterScanTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanTemplate.
+func (in *ClusterScanTemplate) DeepCopy() *ClusterScanTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterScanTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanTemplateCluster) DeepCopyInto(out *ClusterScanTemplateCluster) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanTemplateCluster.
+func (in *ClusterScanTemplateCluster) DeepCopy() *ClusterScanTemplateCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanTemplateCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanTemplateList) DeepCopyInto(out *ClusterScanTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterScanTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanTemplateList.
+func (in *ClusterScanTemplateList) DeepCopy() *ClusterScanTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterScanTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanTemplateSpec) DeepCopyInto(out *ClusterScanTemplateSpec) {
+	*out = *in
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Template.DeepCopyInto(&out.Template)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanTemplateSpec.
+func (in *ClusterScanTemplateSpec) DeepCopy() *ClusterScanTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanTemplateStatus) DeepCopyInto(out *ClusterScanTemplateStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterScanTemplateCluster, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanTemplateStatus.
+func (in *ClusterScanTemplateStatus) DeepCopy() *ClusterScanTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScanTrendPoint) DeepCopyInto(out *ClusterScanTrendPoint) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanTrendPoint.
+func (in *ClusterScanTrendPoint) DeepCopy() *ClusterScanTrendPoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScanTrendPoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGroupDimension) DeepCopyInto(out *NodeGroupDimension) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupDimension.
+func (in *NodeGroupDimension) DeepCopy() *NodeGroupDimension {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupDimension)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfig) DeepCopyInto(out *OperatorConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfig.
+func (in *OperatorConfig) DeepCopy() *OperatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OperatorConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigDefaultProfiles) DeepCopyInto(out *OperatorConfigDefaultProfiles) {
+	*out = *in
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigDefaultProfiles.
+func (in *OperatorConfigDefaultProfiles) DeepCopy() *OperatorConfigDefaultProfiles {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigDefaultProfiles)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigImages) DeepCopyInto(out *OperatorConfigImages) {
+	*out = *in
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigImages.
+func (in *OperatorConfigImages) DeepCopy() *OperatorConfigImages {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigImages)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigList) DeepCopyInto(out *OperatorConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OperatorConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigList.
+func (in *OperatorConfigList) DeepCopy() *OperatorConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OperatorConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigMetrics) DeepCopyInto(out *OperatorConfigMetrics) {
+	*out = *in
+	if in.TrendWindow != nil {
+		in, out := &in.TrendWindow, &out.TrendWindow
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigMetrics.
+func (in *OperatorConfigMetrics) DeepCopy() *OperatorConfigMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigReports) DeepCopyInto(out *OperatorConfigReports) {
+	*out = *in
+	if in.RetentionDays != nil {
+		in, out := &in.RetentionDays, &out.RetentionDays
+		*out = new(int)
+		**out = **in
+	}
+	if in.CompressionThreshold != nil {
+		in, out := &in.CompressionThreshold, &out.CompressionThreshold
+		*out = new(int)
+		**out = **in
+	}
+	if in.ShardSize != nil {
+		in, out := &in.ShardSize, &out.ShardSize
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigReports.
+func (in *OperatorConfigReports) DeepCopy() *OperatorConfigReports {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigReports)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigSinks) DeepCopyInto(out *OperatorConfigSinks) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigSinks.
+func (in *OperatorConfigSinks) DeepCopy() *OperatorConfigSinks {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigSinks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigSpec) DeepCopyInto(out *OperatorConfigSpec) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = new(OperatorConfigImages)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = new(OperatorConfigSinks)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(OperatorConfigMetrics)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultProfiles != nil {
+		in, out := &in.DefaultProfiles, &out.DefaultProfiles
+		*out = new(OperatorConfigDefaultProfiles)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Reports != nil {
+		in, out := &in.Reports, &out.Reports
+		*out = new(OperatorConfigReports)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigSpec.
+func (in *OperatorConfigSpec) DeepCopy() *OperatorConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigStatus) DeepCopyInto(out *OperatorConfigStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigStatus.
+func (in *OperatorConfigStatus) DeepCopy() *OperatorConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanImageConfig) DeepCopyInto(out *ScanImageConfig) {
+	*out = *in
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultProfiles != nil {
+		in, out := &in.DefaultProfiles, &out.DefaultProfiles
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ReportPlugins != nil {
+		in, out := &in.ReportPlugins, &out.ReportPlugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanImageConfig.
+func (in *ScanImageConfig) DeepCopy() *ScanImageConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanImageConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanPodConfig) DeepCopyInto(out *ScanPodConfig) {
+	*out = *in
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Proxy = in.Proxy
+	if in.HostPathAllowlist != nil {
+		in, out := &in.HostPathAllowlist, &out.HostPathAllowlist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AggregatorPodSecurityContext != nil {
+		in, out := &in.AggregatorPodSecurityContext, &out.AggregatorPodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AggregatorSecurityContext != nil {
+		in, out := &in.AggregatorSecurityContext, &out.AggregatorSecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanPodConfig.
+func (in *ScanPodConfig) DeepCopy() *ScanPodConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanPodConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanRollupConfig) DeepCopyInto(out *ScanRollupConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanRollupConfig.
+func (in *ScanRollupConfig) DeepCopy() *ScanRollupConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanRollupConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanSubscription) DeepCopyInto(out *ScanSubscription) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanSubscription.
+func (in *ScanSubscription) DeepCopy() *ScanSubscription {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanSubscription)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScanSubscription) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanSubscriptionDelivery) DeepCopyInto(out *ScanSubscriptionDelivery) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanSubscriptionDelivery.
+func (in *ScanSubscriptionDelivery) DeepCopy() *ScanSubscriptionDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanSubscriptionDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanSubscriptionList) DeepCopyInto(out *ScanSubscriptionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScanSubscription, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanSubscriptionList.
+func (in *ScanSubscriptionList) DeepCopy() *ScanSubscriptionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanSubscriptionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScanSubscriptionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanSubscriptionPVCTarget) DeepCopyInto(out *ScanSubscriptionPVCTarget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanSubscriptionPVCTarget.
+func (in *ScanSubscriptionPVCTarget) DeepCopy() *ScanSubscriptionPVCTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanSubscriptionPVCTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanSubscriptionSpec) DeepCopyInto(out *ScanSubscriptionSpec) {
+	*out = *in
+	if in.ScanSelector != nil {
+		in, out := &in.ScanSelector, &out.ScanSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Target.DeepCopyInto(&out.Target)
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rollup != nil {
+		in, out := &in.Rollup, &out.Rollup
+		*out = new(ScanRollupConfig)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanSubscriptionSpec.
+func (in *ScanSubscriptionSpec) DeepCopy() *ScanSubscriptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanSubscriptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanSubscriptionStatus) DeepCopyInto(out *ScanSubscriptionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingDeliveries != nil {
+		in, out := &in.PendingDeliveries, &out.PendingDeliveries
+		*out = make([]ScanSubscriptionDelivery, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanSubscriptionStatus.
+func (in *ScanSubscriptionStatus) DeepCopy() *ScanSubscriptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanSubscriptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanSubscriptionTarget) DeepCopyInto(out *ScanSubscriptionTarget) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(ScanSubscriptionPVCTarget)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanSubscriptionTarget.
+func (in *ScanSubscriptionTarget) DeepCopy() *ScanSubscriptionTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanSubscriptionTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledScanConfig) DeepCopyInto(out *ScheduledScanConfig) {
+	*out = *in
+	if in.ScanAlertRule != nil {
+		in, out := &in.ScanAlertRule, &out.ScanAlertRule
+		*out = new(ClusterScanAlertRule)
+		**out = **in
+	}
+	if in.RegressionBudget != nil {
+		in, out := &in.RegressionBudget, &out.RegressionBudget
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledScanConfig.
+func (in *ScheduledScanConfig) DeepCopy() *ScheduledScanConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledScanConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelfCheckFinding) DeepCopyInto(out *SelfCheckFinding) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfCheckFinding.
+func (in *SelfCheckFinding) DeepCopy() *SelfCheckFinding {
+	if in == nil {
+		return nil
+	}
+	out := new(SelfCheckFinding)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -91,3 +91,105 @@ func NewClusterScanBenchmark(namespace, name string, obj ClusterScanBenchmark) *
 	obj.Namespace = namespace
 	return &obj
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScanSubscriptionList is a list of ScanSubscription resources
+type ScanSubscriptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ScanSubscription `json:"items"`
+}
+
+func NewScanSubscription(namespace, name string, obj ScanSubscription) *ScanSubscription {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ScanSubscription").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterScanReportShardList is a list of ClusterScanReportShard resources
+type ClusterScanReportShardList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterScanReportShard `json:"items"`
+}
+
+func NewClusterScanReportShard(namespace, name string, obj ClusterScanReportShard) *ClusterScanReportShard {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ClusterScanReportShard").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterScanRemediationList is a list of ClusterScanRemediation resources
+type ClusterScanRemediationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterScanRemediation `json:"items"`
+}
+
+func NewClusterScanRemediation(namespace, name string, obj ClusterScanRemediation) *ClusterScanRemediation {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ClusterScanRemediation").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterScanCampaignList is a list of ClusterScanCampaign resources
+type ClusterScanCampaignList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterScanCampaign `json:"items"`
+}
+
+func NewClusterScanCampaign(namespace, name string, obj ClusterScanCampaign) *ClusterScanCampaign {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ClusterScanCampaign").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterScanTemplateList is a list of ClusterScanTemplate resources
+type ClusterScanTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterScanTemplate `json:"items"`
+}
+
+func NewClusterScanTemplate(namespace, name string, obj ClusterScanTemplate) *ClusterScanTemplate {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ClusterScanTemplate").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OperatorConfigList is a list of OperatorConfig resources
+type OperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []OperatorConfig `json:"items"`
+}
+
+func NewOperatorConfig(namespace, name string, obj OperatorConfig) *OperatorConfig {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("OperatorConfig").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
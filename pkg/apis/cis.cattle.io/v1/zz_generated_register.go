@@ -28,10 +28,16 @@ import (
 )
 
 var (
-	ClusterScanResourceName          = "clusterscans"
-	ClusterScanBenchmarkResourceName = "clusterscanbenchmarks"
-	ClusterScanProfileResourceName   = "clusterscanprofiles"
-	ClusterScanReportResourceName    = "clusterscanreports"
+	ClusterScanResourceName            = "clusterscans"
+	ClusterScanBenchmarkResourceName   = "clusterscanbenchmarks"
+	ClusterScanCampaignResourceName    = "clusterscancampaigns"
+	ClusterScanProfileResourceName     = "clusterscanprofiles"
+	ClusterScanRemediationResourceName = "clusterscanremediations"
+	ClusterScanReportResourceName      = "clusterscanreports"
+	ClusterScanReportShardResourceName = "clusterscanreportshards"
+	ClusterScanTemplateResourceName    = "clusterscantemplates"
+	OperatorConfigResourceName         = "operatorconfigs"
+	ScanSubscriptionResourceName       = "scansubscriptions"
 )
 
 // SchemeGroupVersion is group version used to register these objects
@@ -59,10 +65,22 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ClusterScanList{},
 		&ClusterScanBenchmark{},
 		&ClusterScanBenchmarkList{},
+		&ClusterScanCampaign{},
+		&ClusterScanCampaignList{},
 		&ClusterScanProfile{},
 		&ClusterScanProfileList{},
+		&ClusterScanRemediation{},
+		&ClusterScanRemediationList{},
 		&ClusterScanReport{},
 		&ClusterScanReportList{},
+		&ClusterScanReportShard{},
+		&ClusterScanReportShardList{},
+		&ClusterScanTemplate{},
+		&ClusterScanTemplateList{},
+		&OperatorConfig{},
+		&OperatorConfigList{},
+		&ScanSubscription{},
+		&ScanSubscriptionList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -0,0 +1,121 @@
+package v2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+const (
+	CheckStatePass          = "pass"
+	CheckStateFail          = "fail"
+	CheckStateSkip          = "skip"
+	CheckStateMixed         = "mixed"
+	CheckStateNotApplicable = "notApplicable"
+	CheckStateWarn          = "warn"
+
+	CheckSeverityHigh          = "high"
+	CheckSeverityMedium        = "medium"
+	CheckSeverityLow           = "low"
+	CheckSeverityInformational = "informational"
+
+	// annotation of the v2 reports converted from a v1 report, keeping the reportEncoding of its reportJSON for the
+	// conversion back to v1
+	ReportEncodingAnnotation = "cis.cattle.io/report-encoding"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterScanReport is the v2 of the v1 ClusterScanReport, with its results structured in place of the reportJSON.
+// The reports are stored in v1 and converted by the conversion webhook of the operator.
+type ClusterScanReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterScanReportSpec      `json:"spec"`
+	Status v1.ClusterScanReportStatus `json:"status,omitempty"`
+}
+
+// ClusterScanReportSpec holds the fields of the v1 spec, see v1.ClusterScanReportSpec, the reportJSON and its
+// reportEncoding excepted.
+type ClusterScanReportSpec struct {
+	BenchmarkVersion string `json:"benchmarkVersion,omitempty"`
+	LastRunTimestamp string `json:"lastRunTimestamp"`
+	// results of the scan, the nodes of the checks are left out of those of a sharded report, they are in its
+	// ClusterScanReportShards
+	Results ClusterScanResults `json:"results"`
+	Sharded bool               `json:"sharded,omitempty"`
+
+	NodeSelector      map[string]string                `json:"nodeSelector,omitempty"`
+	NodesInScope      []string                         `json:"nodesInScope,omitempty"`
+	PartialCoverage   bool                             `json:"partialCoverage,omitempty"`
+	TotalNodeCount    int                              `json:"totalNodeCount,omitempty"`
+	ScanProfileName   string                           `json:"scanProfileName,omitempty"`
+	AdditionalProfile bool                             `json:"additionalProfile,omitempty"`
+	DurationSeconds   int64                            `json:"durationSeconds,omitempty"`
+	NodeCount         int                              `json:"nodeCount,omitempty"`
+	TeamSummaries     map[string]v1.ClusterScanSummary `json:"teamSummaries,omitempty"`
+	Exemptions        []v1.ClusterScanExemption        `json:"exemptions,omitempty"`
+	Drift             []v1.ClusterScanDrift            `json:"drift,omitempty"`
+	NodeGroups        []v1.ClusterScanNodeGroup        `json:"nodeGroups,omitempty"`
+	SelfCheck         []v1.SelfCheckFinding            `json:"selfCheck,omitempty"`
+	Diff              *v1.ClusterScanReportDiff        `json:"diff,omitempty"`
+	// DSSE envelope of the attestation of the v1 reportJSON, see v1.ClusterScanReportSpec
+	Attestation       string                           `json:"attestation,omitempty"`
+	ComplianceScore   *v1.ClusterScanComplianceScore   `json:"complianceScore,omitempty"`
+	Remediations      []v1.ClusterScanCheckRemediation `json:"remediations,omitempty"`
+	EvaluationMethods map[string]string                `json:"evaluationMethods,omitempty"`
+	IncludedChecks    []string                         `json:"includedChecks,omitempty"`
+	ProcessedBy       []string                         `json:"processedBy,omitempty"`
+	PluginErrors      []string                         `json:"pluginErrors,omitempty"`
+}
+
+type ClusterScanResults struct {
+	// version of the benchmark the checks come from
+	Version string                `json:"version,omitempty"`
+	Summary v1.ClusterScanSummary `json:"summary"`
+	// nodes scanned, by node type: etcd, master or node
+	Nodes  map[string][]string     `json:"nodes,omitempty"`
+	Groups []ClusterScanCheckGroup `json:"groups,omitempty"`
+}
+
+type ClusterScanCheckGroup struct {
+	ID          string             `json:"id"`
+	Description string             `json:"description,omitempty"`
+	Checks      []ClusterScanCheck `json:"checks,omitempty"`
+}
+
+type ClusterScanCheck struct {
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	// one of the CheckState constants, mixed when it differs across the nodes
+	State string `json:"state"`
+	// derived from the state and whether the check is scored, see GetCheckSeverity
+	Severity    string `json:"severity"`
+	Scored      bool   `json:"scored"`
+	Remediation string `json:"remediation,omitempty"`
+	// node types the check runs on, and the nodes it did not pass on
+	NodeTypes      []string `json:"nodeTypes,omitempty"`
+	Nodes          []string `json:"nodes,omitempty"`
+	Audit          string   `json:"audit,omitempty"`
+	AuditConfig    string   `json:"auditConfig,omitempty"`
+	ExpectedResult string   `json:"expectedResult,omitempty"`
+	TestInfo       []string `json:"testInfo,omitempty"`
+}
+
+// GetCheckSeverity rates the failing scored checks high and the other failing checks medium, the warnings low and the
+// rest informational.
+func GetCheckSeverity(state string, scored bool) string {
+	switch state {
+	case CheckStateFail, CheckStateMixed:
+		if scored {
+			return CheckSeverityHigh
+		}
+		return CheckSeverityMedium
+	case CheckStateWarn:
+		return CheckSeverityLow
+	}
+	return CheckSeverityInformational
+}
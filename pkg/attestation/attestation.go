@@ -0,0 +1,205 @@
+// Package attestation signs the scan reports and verifies their signatures. An attestation is a DSSE
+// envelope of an in-toto statement whose subject is the sha256 digest of the report JSON, the format
+// verified by cosign verify-blob-attestation.
+package attestation
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/rancher/cis-operator/pkg/fips"
+)
+
+const (
+	PayloadType   = "application/vnd.in-toto+json"
+	StatementType = "https://in-toto.io/Statement/v1"
+	PredicateType = "https://cis.cattle.io/attestation/report/v1"
+)
+
+// ErrVerification is returned when an attestation is not signed with the key or not about the report.
+var ErrVerification = errors.New("report attestation verification failed")
+
+// Predicate describes the scan that produced the report.
+type Predicate struct {
+	ScanName         string `json:"scanName"`
+	ScanProfileName  string `json:"scanProfileName,omitempty"`
+	BenchmarkVersion string `json:"benchmarkVersion"`
+	LastRunTimestamp string `json:"lastRunTimestamp"`
+}
+
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+type Statement struct {
+	Type          string    `json:"_type"`
+	Subject       []Subject `json:"subject"`
+	PredicateType string    `json:"predicateType"`
+	Predicate     Predicate `json:"predicate"`
+}
+
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+type Signature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// Signer signs attestations with a private key.
+type Signer struct {
+	key crypto.Signer
+}
+
+// NewSigner builds a Signer from an unencrypted PEM encoded ECDSA, RSA or Ed25519 private key.
+func NewSigner(privateKeyPEM []byte) (*Signer, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in private key")
+	}
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported private key PEM type %q, encrypted keys are not supported", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing private key: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	if err := fips.CheckPublicKey(signer.Public()); err != nil {
+		return nil, err
+	}
+	return &Signer{key: signer}, nil
+}
+
+// Attest returns the JSON attestation of the report, named subjectName in its statement.
+func (s *Signer) Attest(subjectName string, reportJSON []byte, predicate Predicate) (string, error) {
+	digest := sha256.Sum256(reportJSON)
+	payload, err := json.Marshal(Statement{
+		Type: StatementType,
+		Subject: []Subject{{
+			Name:   subjectName,
+			Digest: map[string]string{"sha256": hex.EncodeToString(digest[:])},
+		}},
+		PredicateType: PredicateType,
+		Predicate:     predicate,
+	})
+	if err != nil {
+		return "", err
+	}
+	pae := preAuthEncoding(PayloadType, payload)
+	var sig []byte
+	if _, ok := s.key.(ed25519.PrivateKey); ok {
+		sig, err = s.key.Sign(rand.Reader, pae, crypto.Hash(0))
+	} else {
+		hash := sha256.Sum256(pae)
+		sig, err = s.key.Sign(rand.Reader, hash[:], crypto.SHA256)
+	}
+	if err != nil {
+		return "", fmt.Errorf("error signing attestation: %w", err)
+	}
+	envelope, err := json.Marshal(Envelope{
+		PayloadType: PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []Signature{{Sig: base64.StdEncoding.EncodeToString(sig)}},
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(envelope), nil
+}
+
+// Verify checks that the attestation is signed with the PEM encoded public key and that its subject is the
+// report, it returns the verified statement.
+func Verify(attestation string, reportJSON []byte, publicKeyPEM []byte) (*Statement, error) {
+	block, _ := pem.Decode(publicKeyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in public key")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %w", err)
+	}
+	var envelope Envelope
+	if err := json.Unmarshal([]byte(attestation), &envelope); err != nil {
+		return nil, fmt.Errorf("error parsing attestation: %w", err)
+	}
+	if envelope.PayloadType != PayloadType {
+		return nil, fmt.Errorf("%w: unexpected payload type %q", ErrVerification, envelope.PayloadType)
+	}
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding attestation payload: %w", err)
+	}
+	pae := preAuthEncoding(envelope.PayloadType, payload)
+	verified := false
+	for _, signature := range envelope.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(signature.Sig)
+		if err == nil && verifySignature(key, pae, sig) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("%w: no valid signature", ErrVerification)
+	}
+
+	var statement Statement
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return nil, fmt.Errorf("error parsing attestation statement: %w", err)
+	}
+	digest := sha256.Sum256(reportJSON)
+	for _, subject := range statement.Subject {
+		if subject.Digest["sha256"] == hex.EncodeToString(digest[:]) {
+			return &statement, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: the report does not match the digest of the attestation", ErrVerification)
+}
+
+func verifySignature(key crypto.PublicKey, pae, sig []byte) bool {
+	hash := sha256.Sum256(pae)
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, hash[:], sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, pae, sig)
+	}
+	return false
+}
+
+// preAuthEncoding returns the DSSE pre-authentication encoding of the payload, the bytes actually signed
+func preAuthEncoding(payloadType string, payload []byte) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "DSSEv1 %d %s %d ", len(payloadType), payloadType, len(payload))
+	buf.Write(payload)
+	return buf.Bytes()
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/scan"
+)
+
+// ScanBuilder builds a ClusterScan, validating it the same way the operator does.
+type ScanBuilder struct {
+	scan *v1.ClusterScan
+}
+
+// NewScan starts building a ClusterScan with a generated name.
+func NewScan() *ScanBuilder {
+	return &ScanBuilder{
+		scan: &v1.ClusterScan{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "scan-",
+			},
+		},
+	}
+}
+
+// WithName sets the name of the scan instead of generating one.
+func (b *ScanBuilder) WithName(name string) *ScanBuilder {
+	b.scan.Name = name
+	b.scan.GenerateName = ""
+	return b
+}
+
+// WithLabels adds labels to the scan, e.g. to be matched by a ScanSubscription.
+func (b *ScanBuilder) WithLabels(labels map[string]string) *ScanBuilder {
+	if b.scan.Labels == nil {
+		b.scan.Labels = map[string]string{}
+	}
+	for k, v := range labels {
+		b.scan.Labels[k] = v
+	}
+	return b
+}
+
+// WithProfile sets the ClusterScanProfile to run, the provider default is used otherwise.
+func (b *ScanBuilder) WithProfile(profileName string) *ScanBuilder {
+	b.scan.Spec.ScanProfileName = profileName
+	return b
+}
+
+// WithNodeSelector limits the scan to the nodes matching the given labels.
+func (b *ScanBuilder) WithNodeSelector(nodeSelector map[string]string) *ScanBuilder {
+	b.scan.Spec.NodeSelector = nodeSelector
+	return b
+}
+
+// WithMaxNodes turns the scan into a canary scan of at most maxNodes of the nodes in scope.
+func (b *ScanBuilder) WithMaxNodes(maxNodes int) *ScanBuilder {
+	b.scan.Spec.MaxNodes = maxNodes
+	return b
+}
+
+// WithNodeNames turns the scan into a canary scan of the given nodes only.
+func (b *ScanBuilder) WithNodeNames(nodeNames []string) *ScanBuilder {
+	b.scan.Spec.NodeNames = nodeNames
+	return b
+}
+
+// WithKubeletVersionRange limits the scan to the nodes whose kubelet version is in the given semver range.
+func (b *ScanBuilder) WithKubeletVersionRange(kubeletVersionRange string) *ScanBuilder {
+	b.scan.Spec.KubeletVersionRange = kubeletVersionRange
+	return b
+}
+
+// WithOSImages limits the scan to the nodes whose OS image contains one of the given strings.
+func (b *ScanBuilder) WithOSImages(osImages []string) *ScanBuilder {
+	b.scan.Spec.OSImages = osImages
+	return b
+}
+
+// WithIncludeChecks limits the results of the scan to the given check IDs or sections.
+func (b *ScanBuilder) WithIncludeChecks(checkIDs []string) *ScanBuilder {
+	b.scan.Spec.IncludeChecks = checkIDs
+	return b
+}
+
+// WithScoreWarning sets whether warnings count towards scan failure.
+func (b *ScanBuilder) WithScoreWarning(scoreWarning string) *ScanBuilder {
+	b.scan.Spec.ScoreWarning = scoreWarning
+	return b
+}
+
+// WithSchedule turns the scan into a scheduled scan keeping retentionCount reports.
+func (b *ScanBuilder) WithSchedule(cronSchedule string, retentionCount int) *ScanBuilder {
+	b.scheduledScanConfig().CronSchedule = cronSchedule
+	b.scheduledScanConfig().RetentionCount = retentionCount
+	return b
+}
+
+// WithAlerts configures the alerts sent out for a scheduled scan.
+func (b *ScanBuilder) WithAlerts(alertOnComplete, alertOnFailure bool) *ScanBuilder {
+	b.scheduledScanConfig().ScanAlertRule = &v1.ClusterScanAlertRule{
+		AlertOnComplete: alertOnComplete,
+		AlertOnFailure:  alertOnFailure,
+	}
+	return b
+}
+
+// Build validates and returns the ClusterScan.
+func (b *ScanBuilder) Build() (*v1.ClusterScan, error) {
+	if err := scan.ValidateClusterScanSpec(&b.scan.Spec); err != nil {
+		return nil, err
+	}
+	return b.scan.DeepCopy(), nil
+}
+
+func (b *ScanBuilder) scheduledScanConfig() *v1.ScheduledScanConfig {
+	if b.scan.Spec.ScheduledScanConfig == nil {
+		b.scan.Spec.ScheduledScanConfig = &v1.ScheduledScanConfig{}
+	}
+	return b.scan.Spec.ScheduledScanConfig
+}
@@ -0,0 +1,158 @@
+// Package client exposes a stable API to drive CIS scans from other Go programs,
+// without depending on the operator's internal controller code.
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/attestation"
+	cisoperatorctl "github.com/rancher/cis-operator/pkg/generated/controllers/cis.cattle.io"
+	cisoperatorctlv1 "github.com/rancher/cis-operator/pkg/generated/controllers/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+)
+
+// DefaultPollInterval is how often WaitForScanCompletion checks the scan status.
+const DefaultPollInterval = 5 * time.Second
+
+// Client is a typed client for the cis.cattle.io resources.
+type Client struct {
+	Scans      cisoperatorctlv1.ClusterScanClient
+	Profiles   cisoperatorctlv1.ClusterScanProfileClient
+	Benchmarks cisoperatorctlv1.ClusterScanBenchmarkClient
+	Reports    cisoperatorctlv1.ClusterScanReportClient
+	// nodes of the sharded reports, FetchReport merges them back into their report
+	ReportShards cisoperatorctlv1.ClusterScanReportShardClient
+
+	PollInterval time.Duration
+}
+
+// NewForConfig builds a Client talking to the cluster described by cfg.
+func NewForConfig(cfg *rest.Config) (*Client, error) {
+	factory, err := cisoperatorctl.NewFactoryFromConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error building cis client: %w", err)
+	}
+	cis := factory.Cis().V1()
+	return &Client{
+		Scans:        cis.ClusterScan(),
+		Profiles:     cis.ClusterScanProfile(),
+		Benchmarks:   cis.ClusterScanBenchmark(),
+		Reports:      cis.ClusterScanReport(),
+		ReportShards: cis.ClusterScanReportShard(),
+		PollInterval: DefaultPollInterval,
+	}, nil
+}
+
+// CreateScan creates the given ClusterScan, which the operator then starts running.
+func (c *Client) CreateScan(scan *v1.ClusterScan) (*v1.ClusterScan, error) {
+	return c.Scans.Create(scan)
+}
+
+// WaitForScanCompletion blocks until the named scan is complete, failed or ctx is done.
+// The scan is returned along with an error when the scan failed.
+func (c *Client) WaitForScanCompletion(ctx context.Context, scanName string) (*v1.ClusterScan, error) {
+	var scan *v1.ClusterScan
+	interval := c.PollInterval
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+	err := wait.PollUntilContextCancel(ctx, interval, true, func(context.Context) (bool, error) {
+		var err error
+		scan, err = c.Scans.Get(scanName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return v1.ClusterScanConditionComplete.IsTrue(scan) || v1.ClusterScanConditionFailed.IsTrue(scan), nil
+	})
+	if err != nil {
+		return scan, fmt.Errorf("error waiting for ClusterScan %v: %w", scanName, err)
+	}
+	if v1.ClusterScanConditionFailed.IsTrue(scan) {
+		return scan, fmt.Errorf("ClusterScan %v failed: %v", scanName, v1.ClusterScanConditionFailed.GetMessage(scan))
+	}
+	return scan, nil
+}
+
+// FetchReport returns the most recent ClusterScanReport generated by the named scan for its own profile.
+func (c *Client) FetchReport(scanName string) (*v1.ClusterScanReport, error) {
+	reportList, err := c.Reports.List(metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing ClusterScanReports: %w", err)
+	}
+	var latest *v1.ClusterScanReport
+	for i := range reportList.Items {
+		report := &reportList.Items[i]
+		if !IsReportOwnedBy(report, scanName) || report.Spec.AdditionalProfile {
+			continue
+		}
+		if latest == nil || latest.CreationTimestamp.Before(&report.CreationTimestamp) {
+			latest = report
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no ClusterScanReport found for ClusterScan %v", scanName)
+	}
+	return c.mergeReportShards(latest)
+}
+
+// mergeReportShards returns a sharded report with the nodes of its shards merged back into its
+// report JSON, which is then stored uncompressed
+func (c *Client) mergeReportShards(report *v1.ClusterScanReport) (*v1.ClusterScanReport, error) {
+	if !report.Spec.Sharded {
+		return report, nil
+	}
+	reportJSON, err := report.Spec.GetReportJSON()
+	if err != nil {
+		return nil, fmt.Errorf("error reading ClusterScanReport %v: %w", report.Name, err)
+	}
+	shardList, err := c.ReportShards.List(metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing ClusterScanReportShards: %w", err)
+	}
+	var shards []v1.ClusterScanReportShardSpec
+	for _, shard := range shardList.Items {
+		if shard.Spec.ReportName == report.Name {
+			shards = append(shards, shard.Spec)
+		}
+	}
+	merged, err := engine.MergeReportShards(reportJSON, shards)
+	if err != nil {
+		return nil, fmt.Errorf("error merging shards of ClusterScanReport %v: %w", report.Name, err)
+	}
+	report = report.DeepCopy()
+	if err := report.Spec.SetReportJSON(merged, false); err != nil {
+		return nil, err
+	}
+	report.Spec.Sharded = false
+	return report, nil
+}
+
+// VerifyReportAttestation checks that a report fetched with FetchReport is attested by the operator
+// holding the private key of the PEM encoded public key, and returns the verified statement.
+func VerifyReportAttestation(report *v1.ClusterScanReport, publicKeyPEM []byte) (*attestation.Statement, error) {
+	if report.Spec.Attestation == "" {
+		return nil, fmt.Errorf("%w: ClusterScanReport %v has no attestation", attestation.ErrVerification, report.Name)
+	}
+	reportJSON, err := report.Spec.GetReportJSON()
+	if err != nil {
+		return nil, fmt.Errorf("error reading ClusterScanReport %v: %w", report.Name, err)
+	}
+	return attestation.Verify(report.Spec.Attestation, reportJSON, publicKeyPEM)
+}
+
+// IsReportOwnedBy returns true if the report was generated by the named scan.
+func IsReportOwnedBy(report *v1.ClusterScanReport, scanName string) bool {
+	for _, ownerRef := range report.OwnerReferences {
+		if ownerRef.Kind == "ClusterScan" && ownerRef.Name == scanName {
+			return true
+		}
+	}
+	return false
+}
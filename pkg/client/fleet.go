@@ -0,0 +1,204 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rancher/security-scan/pkg/kb-summarizer/report"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// ClusterResult is the outcome of a scan on one cluster of a fleet, Err is set when the scan could not be run or its
+// report fetched.
+type ClusterResult struct {
+	// kubeconfig context of the cluster
+	Context  string
+	ScanName string
+	Report   *v1.ClusterScanReport
+	Err      error
+}
+
+// FleetReport merges the reports of the scans run on several clusters, e.g. for an audit covering many clusters.
+type FleetReport struct {
+	GeneratedAt string         `json:"generatedAt"`
+	Clusters    []FleetCluster `json:"clusters"`
+	// totals of the clusters with a report
+	Summary FleetSummary `json:"summary"`
+	// every check of the reports, by benchmark and ID, with its state on every cluster it ran on
+	Checks []FleetCheck `json:"checks"`
+}
+
+type FleetCluster struct {
+	Context         string   `json:"context"`
+	ScanName        string   `json:"scanName,omitempty"`
+	ReportName      string   `json:"reportName,omitempty"`
+	Benchmark       string   `json:"benchmark,omitempty"`
+	LastRun         string   `json:"lastRun,omitempty"`
+	Total           int      `json:"total"`
+	Pass            int      `json:"pass"`
+	Fail            int      `json:"fail"`
+	Skip            int      `json:"skip"`
+	Warn            int      `json:"warn"`
+	NotApplicable   int      `json:"notApplicable"`
+	ComplianceScore *float64 `json:"complianceScore,omitempty"`
+	PartialCoverage bool     `json:"partialCoverage,omitempty"`
+	Error           string   `json:"error,omitempty"`
+}
+
+type FleetSummary struct {
+	Clusters int `json:"clusters"`
+	// clusters with a report and no failing check, and the ones without a report
+	Passed int `json:"passed"`
+	Errors int `json:"errors"`
+	Total  int `json:"total"`
+	Pass   int `json:"pass"`
+	Fail   int `json:"fail"`
+	Skip   int `json:"skip"`
+	Warn   int `json:"warn"`
+	// lowest compliance score of the clusters
+	MinComplianceScore *float64 `json:"minComplianceScore,omitempty"`
+}
+
+type FleetCheck struct {
+	Benchmark   string `json:"benchmark"`
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	// state of the check by context
+	States map[string]report.State `json:"states"`
+	// contexts the check fails on, sorted
+	FailingClusters []string `json:"failingClusters,omitempty"`
+}
+
+// NewFleetReport merges the results of the clusters, in the order of the results. A cluster whose report cannot be
+// read is counted as an error, as one without a report.
+func NewFleetReport(results []ClusterResult, now time.Time) *FleetReport {
+	fleet := &FleetReport{
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+		Clusters:    []FleetCluster{},
+		Checks:      []FleetCheck{},
+	}
+	checks := map[string]*FleetCheck{}
+	for _, result := range results {
+		cluster := FleetCluster{Context: result.Context, ScanName: result.ScanName}
+		fleet.Summary.Clusters++
+		if result.Err != nil || result.Report == nil {
+			cluster.Error = "no report"
+			if result.Err != nil {
+				cluster.Error = result.Err.Error()
+			}
+			fleet.Summary.Errors++
+			fleet.Clusters = append(fleet.Clusters, cluster)
+			continue
+		}
+		cluster.ReportName = result.Report.Name
+		r, err := readFleetClusterReport(result.Report)
+		if err != nil {
+			cluster.Error = err.Error()
+			fleet.Summary.Errors++
+			fleet.Clusters = append(fleet.Clusters, cluster)
+			continue
+		}
+		cluster.Benchmark = result.Report.Spec.BenchmarkVersion
+		cluster.LastRun = result.Report.Spec.LastRunTimestamp
+		cluster.Total, cluster.Pass, cluster.Fail, cluster.Skip, cluster.Warn, cluster.NotApplicable = r.Total, r.Pass, r.Fail, r.Skip, r.Warn, r.NotApplicable
+		cluster.PartialCoverage = result.Report.Spec.PartialCoverage
+		if score := result.Report.Spec.ComplianceScore; score != nil {
+			cluster.ComplianceScore = &score.Score
+			if fleet.Summary.MinComplianceScore == nil || score.Score < *fleet.Summary.MinComplianceScore {
+				fleet.Summary.MinComplianceScore = &score.Score
+			}
+		}
+		fleet.Clusters = append(fleet.Clusters, cluster)
+
+		if r.Fail == 0 {
+			fleet.Summary.Passed++
+		}
+		fleet.Summary.Total += r.Total
+		fleet.Summary.Pass += r.Pass
+		fleet.Summary.Fail += r.Fail
+		fleet.Summary.Skip += r.Skip
+		fleet.Summary.Warn += r.Warn
+		for _, group := range r.Results {
+			for _, check := range group.Checks {
+				key := cluster.Benchmark + "/" + check.Id
+				fleetCheck, ok := checks[key]
+				if !ok {
+					fleetCheck = &FleetCheck{Benchmark: cluster.Benchmark, ID: check.Id, Description: check.Description, States: map[string]report.State{}}
+					checks[key] = fleetCheck
+				}
+				fleetCheck.States[result.Context] = check.State
+				if check.State == report.Fail {
+					fleetCheck.FailingClusters = append(fleetCheck.FailingClusters, result.Context)
+				}
+			}
+		}
+	}
+	keys := make([]string, 0, len(checks))
+	for key := range checks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		sort.Strings(checks[key].FailingClusters)
+		fleet.Checks = append(fleet.Checks, *checks[key])
+	}
+	return fleet
+}
+
+func readFleetClusterReport(clusterReport *v1.ClusterScanReport) (*report.Report, error) {
+	reportJSON, err := clusterReport.Spec.GetReportJSON()
+	if err != nil {
+		return nil, fmt.Errorf("error reading report %v: %w", clusterReport.Name, err)
+	}
+	r, err := report.Get(reportJSON)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing report %v: %w", clusterReport.Name, err)
+	}
+	if r == nil {
+		return nil, fmt.Errorf("report %v is empty", clusterReport.Name)
+	}
+	return r, nil
+}
+
+// RenderFleetReport writes the fleet report to w as json, or as text: a line per cluster followed by the checks
+// failing on at least one cluster.
+func RenderFleetReport(w io.Writer, fleet *FleetReport, format string) error {
+	switch format {
+	case FormatJSON, "":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(fleet)
+	case FormatText:
+		return renderFleetText(w, fleet)
+	}
+	return fmt.Errorf("unsupported fleet report format %q, must be json or text", format)
+}
+
+func renderFleetText(w io.Writer, fleet *FleetReport) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	summary := fleet.Summary
+	fmt.Fprintf(tw, "Clusters: %d\tPassed: %d\tErrors: %d\n", summary.Clusters, summary.Passed, summary.Errors)
+	fmt.Fprintf(tw, "Total: %d\tPass: %d\tFail: %d\tSkip: %d\tWarn: %d\n\n", summary.Total, summary.Pass, summary.Fail, summary.Skip, summary.Warn)
+	fmt.Fprintln(tw, "CONTEXT\tSCAN\tBENCHMARK\tTOTAL\tFAIL\tWARN\tSCORE\tERROR")
+	for _, cluster := range fleet.Clusters {
+		score := ""
+		if cluster.ComplianceScore != nil {
+			score = fmt.Sprintf("%.1f", *cluster.ComplianceScore)
+		}
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%d\t%d\t%d\t%v\t%v\n", cluster.Context, cluster.ScanName, cluster.Benchmark, cluster.Total, cluster.Fail, cluster.Warn, score, cluster.Error)
+	}
+	fmt.Fprintln(tw, "\nBENCHMARK\tID\tFAILING\tDESCRIPTION\tCONTEXTS")
+	for _, check := range fleet.Checks {
+		if len(check.FailingClusters) == 0 {
+			continue
+		}
+		fmt.Fprintf(tw, "%v\t%v\t%d/%d\t%v\t%v\n", check.Benchmark, check.ID, len(check.FailingClusters), len(check.States), check.Description, strings.Join(check.FailingClusters, ","))
+	}
+	return tw.Flush()
+}
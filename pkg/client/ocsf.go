@@ -0,0 +1,189 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/rancher/security-scan/pkg/kb-summarizer/report"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// OCSF Compliance Finding class, see https://schema.ocsf.io/1.1.0/classes/compliance_finding
+const (
+	ocsfVersion      = "1.1.0"
+	ocsfCategoryUID  = 2
+	ocsfCategoryName = "Findings"
+	ocsfClassUID     = 2003
+	ocsfClassName    = "Compliance Finding"
+	ocsfActivityID   = 1
+	ocsfActivityName = "Create"
+	ocsfStatusID     = 1
+	ocsfStatus       = "New"
+)
+
+// OCSF severities, and the statuses of a compliance check
+const (
+	ocsfSeverityInformational = 1
+	ocsfSeverityLow           = 2
+	ocsfSeverityMedium        = 3
+	ocsfSeverityHigh          = 4
+
+	ocsfComplianceUnknown = 0
+	ocsfCompliancePass    = 1
+	ocsfComplianceWarning = 2
+	ocsfComplianceFail    = 3
+)
+
+type ocsfFinding struct {
+	ActivityID   int                    `json:"activity_id"`
+	ActivityName string                 `json:"activity_name"`
+	CategoryUID  int                    `json:"category_uid"`
+	CategoryName string                 `json:"category_name"`
+	ClassUID     int                    `json:"class_uid"`
+	ClassName    string                 `json:"class_name"`
+	TypeUID      int                    `json:"type_uid"`
+	Time         int64                  `json:"time"`
+	SeverityID   int                    `json:"severity_id"`
+	Severity     string                 `json:"severity"`
+	StatusID     int                    `json:"status_id"`
+	Status       string                 `json:"status"`
+	Message      string                 `json:"message"`
+	Metadata     ocsfMetadata           `json:"metadata"`
+	FindingInfo  ocsfFindingInfo        `json:"finding_info"`
+	Compliance   ocsfCompliance         `json:"compliance"`
+	Remediation  *ocsfRemediation       `json:"remediation,omitempty"`
+	Resources    []ocsfResource         `json:"resources,omitempty"`
+	Unmapped     map[string]interface{} `json:"unmapped,omitempty"`
+}
+
+type ocsfMetadata struct {
+	Version string      `json:"version"`
+	Product ocsfProduct `json:"product"`
+	Labels  []string    `json:"labels,omitempty"`
+}
+
+type ocsfProduct struct {
+	Name       string `json:"name"`
+	VendorName string `json:"vendor_name"`
+}
+
+type ocsfFindingInfo struct {
+	UID         string `json:"uid"`
+	Title       string `json:"title"`
+	Desc        string `json:"desc,omitempty"`
+	CreatedTime int64  `json:"created_time"`
+}
+
+type ocsfCompliance struct {
+	Control      string   `json:"control"`
+	Requirements []string `json:"requirements"`
+	Standards    []string `json:"standards"`
+	StatusID     int      `json:"status_id"`
+	Status       string   `json:"status"`
+}
+
+type ocsfRemediation struct {
+	Desc string `json:"desc"`
+}
+
+type ocsfResource struct {
+	UID  string `json:"uid"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// renderOCSF writes one OCSF Compliance Finding per check, as JSON lines so that the findings can be
+// streamed to security data lakes as they are. The nodes a check ran on are the resources of its finding,
+// the machine tags of the check the labels of its metadata.
+func renderOCSF(w io.Writer, scanReport *v1.ClusterScanReport, reportJSON []byte) error {
+	r, err := report.Get(reportJSON)
+	if err != nil {
+		return fmt.Errorf("error parsing report %v: %w", scanReport.Name, err)
+	}
+	created := scanReport.CreationTimestamp.UnixMilli()
+	encoder := json.NewEncoder(w)
+	for _, group := range r.Results {
+		for _, check := range group.Checks {
+			severityID, severity := getOCSFSeverity(check)
+			complianceID, compliance := getOCSFComplianceStatus(check.State)
+			finding := ocsfFinding{
+				ActivityID:   ocsfActivityID,
+				ActivityName: ocsfActivityName,
+				CategoryUID:  ocsfCategoryUID,
+				CategoryName: ocsfCategoryName,
+				ClassUID:     ocsfClassUID,
+				ClassName:    ocsfClassName,
+				TypeUID:      ocsfClassUID*100 + ocsfActivityID,
+				Time:         created,
+				SeverityID:   severityID,
+				Severity:     severity,
+				StatusID:     ocsfStatusID,
+				Status:       ocsfStatus,
+				Message:      fmt.Sprintf("%v: %v", check.State, check.Description),
+				Metadata: ocsfMetadata{
+					Version: ocsfVersion,
+					Product: ocsfProduct{Name: "cis-operator", VendorName: "Rancher"},
+					Labels:  GetFindingTags(scanReport, r, check),
+				},
+				FindingInfo: ocsfFindingInfo{
+					UID:         scanReport.Name + "/" + check.Id,
+					Title:       check.Description,
+					Desc:        group.Text,
+					CreatedTime: created,
+				},
+				Compliance: ocsfCompliance{
+					Control:      check.Id,
+					Requirements: []string{check.Id},
+					Standards:    []string{"CIS " + scanReport.Spec.BenchmarkVersion},
+					StatusID:     complianceID,
+					Status:       compliance,
+				},
+				Unmapped: map[string]interface{}{
+					"state":    string(check.State),
+					"scored":   check.Scored,
+					"nodeType": check.NodeType,
+				},
+			}
+			if check.Remediation != "" {
+				finding.Remediation = &ocsfRemediation{Desc: check.Remediation}
+			}
+			for _, node := range check.Nodes {
+				finding.Resources = append(finding.Resources, ocsfResource{UID: node, Name: node, Type: "Kubernetes Node"})
+			}
+			if err := encoder.Encode(finding); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getOCSFSeverity rates the failing scored checks high and the other failing checks medium, the warnings
+// are low and the rest informational
+func getOCSFSeverity(check *report.Check) (int, string) {
+	switch check.State {
+	case report.Fail, report.Mixed:
+		if check.Scored {
+			return ocsfSeverityHigh, "High"
+		}
+		return ocsfSeverityMedium, "Medium"
+	case report.Warn:
+		return ocsfSeverityLow, "Low"
+	}
+	return ocsfSeverityInformational, "Informational"
+}
+
+// getOCSFComplianceStatus maps the state of a check to the status of its compliance object
+func getOCSFComplianceStatus(state report.State) (int, string) {
+	switch state {
+	case report.Pass:
+		return ocsfCompliancePass, "Pass"
+	case report.Warn:
+		return ocsfComplianceWarning, "Warning"
+	case report.Fail, report.Mixed:
+		return ocsfComplianceFail, "Fail"
+	}
+	return ocsfComplianceUnknown, "Unknown"
+}
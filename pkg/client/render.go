@@ -0,0 +1,84 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rancher/security-scan/pkg/kb-summarizer/report"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+const (
+	// FormatJSON renders the full report JSON, indented, with the machine tags of the checks.
+	FormatJSON = "json"
+	// FormatText renders a summary followed by one line per check.
+	FormatText = "text"
+	// FormatSARIF renders the checks as a SARIF 2.1.0 log, for code scanning dashboards.
+	FormatSARIF = "sarif"
+	// FormatOCSF renders the checks as OCSF Compliance Findings, one JSON object per line, for security data lakes.
+	FormatOCSF = "ocsf"
+)
+
+// RenderReport writes the report to w in the requested format.
+func RenderReport(w io.Writer, scanReport *v1.ClusterScanReport, format string) error {
+	reportJSON, err := scanReport.Spec.GetReportJSON()
+	if err != nil {
+		return fmt.Errorf("error reading report %v: %w", scanReport.Name, err)
+	}
+	switch format {
+	case FormatJSON, "":
+		return renderJSON(w, scanReport, reportJSON)
+	case FormatText:
+		return renderText(w, scanReport, reportJSON)
+	case FormatSARIF:
+		return renderSARIF(w, scanReport, reportJSON)
+	case FormatOCSF:
+		return renderOCSF(w, scanReport, reportJSON)
+	}
+	return fmt.Errorf("unsupported report format %q", format)
+}
+
+func renderJSON(w io.Writer, scanReport *v1.ClusterScanReport, reportJSON []byte) error {
+	r, err := report.Get(reportJSON)
+	if err != nil {
+		return fmt.Errorf("error parsing report %v: %w", scanReport.Name, err)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(tagReport(scanReport, r))
+}
+
+func renderText(w io.Writer, scanReport *v1.ClusterScanReport, reportJSON []byte) error {
+	r, err := report.Get(reportJSON)
+	if err != nil {
+		return fmt.Errorf("error parsing report %v: %w", scanReport.Name, err)
+	}
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "Report:\t%v\n", scanReport.Name)
+	fmt.Fprintf(tw, "Benchmark:\t%v\n", scanReport.Spec.BenchmarkVersion)
+	fmt.Fprintf(tw, "Last run:\t%v\n", scanReport.Spec.LastRunTimestamp)
+	if scanReport.Spec.PartialCoverage {
+		fmt.Fprintf(tw, "Coverage:\tpartial, %d of %d nodes\n", len(scanReport.Spec.NodesInScope), scanReport.Spec.TotalNodeCount)
+	}
+	fmt.Fprintf(tw, "Total: %d\tPass: %d\tFail: %d\tSkip: %d\tWarn: %d\tN/A: %d\n\n", r.Total, r.Pass, r.Fail, r.Skip, r.Warn, r.NotApplicable)
+	fmt.Fprintln(tw, "ID\tSTATE\tDESCRIPTION\tTAGS")
+	for _, group := range r.Results {
+		for _, check := range group.Checks {
+			fmt.Fprintf(tw, "%v\t%v\t%v\t%v\n", check.Id, check.State, check.Description, strings.Join(GetFindingTags(scanReport, r, check), ","))
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(scanReport.Spec.Remediations) > 0 {
+		fmt.Fprintln(w, "\nRemediations:")
+	}
+	for _, remediation := range scanReport.Spec.Remediations {
+		fmt.Fprintf(w, "\n%v %v\n%v\n", remediation.TestID, remediation.Description, remediation.Remediation)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/rancher/security-scan/pkg/kb-summarizer/report"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool              `json:"tool"`
+	Results    []sarifResult          `json:"results"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string        `json:"id"`
+	ShortDescription sarifMessage  `json:"shortDescription"`
+	Help             *sarifMessage `json:"help,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID     string                 `json:"ruleId"`
+	Kind       string                 `json:"kind"`
+	Level      string                 `json:"level"`
+	Message    sarifMessage           `json:"message"`
+	Locations  []sarifLocation        `json:"locations,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text,omitempty"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+// renderSARIF writes one rule per check and one result per check, the failing ones as errors and
+// the warnings as warnings. The nodes a check failed on are its logical locations, the machine tags of
+// the check are the tags of its result.
+func renderSARIF(w io.Writer, scanReport *v1.ClusterScanReport, reportJSON []byte) error {
+	r, err := report.Get(reportJSON)
+	if err != nil {
+		return fmt.Errorf("error parsing report %v: %w", scanReport.Name, err)
+	}
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:  "cis-operator",
+			Rules: []sarifRule{},
+		}},
+		Results: []sarifResult{},
+		Properties: map[string]interface{}{
+			"report":           scanReport.Name,
+			"benchmarkVersion": scanReport.Spec.BenchmarkVersion,
+			"lastRunTimestamp": scanReport.Spec.LastRunTimestamp,
+		},
+	}
+	for _, group := range r.Results {
+		for _, check := range group.Checks {
+			rule := sarifRule{
+				ID:               check.Id,
+				ShortDescription: sarifMessage{Text: check.Description},
+			}
+			if check.Remediation != "" {
+				rule.Help = &sarifMessage{Text: check.Remediation}
+			}
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+			kind, level := getSARIFKindAndLevel(check.State)
+			result := sarifResult{
+				RuleID:  check.Id,
+				Kind:    kind,
+				Level:   level,
+				Message: sarifMessage{Text: fmt.Sprintf("%v: %v", check.State, check.Description)},
+				Properties: map[string]interface{}{
+					"state":    string(check.State),
+					"scored":   check.Scored,
+					"nodeType": check.NodeType,
+					"tags":     GetFindingTags(scanReport, r, check),
+				},
+			}
+			for _, node := range check.Nodes {
+				result.Locations = append(result.Locations, sarifLocation{
+					LogicalLocations: []sarifLogicalLocation{{Name: node, Kind: "node"}},
+				})
+			}
+			run.Results = append(run.Results, result)
+		}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
+
+// getSARIFKindAndLevel maps the state of a check to the kind and level of its SARIF result
+func getSARIFKindAndLevel(state report.State) (string, string) {
+	switch state {
+	case report.Fail, report.Mixed:
+		return "fail", "error"
+	case report.Warn:
+		return "review", "warning"
+	case report.Pass:
+		return "pass", "none"
+	case report.Skip, report.NotApplicable:
+		return "notApplicable", "none"
+	}
+	return "informational", "note"
+}
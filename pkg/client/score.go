@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+const (
+	// ComplianceScoreMetric is the metric of the scores served by the report API under /v1/score
+	ComplianceScoreMetric = "cis_compliance_score"
+	// ComplianceScoreAPIVersion is the version of the custom metrics API the score list follows
+	ComplianceScoreAPIVersion = "custom.metrics.k8s.io/v1beta2"
+	// ComplianceScoreClusterLabel is the selector label of the cluster the scores were measured on
+	ComplianceScoreClusterLabel = "cluster_name"
+)
+
+// ComplianceScoreList is the list of compliance scores served by the report API, shaped as a MetricValueList of the
+// custom metrics API for the consumers already reading it.
+type ComplianceScoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComplianceScore `json:"items"`
+}
+
+// ComplianceScore is the compliance score of the last run of a ClusterScan, see v1.ClusterScanComplianceScore.
+type ComplianceScore struct {
+	// the ClusterScan
+	DescribedObject corev1.ObjectReference `json:"describedObject"`
+	Metric          MetricIdentifier       `json:"metric"`
+	// the last run of the scan
+	Timestamp metav1.Time `json:"timestamp"`
+	// the score, from 0 to 100
+	Value resource.Quantity `json:"value"`
+}
+
+// MetricIdentifier names the metric and the cluster of a ComplianceScore.
+type MetricIdentifier struct {
+	Name     string                `json:"name"`
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// Score returns the score of the ComplianceScore, from 0 to 100.
+func (s *ComplianceScore) Score() float64 {
+	return s.Value.AsApproximateFloat64()
+}
+
+// Get returns the score of the named scan, false if the list has none.
+func (l *ComplianceScoreList) Get(scanName string) (float64, bool) {
+	for i := range l.Items {
+		if l.Items[i].DescribedObject.Name == scanName {
+			return l.Items[i].Score(), true
+		}
+	}
+	return 0, false
+}
+
+// NewComplianceScore returns the ComplianceScore of a scan that has one.
+func NewComplianceScore(scan *v1.ClusterScan, clusterName string) ComplianceScore {
+	score := ComplianceScore{
+		DescribedObject: corev1.ObjectReference{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       "ClusterScan",
+			Name:       scan.Name,
+			UID:        scan.UID,
+		},
+		Metric: MetricIdentifier{Name: ComplianceScoreMetric},
+		Value:  *resource.NewMilliQuantity(int64(math.Round(scan.Status.ComplianceScore.Score*1000)), resource.DecimalSI),
+	}
+	if clusterName != "" {
+		score.Metric.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{ComplianceScoreClusterLabel: clusterName}}
+	}
+	if t, err := time.Parse(time.RFC3339, scan.Status.LastRunTimestamp); err == nil {
+		score.Timestamp = metav1.NewTime(t)
+	}
+	return score
+}
+
+// GetComplianceScore returns the compliance score of the last run of the named scan, read from its status.
+func (c *Client) GetComplianceScore(scanName string) (*v1.ClusterScanComplianceScore, error) {
+	scan, err := c.Scans.Get(scanName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if scan.Status.ComplianceScore == nil {
+		return nil, fmt.Errorf("ClusterScan %v has no compliance score yet", scanName)
+	}
+	return scan.Status.ComplianceScore, nil
+}
+
+// FetchComplianceScores returns the compliance scores served by the report API at baseURL, e.g.
+// https://cis-operator-report-api.cis-operator-system.svc:8443, with the bearer token if not empty. httpClient
+// carries the TLS configuration and the client certificate of the mtls mode, http.DefaultClient is used if nil.
+func FetchComplianceScores(ctx context.Context, httpClient *http.Client, baseURL, token string) (*ComplianceScoreList, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	u, err := url.JoinPath(baseURL, "/v1/score")
+	if err != nil {
+		return nil, fmt.Errorf("invalid report API URL %v: %w", baseURL, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching compliance scores: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching compliance scores: %v", resp.Status)
+	}
+	list := &ComplianceScoreList{}
+	if err := json.NewDecoder(resp.Body).Decode(list); err != nil {
+		return nil, fmt.Errorf("error decoding compliance scores: %w", err)
+	}
+	return list, nil
+}
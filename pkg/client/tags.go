@@ -0,0 +1,65 @@
+package client
+
+import (
+	"sort"
+
+	"github.com/rancher/security-scan/pkg/kb-summarizer/report"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// machine tags of the findings, key=value strings set on every check in every format, so that the tools
+// deduplicating or suppressing the findings downstream can rely on the same identifiers
+const (
+	TagBenchmark        = "cis.cattle.io/benchmark"
+	TagBenchmarkVersion = "cis.cattle.io/benchmark-version"
+	TagCheck            = "cis.cattle.io/check"
+	TagNode             = "cis.cattle.io/node"
+	TagState            = "cis.cattle.io/state"
+	TagRun              = "cis.cattle.io/run"
+)
+
+// GetFindingTags returns the machine tags of a check of the report: the ClusterScanBenchmark and the version
+// of the benchmark the report was run against, the check ID, one tag per node the check ran on, sorted, the
+// state of the check and the run, which is the name of the report.
+func GetFindingTags(scanReport *v1.ClusterScanReport, r *report.Report, check *report.Check) []string {
+	tags := []string{
+		TagBenchmark + "=" + scanReport.Spec.BenchmarkVersion,
+		TagBenchmarkVersion + "=" + r.Version,
+		TagCheck + "=" + check.Id,
+	}
+	nodes := append([]string(nil), check.Nodes...)
+	sort.Strings(nodes)
+	for _, node := range nodes {
+		tags = append(tags, TagNode+"="+node)
+	}
+	return append(tags, TagState+"="+string(check.State), TagRun+"="+scanReport.Name)
+}
+
+type taggedReport struct {
+	*report.Report
+	Results []*taggedGroup `json:"results"`
+}
+
+type taggedGroup struct {
+	*report.Group
+	Checks []*taggedCheck `json:"checks"`
+}
+
+type taggedCheck struct {
+	*report.Check
+	Tags []string `json:"tags"`
+}
+
+// tagReport returns the report with the machine tags of its checks
+func tagReport(scanReport *v1.ClusterScanReport, r *report.Report) *taggedReport {
+	tagged := &taggedReport{Report: r, Results: []*taggedGroup{}}
+	for _, group := range r.Results {
+		taggedGroup := &taggedGroup{Group: group, Checks: []*taggedCheck{}}
+		for _, check := range group.Checks {
+			taggedGroup.Checks = append(taggedGroup.Checks, &taggedCheck{Check: check, Tags: GetFindingTags(scanReport, r, check)})
+		}
+		tagged.Results = append(tagged.Results, taggedGroup)
+	}
+	return tagged
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/rancher/wrangler/pkg/controller-gen/args"
 
 	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	v2 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v2"
 	"github.com/rancher/cis-operator/pkg/crds"
 )
 
@@ -22,6 +23,13 @@ func main() {
 					v1.ClusterScanProfile{},
 					v1.ClusterScanReport{},
 					v1.ClusterScanBenchmark{},
+					v1.ScanSubscription{},
+					v1.ClusterScanReportShard{},
+					v1.ClusterScanRemediation{},
+					v1.ClusterScanCampaign{},
+					v1.ClusterScanTemplate{},
+					v1.OperatorConfig{},
+					v2.ClusterScanReport{},
 				},
 				GenerateTypes: true,
 			},
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	cisoperator "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	cisoperatorv2 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v2"
 	"github.com/rancher/wrangler/pkg/crd"
 	_ "github.com/rancher/wrangler/pkg/generated/controllers/apiextensions.k8s.io" //using init
 	"github.com/rancher/wrangler/pkg/yaml"
@@ -31,20 +32,34 @@ func WriteCRD() error {
 		if crd.Name == "clusterscans.cis.cattle.io" {
 			customizeClusterScan(&crd)
 		}
-		yamlBytes, err := yaml.Export(&crd)
-		if err != nil {
+		if err := writeCRDFile("./crds", &crd); err != nil {
 			return err
 		}
-
-		filename := fmt.Sprintf("./crds/%s.yaml", strings.ToLower(crd.Spec.Names.Kind))
-		err = os.WriteFile(filename, yamlBytes, 0o644)
-		if err != nil {
-			return err
+		if crd.Name == "clusterscanreports.cis.cattle.io" {
+			// only applied in place of the v1 CRD along with the conversion webhook, which it requires
+			if err := addClusterScanReportV2(&crd); err != nil {
+				return err
+			}
+			if err := writeCRDFile("./crds/v2", &crd); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
+func writeCRDFile(dir string, crd *apiextv1.CustomResourceDefinition) error {
+	yamlBytes, err := yaml.Export(crd)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	filename := fmt.Sprintf("%s/%s.yaml", dir, strings.ToLower(crd.Spec.Names.Kind))
+	return os.WriteFile(filename, yamlBytes, 0o644)
+}
+
 func List() []crd.CRD {
 	return []crd.CRD{
 		newCRD(&cisoperator.ClusterScan{}, func(c crd.CRD) crd.CRD {
@@ -57,7 +72,8 @@ func List() []crd.CRD {
 				WithColumn("Warn", ".status.summary.warn").
 				WithColumn("Not Applicable", ".status.summary.notApplicable").
 				WithColumn("LastRunTimestamp", ".status.lastRunTimestamp").
-				WithColumn("CronSchedule", ".spec.scheduledScanConfig.cronSchedule")
+				WithColumn("CronSchedule", ".spec.scheduledScanConfig.cronSchedule").
+				WithColumn("Phase", ".status.progress.phase")
 		}),
 		newCRD(&cisoperator.ClusterScanProfile{}, func(c crd.CRD) crd.CRD {
 			return c.
@@ -66,7 +82,9 @@ func List() []crd.CRD {
 		newCRD(&cisoperator.ClusterScanReport{}, func(c crd.CRD) crd.CRD {
 			return c.
 				WithColumn("LastRunTimestamp", ".spec.lastRunTimestamp").
-				WithColumn("BenchmarkVersion", ".spec.benchmarkVersion")
+				WithColumn("BenchmarkVersion", ".spec.benchmarkVersion").
+				WithColumn("ScanProfileName", ".spec.scanProfileName").
+				WithColumn("PartialCoverage", ".spec.partialCoverage")
 		}),
 		newCRD(&cisoperator.ClusterScanBenchmark{}, func(c crd.CRD) crd.CRD {
 			return c.
@@ -76,6 +94,41 @@ func List() []crd.CRD {
 				WithColumn("customBenchmarkConfigMapName", ".spec.customBenchmarkConfigMapName").
 				WithColumn("customBenchmarkConfigMapNamespace", ".spec.customBenchmarkConfigMapNamespace")
 		}),
+		newCRD(&cisoperator.ScanSubscription{}, func(c crd.CRD) crd.CRD {
+			return c.
+				WithColumn("ConfigMapNamespace", ".spec.target.configMapNamespace").
+				WithColumn("ConfigMapName", ".spec.target.configMapName").
+				WithColumn("LastNotifiedScan", ".status.lastNotifiedScan").
+				WithColumn("LastNotifiedTimestamp", ".status.lastNotifiedTimestamp")
+		}),
+		newCRD(&cisoperator.ClusterScanReportShard{}, func(c crd.CRD) crd.CRD {
+			c.Status = false
+			return c.
+				WithColumn("ClusterScanReport", ".spec.reportName").
+				WithColumn("Index", ".spec.index")
+		}),
+		newCRD(&cisoperator.ClusterScanRemediation{}, func(c crd.CRD) crd.CRD {
+			return c.
+				WithColumn("Workload", ".spec.workload").
+				WithColumn("DryRun", ".spec.dryRun")
+		}),
+		newCRD(&cisoperator.ClusterScanCampaign{}, func(c crd.CRD) crd.CRD {
+			return c.
+				WithColumn("Deadline", ".spec.deadline").
+				WithColumn("Fixed", ".status.fixed").
+				WithColumn("Total", ".status.total").
+				WithColumn("Completion", ".status.completionPercentage")
+		}),
+		newCRD(&cisoperator.ClusterScanTemplate{}, func(c crd.CRD) crd.CRD {
+			return c.
+				WithColumn("ScanName", ".spec.scanName").
+				WithColumn("ClusterScanProfile", ".spec.template.scanProfileName").
+				WithColumn("LastSyncTimestamp", ".status.lastSyncTimestamp")
+		}),
+		newCRD(&cisoperator.OperatorConfig{}, func(c crd.CRD) crd.CRD {
+			return c.
+				WithColumn("MaxConcurrentScans", ".spec.maxConcurrentScans")
+		}),
 	}
 }
 
@@ -95,6 +148,51 @@ func newCRD(obj interface{}, customize func(crd.CRD) crd.CRD) crd.CRD {
 	return crd
 }
 
+// ConversionWebhookService is the Service of the operator serving the conversion webhook of the ClusterScanReports
+const ConversionWebhookService = "cis-operator-webhook"
+
+// addClusterScanReportV2 serves the v2 of the ClusterScanReports, stored in v1 and converted by the webhook of the
+// operator. The API server prefers v2 once served, so the CRD must only be applied with the webhook deployed. The
+// caBundle of the webhook is left to the deployment to inject.
+func addClusterScanReportV2(clusterScanReport *apiextv1.CustomResourceDefinition) error {
+	v2CRD := newCRD(&cisoperatorv2.ClusterScanReport{}, func(c crd.CRD) crd.CRD {
+		c.GVK.Version = "v2"
+		return c.
+			WithColumn("LastRunTimestamp", ".spec.lastRunTimestamp").
+			WithColumn("BenchmarkVersion", ".spec.benchmarkVersion").
+			WithColumn("ScanProfileName", ".spec.scanProfileName").
+			WithColumn("Pass", ".spec.results.summary.pass").
+			WithColumn("Fail", ".spec.results.summary.fail")
+	})
+	obj, err := v2CRD.ToCustomResourceDefinition()
+	if err != nil {
+		return err
+	}
+	var v2 apiextv1.CustomResourceDefinition
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).Object, &v2); err != nil {
+		return err
+	}
+	version := v2.Spec.Versions[0]
+	version.Storage = false
+	clusterScanReport.Spec.Versions = append(clusterScanReport.Spec.Versions, version)
+
+	path := "/convert"
+	clusterScanReport.Spec.Conversion = &apiextv1.CustomResourceConversion{
+		Strategy: apiextv1.WebhookConverter,
+		Webhook: &apiextv1.WebhookConversion{
+			ClientConfig: &apiextv1.WebhookClientConfig{
+				Service: &apiextv1.ServiceReference{
+					Namespace: cisoperator.ClusterScanNS,
+					Name:      ConversionWebhookService,
+					Path:      &path,
+				},
+			},
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+	return nil
+}
+
 func customizeClusterScan(clusterScan *apiextv1.CustomResourceDefinition) {
 	properties := clusterScan.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties
 
@@ -0,0 +1,95 @@
+// Package debug serves the opt-in debug listener of the operator: the pprof profiles, goroutine dumps, runtime memory
+// stats and the stats of the controller workqueues, to diagnose its memory and queue growth.
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	runtimepprof "runtime/pprof"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	PprofPath      = "/debug/pprof/"
+	GoroutinesPath = "/debug/goroutines"
+	RuntimePath    = "/debug/runtime"
+	WorkqueuesPath = "/debug/workqueues"
+)
+
+// RuntimeStats are the figures of the Go runtime that grow with a leak of goroutines or memory
+type RuntimeStats struct {
+	Goroutines int `json:"goroutines"`
+	GOMAXPROCS int `json:"gomaxprocs"`
+	// bytes of the live heap objects, of the heap spans in use, and obtained from the OS
+	HeapAlloc   uint64 `json:"heapAlloc"`
+	HeapInuse   uint64 `json:"heapInuse"`
+	HeapObjects uint64 `json:"heapObjects"`
+	Sys         uint64 `json:"sys"`
+	// heap size the next GC runs at
+	NextGC       uint64 `json:"nextGC"`
+	NumGC        uint32 `json:"numGC"`
+	LastGC       string `json:"lastGC,omitempty"`
+	PauseTotalNs uint64 `json:"pauseTotalNs"`
+}
+
+// NewServer returns the HTTP server of the debug listener, serving the workqueue stats of the provider, which must be
+// set as the workqueue metrics provider before the controllers start
+func NewServer(addr string, queues *WorkqueueProvider) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PprofPath, pprof.Index)
+	mux.HandleFunc(PprofPath+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(PprofPath+"profile", pprof.Profile)
+	mux.HandleFunc(PprofPath+"symbol", pprof.Symbol)
+	mux.HandleFunc(PprofPath+"trace", pprof.Trace)
+	mux.HandleFunc(GoroutinesPath, serveGoroutines)
+	mux.HandleFunc(RuntimePath, serveRuntime)
+	mux.HandleFunc(WorkqueuesPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, queues.Stats())
+	})
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// serveGoroutines writes the stacks of all the goroutines, as a panic prints them
+func serveGoroutines(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err := runtimepprof.Lookup("goroutine").WriteTo(w, 2); err != nil {
+		logrus.Debugf("Debug: error writing goroutine dump: %v", err)
+	}
+}
+
+func serveRuntime(w http.ResponseWriter, r *http.Request) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	stats := RuntimeStats{
+		Goroutines:   runtime.NumGoroutine(),
+		GOMAXPROCS:   runtime.GOMAXPROCS(0),
+		HeapAlloc:    mem.HeapAlloc,
+		HeapInuse:    mem.HeapInuse,
+		HeapObjects:  mem.HeapObjects,
+		Sys:          mem.Sys,
+		NextGC:       mem.NextGC,
+		NumGC:        mem.NumGC,
+		PauseTotalNs: mem.PauseTotalNs,
+	}
+	if mem.LastGC > 0 {
+		stats.LastGC = time.Unix(0, int64(mem.LastGC)).UTC().Format(time.RFC3339)
+	}
+	writeJSON(w, stats)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		logrus.Debugf("Debug: error writing response: %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package debug
+
+import (
+	"sort"
+	"sync"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// WorkqueueStats are the stats of a controller workqueue since the operator started
+type WorkqueueStats struct {
+	Name string `json:"name"`
+	// items waiting, added in total, and requeued after an error
+	Depth   int64 `json:"depth"`
+	Adds    int64 `json:"adds"`
+	Retries int64 `json:"retries"`
+	// time the items waited in the queue and took to process, on average and at most
+	MeanQueueLatencySeconds float64 `json:"meanQueueLatencySeconds"`
+	MaxQueueLatencySeconds  float64 `json:"maxQueueLatencySeconds"`
+	MeanWorkSeconds         float64 `json:"meanWorkSeconds"`
+	MaxWorkSeconds          float64 `json:"maxWorkSeconds"`
+	// time spent by the items in process, and by the longest running one
+	UnfinishedWorkSeconds          float64 `json:"unfinishedWorkSeconds"`
+	LongestRunningProcessorSeconds float64 `json:"longestRunningProcessorSeconds"`
+}
+
+// WorkqueueProvider is a workqueue metrics provider keeping the stats of the named workqueues, those of the
+// controllers, in memory
+type WorkqueueProvider struct {
+	mu     sync.Mutex
+	queues map[string]*queueStats
+}
+
+// queueStats holds the stats of a workqueue, guarded by the mutex of the provider
+type queueStats struct {
+	stats WorkqueueStats
+	// observations of the latency and the work duration
+	latencyCount, workCount int64
+	latencySum, workSum     float64
+}
+
+// name of the queue probing which provider is set
+const probeQueueName = "debug-provider-probe"
+
+// Register sets the provider as the workqueue metrics provider, it must be called before the workqueues are created.
+// The provider set first wins, it returns false if another one was, e.g. the Prometheus one of lasso with
+// CATTLE_PROMETHEUS_METRICS=true.
+func (p *WorkqueueProvider) Register() bool {
+	workqueue.SetProvider(p)
+	// the provider is asked for the metrics of the named queues only, when they are created
+	workqueue.NewNamed(probeQueueName).ShutDown()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, registered := p.queues[probeQueueName]
+	delete(p.queues, probeQueueName)
+	return registered
+}
+
+// Stats returns the stats of the workqueues sorted by name
+func (p *WorkqueueProvider) Stats() []WorkqueueStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := make([]WorkqueueStats, 0, len(p.queues))
+	for _, queue := range p.queues {
+		s := queue.stats
+		if queue.latencyCount > 0 {
+			s.MeanQueueLatencySeconds = queue.latencySum / float64(queue.latencyCount)
+		}
+		if queue.workCount > 0 {
+			s.MeanWorkSeconds = queue.workSum / float64(queue.workCount)
+		}
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+func (p *WorkqueueProvider) getQueue(name string) *queueStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.queues == nil {
+		p.queues = map[string]*queueStats{}
+	}
+	queue, ok := p.queues[name]
+	if !ok {
+		queue = &queueStats{stats: WorkqueueStats{Name: name}}
+		p.queues[name] = queue
+	}
+	return queue
+}
+
+// metric adapts a stat of a queue to the metric interfaces of the workqueues
+type metric struct {
+	provider *WorkqueueProvider
+	queue    *queueStats
+	inc      func(q *queueStats, delta int64)
+	observe  func(q *queueStats, value float64)
+}
+
+func (m metric) update(f func()) {
+	m.provider.mu.Lock()
+	defer m.provider.mu.Unlock()
+	f()
+}
+
+func (m metric) Inc()                  { m.update(func() { m.inc(m.queue, 1) }) }
+func (m metric) Dec()                  { m.update(func() { m.inc(m.queue, -1) }) }
+func (m metric) Set(value float64)     { m.update(func() { m.observe(m.queue, value) }) }
+func (m metric) Observe(value float64) { m.update(func() { m.observe(m.queue, value) }) }
+
+// counter returns a metric incrementing a stat of the queue, and observer one recording the values of a stat
+func (p *WorkqueueProvider) counter(name string, inc func(q *queueStats, delta int64)) metric {
+	return metric{provider: p, queue: p.getQueue(name), inc: inc}
+}
+
+func (p *WorkqueueProvider) observer(name string, observe func(q *queueStats, value float64)) metric {
+	return metric{provider: p, queue: p.getQueue(name), observe: observe}
+}
+
+func (p *WorkqueueProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return p.counter(name, func(q *queueStats, delta int64) { q.stats.Depth += delta })
+}
+
+func (p *WorkqueueProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return p.counter(name, func(q *queueStats, delta int64) { q.stats.Adds += delta })
+}
+
+func (p *WorkqueueProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return p.observer(name, func(q *queueStats, value float64) {
+		q.latencyCount++
+		q.latencySum += value
+		if value > q.stats.MaxQueueLatencySeconds {
+			q.stats.MaxQueueLatencySeconds = value
+		}
+	})
+}
+
+func (p *WorkqueueProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return p.observer(name, func(q *queueStats, value float64) {
+		q.workCount++
+		q.workSum += value
+		if value > q.stats.MaxWorkSeconds {
+			q.stats.MaxWorkSeconds = value
+		}
+	})
+}
+
+func (p *WorkqueueProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return p.observer(name, func(q *queueStats, value float64) { q.stats.UnfinishedWorkSeconds = value })
+}
+
+func (p *WorkqueueProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return p.observer(name, func(q *queueStats, value float64) { q.stats.LongestRunningProcessorSeconds = value })
+}
+
+func (p *WorkqueueProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return p.counter(name, func(q *queueStats, delta int64) { q.stats.Retries += delta })
+}
@@ -0,0 +1,8 @@
+//go:build boringcrypto
+
+package fips
+
+// restrict crypto/tls to the FIPS approved settings
+import _ "crypto/tls/fipsonly"
+
+const boringCrypto = true
@@ -0,0 +1,80 @@
+// Package fips holds the FIPS mode of the operator. The mode is only available in the builds
+// made with GOEXPERIMENT=boringcrypto, see make build-fips, which link the FIPS 140-2 validated
+// BoringCrypto module and restrict crypto/tls to the FIPS approved settings. Once enabled, the
+// operator also refuses the TLS settings and the signature keys that are not FIPS approved.
+package fips
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// minRSAKeyBits is the smallest RSA key size approved for signature verification
+const minRSAKeyBits = 2048
+
+var enabled atomic.Bool
+
+// ErrNotBoringCrypto is returned when enabling the FIPS mode in a build without BoringCrypto
+var ErrNotBoringCrypto = errors.New("the FIPS mode requires a build with GOEXPERIMENT=boringcrypto")
+
+// Enable turns the FIPS mode on for the whole process.
+func Enable() error {
+	if !boringCrypto {
+		return ErrNotBoringCrypto
+	}
+	enabled.Store(true)
+	return nil
+}
+
+// Enabled returns whether the FIPS mode is on.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// ConfigureTLS restricts the TLS config to TLS 1.2 or later, the FIPS approved cipher suites and
+// curves, when the FIPS mode is on.
+func ConfigureTLS(cfg *tls.Config) {
+	if !Enabled() || cfg == nil {
+		return
+	}
+	if cfg.MinVersion < tls.VersionTLS12 {
+		cfg.MinVersion = tls.VersionTLS12
+	}
+	cfg.CipherSuites = []uint16{
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	}
+	cfg.CurvePreferences = []tls.CurveID{tls.CurveP256, tls.CurveP384}
+}
+
+// CheckPublicKey rejects the signature verification keys that are not FIPS approved when the FIPS
+// mode is on: RSA keys under 2048 bits, EC keys off the NIST P-256, P-384 and P-521 curves, and
+// Ed25519 keys.
+func CheckPublicKey(key crypto.PublicKey) error {
+	if !Enabled() {
+		return nil
+	}
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return fmt.Errorf("%d bits RSA keys are not allowed in FIPS mode", key.N.BitLen())
+		}
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256(), elliptic.P384(), elliptic.P521():
+		default:
+			return fmt.Errorf("EC keys on curve %v are not allowed in FIPS mode", key.Curve.Params().Name)
+		}
+	default:
+		return fmt.Errorf("%T keys are not allowed in FIPS mode", key)
+	}
+	return nil
+}
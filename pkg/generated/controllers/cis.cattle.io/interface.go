@@ -20,11 +20,13 @@ package cis
 
 import (
 	v1 "github.com/rancher/cis-operator/pkg/generated/controllers/cis.cattle.io/v1"
+	v2 "github.com/rancher/cis-operator/pkg/generated/controllers/cis.cattle.io/v2"
 	"github.com/rancher/lasso/pkg/controller"
 )
 
 type Interface interface {
 	V1() v1.Interface
+	V2() v2.Interface
 }
 
 type group struct {
@@ -38,6 +40,10 @@ func New(controllerFactory controller.SharedControllerFactory) Interface {
 	}
 }
 
+func (g *group) V2() v2.Interface {
+	return v2.New(g.controllerFactory)
+}
+
 func (g *group) V1() v1.Interface {
 	return v1.New(g.controllerFactory)
 }
@@ -25,7 +25,10 @@ import (
 	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
 	"github.com/rancher/lasso/pkg/client"
 	"github.com/rancher/lasso/pkg/controller"
+	"github.com/rancher/wrangler/pkg/apply"
+	"github.com/rancher/wrangler/pkg/condition"
 	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/rancher/wrangler/pkg/kv"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -55,7 +58,7 @@ type ClusterScanReportController interface {
 type ClusterScanReportClient interface {
 	Create(*v1.ClusterScanReport) (*v1.ClusterScanReport, error)
 	Update(*v1.ClusterScanReport) (*v1.ClusterScanReport, error)
-
+	UpdateStatus(*v1.ClusterScanReport) (*v1.ClusterScanReport, error)
 	Delete(name string, options *metav1.DeleteOptions) error
 	Get(name string, options metav1.GetOptions) (*v1.ClusterScanReport, error)
 	List(opts metav1.ListOptions) (*v1.ClusterScanReportList, error)
@@ -184,6 +187,11 @@ func (c *clusterScanReportController) Update(obj *v1.ClusterScanReport) (*v1.Clu
 	return result, c.client.Update(context.TODO(), "", obj, result, metav1.UpdateOptions{})
 }
 
+func (c *clusterScanReportController) UpdateStatus(obj *v1.ClusterScanReport) (*v1.ClusterScanReport, error) {
+	result := &v1.ClusterScanReport{}
+	return result, c.client.UpdateStatus(context.TODO(), "", obj, result, metav1.UpdateOptions{})
+}
+
 func (c *clusterScanReportController) Delete(name string, options *metav1.DeleteOptions) error {
 	if options == nil {
 		options = &metav1.DeleteOptions{}
@@ -254,3 +262,115 @@ func (c *clusterScanReportCache) GetByIndex(indexName, key string) (result []*v1
 	}
 	return result, nil
 }
+
+type ClusterScanReportStatusHandler func(obj *v1.ClusterScanReport, status v1.ClusterScanReportStatus) (v1.ClusterScanReportStatus, error)
+
+type ClusterScanReportGeneratingHandler func(obj *v1.ClusterScanReport, status v1.ClusterScanReportStatus) ([]runtime.Object, v1.ClusterScanReportStatus, error)
+
+func RegisterClusterScanReportStatusHandler(ctx context.Context, controller ClusterScanReportController, condition condition.Cond, name string, handler ClusterScanReportStatusHandler) {
+	statusHandler := &clusterScanReportStatusHandler{
+		client:    controller,
+		condition: condition,
+		handler:   handler,
+	}
+	controller.AddGenericHandler(ctx, name, FromClusterScanReportHandlerToHandler(statusHandler.sync))
+}
+
+func RegisterClusterScanReportGeneratingHandler(ctx context.Context, controller ClusterScanReportController, apply apply.Apply,
+	condition condition.Cond, name string, handler ClusterScanReportGeneratingHandler, opts *generic.GeneratingHandlerOptions) {
+	statusHandler := &clusterScanReportGeneratingHandler{
+		ClusterScanReportGeneratingHandler: handler,
+		apply:                              apply,
+		name:                               name,
+		gvk:                                controller.GroupVersionKind(),
+	}
+	if opts != nil {
+		statusHandler.opts = *opts
+	}
+	controller.OnChange(ctx, name, statusHandler.Remove)
+	RegisterClusterScanReportStatusHandler(ctx, controller, condition, name, statusHandler.Handle)
+}
+
+type clusterScanReportStatusHandler struct {
+	client    ClusterScanReportClient
+	condition condition.Cond
+	handler   ClusterScanReportStatusHandler
+}
+
+func (a *clusterScanReportStatusHandler) sync(key string, obj *v1.ClusterScanReport) (*v1.ClusterScanReport, error) {
+	if obj == nil {
+		return obj, nil
+	}
+
+	origStatus := obj.Status.DeepCopy()
+	obj = obj.DeepCopy()
+	newStatus, err := a.handler(obj, obj.Status)
+	if err != nil {
+		// Revert to old status on error
+		newStatus = *origStatus.DeepCopy()
+	}
+
+	if a.condition != "" {
+		if errors.IsConflict(err) {
+			a.condition.SetError(&newStatus, "", nil)
+		} else {
+			a.condition.SetError(&newStatus, "", err)
+		}
+	}
+	if !equality.Semantic.DeepEqual(origStatus, &newStatus) {
+		if a.condition != "" {
+			// Since status has changed, update the lastUpdatedTime
+			a.condition.LastUpdated(&newStatus, time.Now().UTC().Format(time.RFC3339))
+		}
+
+		var newErr error
+		obj.Status = newStatus
+		newObj, newErr := a.client.UpdateStatus(obj)
+		if err == nil {
+			err = newErr
+		}
+		if newErr == nil {
+			obj = newObj
+		}
+	}
+	return obj, err
+}
+
+type clusterScanReportGeneratingHandler struct {
+	ClusterScanReportGeneratingHandler
+	apply apply.Apply
+	opts  generic.GeneratingHandlerOptions
+	gvk   schema.GroupVersionKind
+	name  string
+}
+
+func (a *clusterScanReportGeneratingHandler) Remove(key string, obj *v1.ClusterScanReport) (*v1.ClusterScanReport, error) {
+	if obj != nil {
+		return obj, nil
+	}
+
+	obj = &v1.ClusterScanReport{}
+	obj.Namespace, obj.Name = kv.RSplit(key, "/")
+	obj.SetGroupVersionKind(a.gvk)
+
+	return nil, generic.ConfigureApplyForObject(a.apply, obj, &a.opts).
+		WithOwner(obj).
+		WithSetID(a.name).
+		ApplyObjects()
+}
+
+func (a *clusterScanReportGeneratingHandler) Handle(obj *v1.ClusterScanReport, status v1.ClusterScanReportStatus) (v1.ClusterScanReportStatus, error) {
+	if !obj.DeletionTimestamp.IsZero() {
+		return status, nil
+	}
+
+	objs, newStatus, err := a.ClusterScanReportGeneratingHandler(obj, status)
+	if err != nil {
+		return newStatus, err
+	}
+
+	return newStatus, generic.ConfigureApplyForObject(a.apply, obj, &a.opts).
+		WithOwner(obj).
+		WithSetID(a.name).
+		ApplyObjects(objs...)
+}
@@ -32,8 +32,14 @@ func init() {
 type Interface interface {
 	ClusterScan() ClusterScanController
 	ClusterScanBenchmark() ClusterScanBenchmarkController
+	ClusterScanCampaign() ClusterScanCampaignController
 	ClusterScanProfile() ClusterScanProfileController
+	ClusterScanRemediation() ClusterScanRemediationController
 	ClusterScanReport() ClusterScanReportController
+	ClusterScanReportShard() ClusterScanReportShardController
+	ClusterScanTemplate() ClusterScanTemplateController
+	OperatorConfig() OperatorConfigController
+	ScanSubscription() ScanSubscriptionController
 }
 
 func New(controllerFactory controller.SharedControllerFactory) Interface {
@@ -52,9 +58,27 @@ func (c *version) ClusterScan() ClusterScanController {
 func (c *version) ClusterScanBenchmark() ClusterScanBenchmarkController {
 	return NewClusterScanBenchmarkController(schema.GroupVersionKind{Group: "cis.cattle.io", Version: "v1", Kind: "ClusterScanBenchmark"}, "clusterscanbenchmarks", false, c.controllerFactory)
 }
+func (c *version) ClusterScanCampaign() ClusterScanCampaignController {
+	return NewClusterScanCampaignController(schema.GroupVersionKind{Group: "cis.cattle.io", Version: "v1", Kind: "ClusterScanCampaign"}, "clusterscancampaigns", false, c.controllerFactory)
+}
 func (c *version) ClusterScanProfile() ClusterScanProfileController {
 	return NewClusterScanProfileController(schema.GroupVersionKind{Group: "cis.cattle.io", Version: "v1", Kind: "ClusterScanProfile"}, "clusterscanprofiles", false, c.controllerFactory)
 }
+func (c *version) ClusterScanRemediation() ClusterScanRemediationController {
+	return NewClusterScanRemediationController(schema.GroupVersionKind{Group: "cis.cattle.io", Version: "v1", Kind: "ClusterScanRemediation"}, "clusterscanremediations", false, c.controllerFactory)
+}
 func (c *version) ClusterScanReport() ClusterScanReportController {
 	return NewClusterScanReportController(schema.GroupVersionKind{Group: "cis.cattle.io", Version: "v1", Kind: "ClusterScanReport"}, "clusterscanreports", false, c.controllerFactory)
 }
+func (c *version) ClusterScanReportShard() ClusterScanReportShardController {
+	return NewClusterScanReportShardController(schema.GroupVersionKind{Group: "cis.cattle.io", Version: "v1", Kind: "ClusterScanReportShard"}, "clusterscanreportshards", false, c.controllerFactory)
+}
+func (c *version) ClusterScanTemplate() ClusterScanTemplateController {
+	return NewClusterScanTemplateController(schema.GroupVersionKind{Group: "cis.cattle.io", Version: "v1", Kind: "ClusterScanTemplate"}, "clusterscantemplates", false, c.controllerFactory)
+}
+func (c *version) OperatorConfig() OperatorConfigController {
+	return NewOperatorConfigController(schema.GroupVersionKind{Group: "cis.cattle.io", Version: "v1", Kind: "OperatorConfig"}, "operatorconfigs", false, c.controllerFactory)
+}
+func (c *version) ScanSubscription() ScanSubscriptionController {
+	return NewScanSubscriptionController(schema.GroupVersionKind{Group: "cis.cattle.io", Version: "v1", Kind: "ScanSubscription"}, "scansubscriptions", false, c.controllerFactory)
+}
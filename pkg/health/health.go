@@ -0,0 +1,75 @@
+// Package health serves the liveness and readiness endpoints of the operator, /healthz and /readyz, from named
+// checks, in the format of the Kubernetes components: "ok" when all of them pass, and with ?verbose one line per check.
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	HealthzPath = "/healthz"
+	ReadyzPath  = "/readyz"
+)
+
+// Checks is a set of named checks served as one endpoint, failing with 500 when one of them fails
+type Checks struct {
+	mu     sync.RWMutex
+	names  []string
+	checks map[string]func() error
+}
+
+// Add adds a check, run on every request in the order the checks were added
+func (c *Checks) Add(name string, check func() error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.checks == nil {
+		c.checks = map[string]func() error{}
+	}
+	if _, ok := c.checks[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.checks[name] = check
+}
+
+func (c *Checks) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.RLock()
+	names := append([]string{}, c.names...)
+	checks := make(map[string]func() error, len(c.checks))
+	for name, check := range c.checks {
+		checks[name] = check
+	}
+	c.mu.RUnlock()
+
+	var out strings.Builder
+	failed := false
+	for _, name := range names {
+		if err := checks[name](); err != nil {
+			failed = true
+			fmt.Fprintf(&out, "[-]%v failed: %v\n", name, err)
+		} else {
+			fmt.Fprintf(&out, "[+]%v ok\n", name)
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if failed {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "%vcheck failed\n", out.String())
+		return
+	}
+	if _, verbose := r.URL.Query()["verbose"]; verbose {
+		fmt.Fprintf(w, "%vok\n", out.String())
+		return
+	}
+	fmt.Fprint(w, "ok")
+}
+
+// Register serves the liveness checks on /healthz and the readiness checks on /readyz of the mux
+func Register(mux *http.ServeMux, healthz, readyz *Checks) {
+	mux.Handle(HealthzPath, healthz)
+	mux.Handle(ReadyzPath, readyz)
+}
@@ -0,0 +1,98 @@
+// Package logging configures the logs of the operator, as text or as JSON, and changes their level while it runs:
+// from the OperatorConfig, or with SIGUSR1 and SIGUSR2 to raise and lower it.
+package logging
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+var (
+	mu sync.Mutex
+	// level last applied with SetLevel, the signals change the level until another one is applied
+	appliedLevel logrus.Level
+	applied      bool
+)
+
+// Configure sets the format of the logs and their level
+func Configure(format, level string) error {
+	switch format {
+	case "", FormatText:
+		logrus.SetFormatter(&logrus.TextFormatter{})
+	case FormatJSON:
+		logrus.SetFormatter(&logrus.JSONFormatter{TimestampFormat: time.RFC3339})
+	default:
+		return fmt.Errorf("invalid log format %q, must be %v or %v", format, FormatText, FormatJSON)
+	}
+	return SetLevel(level)
+}
+
+// ParseLevel returns the level of its name, e.g. debug, info if empty
+func ParseLevel(level string) (logrus.Level, error) {
+	if level == "" {
+		return logrus.InfoLevel, nil
+	}
+	parsed, err := logrus.ParseLevel(level)
+	if err != nil {
+		return 0, fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+	return parsed, nil
+}
+
+// SetLevel sets the level of the logs, unless it is the level applied last, so that applying the same configuration
+// again keeps the level a signal set since
+func SetLevel(level string) error {
+	parsed, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if applied && parsed == appliedLevel {
+		return nil
+	}
+	if applied && parsed != logrus.GetLevel() {
+		logrus.Infof("Setting the log level to %v", parsed)
+	}
+	appliedLevel, applied = parsed, true
+	logrus.SetLevel(parsed)
+	return nil
+}
+
+// HandleSignals raises the level of the logs on SIGUSR1 and lowers it on SIGUSR2, between error and trace, until the
+// channel is closed
+func HandleSignals(stop <-chan struct{}) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1, syscall.SIGUSR2)
+	go func() {
+		defer signal.Stop(signals)
+		for {
+			select {
+			case <-stop:
+				return
+			case sig := <-signals:
+				mu.Lock()
+				level := logrus.GetLevel()
+				if sig == syscall.SIGUSR1 && level < logrus.TraceLevel {
+					level++
+				} else if sig == syscall.SIGUSR2 && level > logrus.ErrorLevel {
+					level--
+				}
+				logrus.SetLevel(level)
+				mu.Unlock()
+				logrus.WithField("signal", sig.String()).Warnf("Log level set to %v", level)
+			}
+		}
+	}()
+}
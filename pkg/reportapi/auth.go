@@ -0,0 +1,378 @@
+package reportapi
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/rancher/cis-operator/pkg/fips"
+)
+
+const (
+	AuthModeTokenReview = "tokenreview"
+	AuthModeOIDC        = "oidc"
+	AuthModeClientCert  = "mtls"
+
+	// OIDC signing keys are refetched at most this often, e.g. on an unknown key id after a rotation
+	jwksRefreshInterval = 5 * time.Minute
+	// tolerated drift between the clocks of the issuer and the operator when checking the token validity
+	tokenClockSkew = time.Minute
+)
+
+var errNoBearerToken = errors.New("no bearer token")
+
+// Authenticator identifies the caller of a report API request.
+type Authenticator interface {
+	Authenticate(r *http.Request) (string, error)
+}
+
+// Authorizer is implemented by the authenticators able to check whether the caller of a request is
+// allowed a verb on a cis.cattle.io resource. The callers of the other ones are allowed every
+// operation the API exposes once authenticated.
+type Authorizer interface {
+	Authorize(r *http.Request, verb, resource string) error
+}
+
+func bearerToken(r *http.Request) (string, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", errNoBearerToken
+	}
+	return token, nil
+}
+
+// ClientCertAuthenticator accepts the callers presenting a client certificate verified against
+// the client CA of the server TLS config.
+type ClientCertAuthenticator struct{}
+
+func (ClientCertAuthenticator) Authenticate(r *http.Request) (string, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return "", errors.New("no verified client certificate")
+	}
+	return r.TLS.VerifiedChains[0][0].Subject.CommonName, nil
+}
+
+// TokenReviewAuthenticator accepts the Kubernetes tokens, such as service account tokens, whose
+// user is allowed to get clusterscanreports.
+type TokenReviewAuthenticator struct {
+	Client kubernetes.Interface
+	// audiences the token must be issued for, the API server's if empty
+	Audiences []string
+}
+
+func (a *TokenReviewAuthenticator) Authenticate(r *http.Request) (string, error) {
+	return a.review(r, "get", "clusterscanreports")
+}
+
+// Authorize checks the user of the token is allowed the verb on the resource.
+func (a *TokenReviewAuthenticator) Authorize(r *http.Request, verb, resource string) error {
+	_, err := a.review(r, verb, resource)
+	return err
+}
+
+// review authenticates the token and checks its user is allowed the verb on the resource
+func (a *TokenReviewAuthenticator) review(r *http.Request, verb, resource string) (string, error) {
+	token, err := bearerToken(r)
+	if err != nil {
+		return "", err
+	}
+	review, err := a.Client.AuthenticationV1().TokenReviews().Create(r.Context(), &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: a.Audiences,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error reviewing token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return "", fmt.Errorf("token not authenticated: %v", review.Status.Error)
+	}
+	user := review.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	access, err := a.Client.AuthorizationV1().SubjectAccessReviews().Create(r.Context(), &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Verb:     verb,
+				Group:    "cis.cattle.io",
+				Resource: resource,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error reviewing access of %v: %w", user.Username, err)
+	}
+	if !access.Status.Allowed {
+		return "", fmt.Errorf("%v is not allowed to %v %v", user.Username, verb, resource)
+	}
+	return user.Username, nil
+}
+
+// OIDCAuthenticator accepts the ID tokens signed by the issuer for the client ID.
+type OIDCAuthenticator struct {
+	IssuerURL string
+	ClientID  string
+	// claim used as the user name
+	UsernameClaim string
+	Client        *http.Client
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	lastFetched time.Time
+}
+
+// NewOIDCAuthenticator returns an OIDCAuthenticator, the issuer keys are fetched on first use.
+func NewOIDCAuthenticator(issuerURL, clientID, usernameClaim string) *OIDCAuthenticator {
+	if usernameClaim == "" {
+		usernameClaim = "sub"
+	}
+	return &OIDCAuthenticator{
+		IssuerURL:     strings.TrimSuffix(issuerURL, "/"),
+		ClientID:      clientID,
+		UsernameClaim: usernameClaim,
+		Client:        &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (a *OIDCAuthenticator) Authenticate(r *http.Request) (string, error) {
+	token, err := bearerToken(r)
+	if err != nil {
+		return "", err
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errors.New("malformed token")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return "", fmt.Errorf("malformed token header: %w", err)
+	}
+	key, err := a.key(r.Context(), header.Kid)
+	if err != nil {
+		return "", err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("malformed token signature: %w", err)
+	}
+	if err := verifyJWS(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return "", err
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return "", fmt.Errorf("malformed token claims: %w", err)
+	}
+	if iss, _ := claims["iss"].(string); strings.TrimSuffix(iss, "/") != a.IssuerURL {
+		return "", fmt.Errorf("unexpected token issuer %q", iss)
+	}
+	if !hasAudience(claims["aud"], a.ClientID) {
+		return "", fmt.Errorf("token not issued for %q", a.ClientID)
+	}
+	now := time.Now()
+	exp, ok := claims["exp"].(float64)
+	if !ok || now.Add(-tokenClockSkew).After(time.Unix(int64(exp), 0)) {
+		return "", errors.New("token expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(tokenClockSkew).Before(time.Unix(int64(nbf), 0)) {
+		return "", errors.New("token not valid yet")
+	}
+	user, _ := claims[a.UsernameClaim].(string)
+	if user == "" {
+		return "", fmt.Errorf("no %v claim in token", a.UsernameClaim)
+	}
+	return user, nil
+}
+
+func hasAudience(aud interface{}, clientID string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, a := range aud {
+			if a == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (a *OIDCAuthenticator) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if key, ok := a.keys[kid]; ok {
+		return key, nil
+	}
+	if time.Since(a.lastFetched) < jwksRefreshInterval {
+		return nil, fmt.Errorf("unknown token key %q", kid)
+	}
+	keys, err := a.fetchKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching keys of issuer %v: %w", a.IssuerURL, err)
+	}
+	a.keys = keys
+	a.lastFetched = time.Now()
+	if key, ok := a.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown token key %q", kid)
+}
+
+func (a *OIDCAuthenticator) fetchKeys(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	var discovery struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := a.getJSON(ctx, a.IssuerURL+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, err
+	}
+	var jwks struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			Kty string `json:"kty"`
+			Crv string `json:"crv"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"keys"`
+	}
+	if err := a.getJSON(ctx, discovery.JWKSURI, &jwks); err != nil {
+		return nil, err
+	}
+	keys := map[string]crypto.PublicKey{}
+	for _, jwk := range jwks.Keys {
+		switch jwk.Kty {
+		case "RSA":
+			n, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+			e, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+			if errN != nil || errE != nil {
+				continue
+			}
+			keys[jwk.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		case "EC":
+			var curve elliptic.Curve
+			switch jwk.Crv {
+			case "P-256":
+				curve = elliptic.P256()
+			case "P-384":
+				curve = elliptic.P384()
+			default:
+				continue
+			}
+			x, errX := base64.RawURLEncoding.DecodeString(jwk.X)
+			y, errY := base64.RawURLEncoding.DecodeString(jwk.Y)
+			if errX != nil || errY != nil {
+				continue
+			}
+			keys[jwk.Kid] = &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		}
+	}
+	return keys, nil
+}
+
+func (a *OIDCAuthenticator) getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := a.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %v: unexpected status %v", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func verifyJWS(alg string, key crypto.PublicKey, signed, signature []byte) error {
+	if err := fips.CheckPublicKey(key); err != nil {
+		return err
+	}
+	var h hash.Hash
+	var hashID crypto.Hash
+	// the curve an ES algorithm is defined on
+	var curve elliptic.Curve
+	switch alg {
+	case "RS256":
+		h, hashID = sha256.New(), crypto.SHA256
+	case "ES256":
+		h, hashID, curve = sha256.New(), crypto.SHA256, elliptic.P256()
+	case "RS384":
+		h, hashID = sha512.New384(), crypto.SHA384
+	case "ES384":
+		h, hashID, curve = sha512.New384(), crypto.SHA384, elliptic.P384()
+	case "RS512":
+		h, hashID = sha512.New(), crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported token algorithm %q", alg)
+	}
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if curve != nil {
+			return fmt.Errorf("algorithm %q does not match the RSA key", alg)
+		}
+		if err := rsa.VerifyPKCS1v15(key, hashID, digest, signature); err != nil {
+			return errors.New("invalid token signature")
+		}
+	case *ecdsa.PublicKey:
+		if curve == nil || key.Curve != curve {
+			return fmt.Errorf("algorithm %q does not match the EC key", alg)
+		}
+		// r and s are each padded to the byte size of the curve order
+		half := (curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*half {
+			return errors.New("invalid token signature")
+		}
+		r := new(big.Int).SetBytes(signature[:half])
+		s := new(big.Int).SetBytes(signature[half:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return errors.New("invalid token signature")
+		}
+	default:
+		return fmt.Errorf("unsupported key type %T", key)
+	}
+	return nil
+}
+
+func decodeSegment(segment string, out interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,308 @@
+package reportapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	cisscan "github.com/rancher/cis-operator/pkg/securityscan/scan"
+)
+
+const (
+	scansPath      = "/v1/scans"
+	rerunSuffix    = "/rerun"
+	progressSuffix = "/progress"
+
+	// how often the progress stream looks for changes of the scan
+	progressInterval = 2 * time.Second
+	// maximum size of the body creating a scan
+	maxScanBodyBytes = 1 << 20
+)
+
+// scanStatus is a scan as returned by the API
+type scanStatus struct {
+	Name             string                 `json:"name"`
+	ScanProfileName  string                 `json:"scanProfileName,omitempty"`
+	Scheduled        bool                   `json:"scheduled"`
+	State            string                 `json:"state,omitempty"`
+	Message          string                 `json:"message,omitempty"`
+	Transitioning    bool                   `json:"transitioning"`
+	LastRunTimestamp string                 `json:"lastRunTimestamp,omitempty"`
+	NextScanAt       string                 `json:"nextScanAt,omitempty"`
+	Summary          *v1.ClusterScanSummary `json:"summary,omitempty"`
+}
+
+// createScanRequest is the body creating a scan, the name is generated if empty
+type createScanRequest struct {
+	Name   string             `json:"name,omitempty"`
+	Labels map[string]string  `json:"labels,omitempty"`
+	Spec   v1.ClusterScanSpec `json:"spec"`
+}
+
+// serveScans serves:
+//   - GET /v1/scans, the scans with the same pagination as the reports,
+//   - GET /v1/scans/<name>, a scan,
+//   - GET /v1/scans/<name>/progress, server-sent events of the scan status until its run completes,
+//   - POST /v1/scans, creating a scan, and POST /v1/scans/<name>/rerun, running a complete scan
+//     again, when scan management is enabled.
+func (s *Server) serveScans(w http.ResponseWriter, r *http.Request) {
+	if s.Scans == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if !s.authenticate(w, r) {
+		return
+	}
+	name := strings.Trim(strings.TrimPrefix(r.URL.Path, scansPath), "/")
+	switch r.Method {
+	case http.MethodGet:
+		switch {
+		case name == "":
+			s.listScans(w, r)
+		case strings.HasSuffix(name, progressSuffix):
+			s.streamScanProgress(w, r, strings.TrimSuffix(name, progressSuffix))
+		default:
+			s.getScan(w, name)
+		}
+	case http.MethodPost:
+		if !s.ManageScans {
+			http.Error(w, "scan management is disabled", http.StatusForbidden)
+			return
+		}
+		switch {
+		case name == "":
+			s.createScan(w, r)
+		case strings.HasSuffix(name, rerunSuffix):
+			s.rerunScan(w, r, strings.TrimSuffix(name, rerunSuffix))
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// authorize checks the caller is allowed the verb on clusterscans. When the authenticator cannot tell, only the
+// ScanManagers are allowed to manage the scans.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request, verb string) bool {
+	authorizer, ok := s.Authenticator.(Authorizer)
+	if !ok {
+		user, err := s.Authenticator.Authenticate(r)
+		if err == nil && !slices.Contains(s.ScanManagers, user) {
+			err = fmt.Errorf("%v is not one of the scan managers", user)
+		}
+		if err != nil {
+			logrus.Debugf("Report API: rejected request to %v %v: %v", r.Method, r.URL.Path, err)
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return false
+		}
+		return true
+	}
+	if err := authorizer.Authorize(r, verb, "clusterscans"); err != nil {
+		logrus.Debugf("Report API: rejected request to %v %v: %v", r.Method, r.URL.Path, err)
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+func (s *Server) listScans(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := getPagination(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	scans, err := s.Scans.Cache().List(labels.Everything())
+	if err != nil {
+		logrus.Errorf("Report API: error listing scans: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	sort.Slice(scans, func(i, j int) bool {
+		return scans[i].Name < scans[j].Name
+	})
+	w.Header().Set("X-Total-Count", strconv.Itoa(len(scans)))
+	start, end := pageBounds(len(scans), limit, offset)
+	statuses := make([]scanStatus, 0, end-start)
+	for _, scan := range scans[start:end] {
+		statuses = append(statuses, getScanStatus(scan))
+	}
+	writeJSON(w, statuses)
+}
+
+func (s *Server) getScan(w http.ResponseWriter, name string) {
+	scan, ok := s.lookupScan(w, name)
+	if !ok {
+		return
+	}
+	writeJSON(w, getScanStatus(scan))
+}
+
+// lookupScan gets a scan from the cache, writing the error response if it can't
+func (s *Server) lookupScan(w http.ResponseWriter, name string) (*v1.ClusterScan, bool) {
+	scan, err := s.Scans.Cache().Get(name)
+	if errors.IsNotFound(err) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return nil, false
+	} else if err != nil {
+		logrus.Errorf("Report API: error getting scan %v: %v", name, err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return nil, false
+	}
+	return scan, true
+}
+
+// streamScanProgress sends the status of the scan as server-sent events whenever it changes, until
+// the scan is no longer transitioning or the client goes away
+func (s *Server) streamScanProgress(w http.ResponseWriter, r *http.Request, name string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	scan, ok := s.lookupScan(w, name)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+	var last *scanStatus
+	for {
+		status := getScanStatus(scan)
+		if last == nil || !reflect.DeepEqual(*last, status) {
+			data, err := json.Marshal(status)
+			if err != nil {
+				logrus.Errorf("Report API: error encoding progress of scan %v: %v", name, err)
+				return
+			}
+			if _, err := fmt.Fprintf(w, "event: progress\ndata: %s\n\n", data); err != nil {
+				return
+			}
+			flusher.Flush()
+			last = &status
+		}
+		if !status.Transitioning {
+			return
+		}
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+		var err error
+		if scan, err = s.Scans.Cache().Get(name); err != nil {
+			logrus.Debugf("Report API: stopping progress of scan %v: %v", name, err)
+			return
+		}
+	}
+}
+
+func (s *Server) createScan(w http.ResponseWriter, r *http.Request) {
+	if !s.authorize(w, r, "create") {
+		return
+	}
+	var req createScanRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxScanBodyBytes)).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid scan: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err := cisscan.ValidateClusterScanSpec(&req.Spec); err != nil {
+		http.Error(w, fmt.Sprintf("invalid scan: %v", err), http.StatusBadRequest)
+		return
+	}
+	clusterScan := &v1.ClusterScan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   req.Name,
+			Labels: req.Labels,
+		},
+		Spec: req.Spec,
+	}
+	if req.Name == "" {
+		clusterScan.GenerateName = "scan-"
+	}
+	created, err := s.Scans.Create(clusterScan)
+	if errors.IsAlreadyExists(err) {
+		http.Error(w, "already exists", http.StatusConflict)
+		return
+	} else if errors.IsInvalid(err) {
+		http.Error(w, fmt.Sprintf("invalid scan: %v", err), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		logrus.Errorf("Report API: error creating scan: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Location", scansPath+"/"+created.Name)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(getScanStatus(created)); err != nil {
+		logrus.Debugf("Report API: error writing response: %v", err)
+	}
+}
+
+// rerunScan annotates the scan with cis.cattle.io/rerun, the operator then launches a new run once
+// the current one is complete
+func (s *Server) rerunScan(w http.ResponseWriter, r *http.Request, name string) {
+	if !s.authorize(w, r, "update") {
+		return
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		scan, err := s.Scans.Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if scan.Annotations == nil {
+			scan.Annotations = map[string]string{}
+		}
+		scan.Annotations[v1.ClusterScanRerunAnnotation] = "true"
+		_, err = s.Scans.Update(scan)
+		return err
+	})
+	if errors.IsNotFound(err) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logrus.Errorf("Report API: error running scan %v again: %v", name, err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func getScanStatus(scan *v1.ClusterScan) scanStatus {
+	status := scanStatus{
+		Name:             scan.Name,
+		ScanProfileName:  scan.Spec.ScanProfileName,
+		Scheduled:        scan.Spec.Continuous != nil || (scan.Spec.ScheduledScanConfig != nil && scan.Spec.ScheduledScanConfig.CronSchedule != ""),
+		LastRunTimestamp: scan.Status.LastRunTimestamp,
+		NextScanAt:       scan.Status.NextScanAt,
+		Summary:          scan.Status.Summary,
+	}
+	if scan.Status.LastRunScanProfileName != "" {
+		status.ScanProfileName = scan.Status.LastRunScanProfileName
+	}
+	if display := scan.Status.Display; display != nil {
+		status.State = display.State
+		status.Message = display.Message
+		status.Transitioning = display.Transitioning
+	} else {
+		// not picked up by the operator yet
+		status.Transitioning = true
+	}
+	return status
+}
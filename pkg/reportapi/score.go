@@ -0,0 +1,66 @@
+package reportapi
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/rancher/cis-operator/pkg/client"
+)
+
+const scorePath = "/v1/score"
+
+// serveScore serves the compliance scores of the scans that completed a run, for the controllers gating on them,
+// e.g. progressive delivery tools:
+//   - GET /v1/score, the scores of every scan,
+//   - GET /v1/score/<name>, the score of a scan, in a list of one.
+func (s *Server) serveScore(w http.ResponseWriter, r *http.Request) {
+	if s.Scans == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.authenticate(w, r) {
+		return
+	}
+	list := &client.ComplianceScoreList{Items: []client.ComplianceScore{}}
+	list.Kind = "MetricValueList"
+	list.APIVersion = client.ComplianceScoreAPIVersion
+	list.SelfLink = r.URL.Path
+
+	if name := strings.Trim(strings.TrimPrefix(r.URL.Path, scorePath), "/"); name != "" {
+		scan, ok := s.lookupScan(w, name)
+		if !ok {
+			return
+		}
+		if scan.Status.ComplianceScore == nil {
+			http.Error(w, "no compliance score yet", http.StatusNotFound)
+			return
+		}
+		list.Items = append(list.Items, client.NewComplianceScore(scan, s.ClusterName))
+		writeJSON(w, list)
+		return
+	}
+
+	scans, err := s.Scans.Cache().List(labels.Everything())
+	if err != nil {
+		logrus.Errorf("Report API: error listing scans: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	sort.Slice(scans, func(i, j int) bool {
+		return scans[i].Name < scans[j].Name
+	})
+	for _, scan := range scans {
+		if scan.Status.ComplianceScore != nil {
+			list.Items = append(list.Items, client.NewComplianceScore(scan, s.ClusterName))
+		}
+	}
+	writeJSON(w, list)
+}
@@ -0,0 +1,318 @@
+// Package reportapi serves the ClusterScanReports and ClusterScans over an HTTP API, for audit
+// tooling and portals that should not be handed access to the Kubernetes API itself.
+package reportapi
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	kbreport "github.com/rancher/security-scan/pkg/kb-summarizer/report"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	cisctlv1 "github.com/rancher/cis-operator/pkg/generated/controllers/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+)
+
+const (
+	reportsPath      = "/v1/reports"
+	exemptionsSuffix = "/exemptions"
+	diffSuffix       = "/diff"
+)
+
+// Server is the report API handler.
+type Server struct {
+	Reports cisctlv1.ClusterScanReportCache
+	// nodes of the sharded reports, merged back into them when they are fetched
+	Shards cisctlv1.ClusterScanReportShardCache
+	// scans listed from their cache, and created or run again when ManageScans is set
+	Scans         cisctlv1.ClusterScanController
+	ManageScans   bool
+	Authenticator Authenticator
+	// users allowed to manage the scans when the Authenticator is not an Authorizer, none if empty
+	ScanManagers []string
+	// cluster the compliance scores are labelled with, unlabelled if empty
+	ClusterName string
+}
+
+// reportSummary is a report without its, potentially large, JSON body
+type reportSummary struct {
+	Name             string `json:"name"`
+	ScanName         string `json:"scanName,omitempty"`
+	BenchmarkVersion string `json:"benchmarkVersion"`
+	LastRunTimestamp string `json:"lastRunTimestamp"`
+	CreatedAt        string `json:"createdAt"`
+
+	Total         int `json:"total"`
+	Pass          int `json:"pass"`
+	Fail          int `json:"fail"`
+	Skip          int `json:"skip"`
+	Warn          int `json:"warn"`
+	NotApplicable int `json:"notApplicable"`
+
+	Teams map[string]v1.ClusterScanSummary `json:"teams,omitempty"`
+	// checks whose result differs across nodes of the same role
+	DriftedChecks int `json:"driftedChecks"`
+
+	NodeGroups []v1.ClusterScanNodeGroup `json:"nodeGroups,omitempty"`
+	// hardening findings of the operator itself, with self-check enabled
+	SelfCheckFindings int `json:"selfCheckFindings"`
+}
+
+// NewServer returns an http.Server serving the report API on addr. The TLS config is required
+// by the client certificate authentication, and recommended for the token based ones.
+func NewServer(addr string, handler *Server, tlsConfig *tls.Config) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle(reportsPath, handler)
+	mux.Handle(reportsPath+"/", handler)
+	mux.HandleFunc(scansPath, handler.serveScans)
+	mux.HandleFunc(scansPath+"/", handler.serveScans)
+	mux.HandleFunc(scorePath, handler.serveScore)
+	mux.HandleFunc(scorePath+"/", handler.serveScore)
+	mux.Handle(uiPath, uiHandler())
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		http.Redirect(w, r, uiPath, http.StatusFound)
+	})
+	return &http.Server{
+		Addr:      addr,
+		Handler:   mux,
+		TLSConfig: tlsConfig,
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.authenticate(w, r) {
+		return
+	}
+
+	name := strings.Trim(strings.TrimPrefix(r.URL.Path, reportsPath), "/")
+	if name == "" {
+		s.listReports(w, r.URL.Query())
+		return
+	}
+	if reportName, ok := strings.CutSuffix(name, exemptionsSuffix); ok {
+		s.getReportExemptions(w, reportName)
+		return
+	}
+	if reportName, ok := strings.CutSuffix(name, diffSuffix); ok {
+		s.getReportDiff(w, reportName)
+		return
+	}
+	s.getReport(w, name)
+}
+
+// authenticate rejects the requests of unauthenticated callers, it returns whether the request can go on
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) bool {
+	user, err := s.Authenticator.Authenticate(r)
+	if err != nil {
+		logrus.Debugf("Report API: rejected request to %v: %v", r.URL.Path, err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return false
+	}
+	logrus.Debugf("Report API: %v requested %v %v", user, r.Method, r.URL.Path)
+	return true
+}
+
+// listReports lists the reports, newest first, filtered by the scan and benchmarkVersion query
+// parameters and paginated by the limit and offset ones. The total count before pagination is
+// returned in the X-Total-Count header.
+func (s *Server) listReports(w http.ResponseWriter, query url.Values) {
+	limit, offset, err := getPagination(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	reports, err := s.Reports.List(labels.Everything())
+	if err != nil {
+		logrus.Errorf("Report API: error listing reports: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].CreationTimestamp.After(reports[j].CreationTimestamp.Time)
+	})
+	scanName, benchmarkVersion := query.Get("scan"), query.Get("benchmarkVersion")
+	summaries := make([]reportSummary, 0, len(reports))
+	for _, report := range reports {
+		if benchmarkVersion != "" && report.Spec.BenchmarkVersion != benchmarkVersion {
+			continue
+		}
+		summary := summarize(report)
+		if scanName != "" && summary.ScanName != scanName {
+			continue
+		}
+		summaries = append(summaries, summary)
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(len(summaries)))
+	start, end := pageBounds(len(summaries), limit, offset)
+	writeJSON(w, summaries[start:end])
+}
+
+// getPagination reads the limit and offset query parameters, a limit of 0 lists everything
+func getPagination(query url.Values) (limit, offset int, err error) {
+	if v := query.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			return 0, 0, fmt.Errorf("invalid limit %q", v)
+		}
+	}
+	if v := query.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			return 0, 0, fmt.Errorf("invalid offset %q", v)
+		}
+	}
+	return limit, offset, nil
+}
+
+// pageBounds returns the bounds of the requested page in a list of count items
+func pageBounds(count, limit, offset int) (int, int) {
+	if offset > count {
+		offset = count
+	}
+	end := count
+	if limit > 0 && offset+limit < count {
+		end = offset + limit
+	}
+	return offset, end
+}
+
+func (s *Server) getReport(w http.ResponseWriter, name string) {
+	report, err := s.Reports.Get(name)
+	if errors.IsNotFound(err) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logrus.Errorf("Report API: error getting report %v: %v", name, err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	reportJSON, err := s.getReportJSON(report)
+	if err != nil {
+		logrus.Errorf("Report API: error reading report %v: %v", name, err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(reportJSON); err != nil {
+		logrus.Debugf("Report API: error writing report %v: %v", name, err)
+	}
+}
+
+// getReportJSON returns the report JSON, with the nodes of its shards if it is sharded
+func (s *Server) getReportJSON(report *v1.ClusterScanReport) ([]byte, error) {
+	reportJSON, err := report.Spec.GetReportJSON()
+	if err != nil || !report.Spec.Sharded {
+		return reportJSON, err
+	}
+	if s.Shards == nil {
+		return nil, fmt.Errorf("report %v is sharded, its shards are not available", report.Name)
+	}
+	shards, err := s.Shards.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("error listing shards of report %v: %w", report.Name, err)
+	}
+	var specs []v1.ClusterScanReportShardSpec
+	for _, shard := range shards {
+		if shard.Spec.ReportName == report.Name {
+			specs = append(specs, shard.Spec)
+		}
+	}
+	if len(specs) != len(report.Status.Shards) {
+		return nil, fmt.Errorf("found %d of the %d shards of report %v", len(specs), len(report.Status.Shards), report.Name)
+	}
+	return engine.MergeReportShards(reportJSON, specs)
+}
+
+// getReportDiff returns the checks whose state changed since the previous report of the same scan
+func (s *Server) getReportDiff(w http.ResponseWriter, name string) {
+	report, err := s.Reports.Get(name)
+	if errors.IsNotFound(err) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logrus.Errorf("Report API: error getting report %v: %v", name, err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if report.Spec.Diff == nil {
+		http.Error(w, "no previous report to compare with", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, report.Spec.Diff)
+}
+
+// getReportExemptions returns the inventory of the tests skipped during the scan of a report
+func (s *Server) getReportExemptions(w http.ResponseWriter, name string) {
+	report, err := s.Reports.Get(name)
+	if errors.IsNotFound(err) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logrus.Errorf("Report API: error getting report %v: %v", name, err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	exemptions := report.Spec.Exemptions
+	if exemptions == nil {
+		exemptions = []v1.ClusterScanExemption{}
+	}
+	writeJSON(w, exemptions)
+}
+
+func summarize(report *v1.ClusterScanReport) reportSummary {
+	summary := reportSummary{
+		Name:              report.Name,
+		BenchmarkVersion:  report.Spec.BenchmarkVersion,
+		LastRunTimestamp:  report.Spec.LastRunTimestamp,
+		CreatedAt:         report.CreationTimestamp.UTC().Format(time.RFC3339),
+		Teams:             report.Spec.TeamSummaries,
+		DriftedChecks:     len(report.Spec.Drift),
+		NodeGroups:        report.Spec.NodeGroups,
+		SelfCheckFindings: len(report.Spec.SelfCheck),
+	}
+	for _, ref := range report.OwnerReferences {
+		if ref.Kind == "ClusterScan" {
+			summary.ScanName = ref.Name
+		}
+	}
+	reportJSON, err := report.Spec.GetReportJSON()
+	if err != nil {
+		logrus.Debugf("Report API: error reading report %v: %v", report.Name, err)
+		return summary
+	}
+	r, err := kbreport.Get(reportJSON)
+	if err != nil {
+		logrus.Debugf("Report API: error reading report %v: %v", report.Name, err)
+		return summary
+	}
+	summary.Total = r.Total
+	summary.Pass = r.Pass
+	summary.Fail = r.Fail
+	summary.Skip = r.Skip
+	summary.Warn = r.Warn
+	summary.NotApplicable = r.NotApplicable
+	return summary
+}
+
+func writeJSON(w http.ResponseWriter, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		logrus.Debugf("Report API: error writing response: %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+package reportapi
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+const uiPath = "/ui/"
+
+// the viewer is static, it reads the reports from the authenticated API with the caller credentials
+//
+//go:embed ui
+var uiFiles embed.FS
+
+func uiHandler() http.Handler {
+	files, err := fs.Sub(uiFiles, "ui")
+	if err != nil {
+		panic(err)
+	}
+	return http.StripPrefix(uiPath, http.FileServer(http.FS(files)))
+}
@@ -0,0 +1,223 @@
+"use strict";
+
+// The token only lives in the tab session, client certificates are sent by the browser itself.
+const tokenKey = "cis-report-api-token";
+let reports = [];
+const selected = new Set();
+
+function $(id) {
+  return document.getElementById(id);
+}
+
+function el(tag, attrs, ...children) {
+  const node = document.createElement(tag);
+  Object.entries(attrs || {}).forEach(([key, value]) => {
+    if (key === "onclick") {
+      node.addEventListener("click", value);
+    } else {
+      node.setAttribute(key, value);
+    }
+  });
+  children.forEach((child) => node.append(child));
+  return node;
+}
+
+function showError(message) {
+  $("error").textContent = message;
+  $("error").hidden = !message;
+}
+
+async function api(path) {
+  const headers = {};
+  const token = sessionStorage.getItem(tokenKey);
+  if (token) {
+    headers.Authorization = "Bearer " + token;
+  }
+  const resp = await fetch("../v1/reports" + path, { headers });
+  if (!resp.ok) {
+    throw new Error(`${resp.status} ${resp.statusText}`);
+  }
+  return resp.json();
+}
+
+function checks(report) {
+  const all = [];
+  (report.results || []).forEach((group) => {
+    (group.checks || []).forEach((check) => all.push(check));
+  });
+  return all;
+}
+
+async function loadReports() {
+  try {
+    reports = await api("");
+    showError("");
+  } catch (err) {
+    showError("Error loading reports: " + err.message);
+    return;
+  }
+  const filter = $("scan-filter");
+  const current = filter.value;
+  filter.replaceChildren(el("option", { value: "" }, "all"));
+  [...new Set(reports.map((r) => r.scanName).filter(Boolean))].sort().forEach((name) => {
+    filter.append(el("option", { value: name }, name));
+  });
+  filter.value = current;
+  renderReports();
+}
+
+function visibleReports() {
+  const scan = $("scan-filter").value;
+  return reports.filter((r) => !scan || r.scanName === scan);
+}
+
+function renderReports() {
+  const body = $("reports").querySelector("tbody");
+  body.replaceChildren();
+  visibleReports().forEach((r) => {
+    const box = el("input", { type: "checkbox" });
+    box.checked = selected.has(r.name);
+    box.addEventListener("change", () => {
+      box.checked ? selected.add(r.name) : selected.delete(r.name);
+      $("diff-button").disabled = selected.size !== 2;
+    });
+    body.append(el("tr", {},
+      el("td", {}, box),
+      el("td", {}, el("a", { onclick: () => showReport(r.name) }, r.name)),
+      el("td", {}, r.scanName || ""),
+      el("td", {}, r.benchmarkVersion),
+      el("td", {}, r.createdAt),
+      el("td", { class: "state-pass" }, String(r.pass)),
+      el("td", { class: "state-fail" }, String(r.fail)),
+      el("td", { class: "state-warn" }, String(r.warn)),
+      el("td", {}, String(r.skip)),
+      el("td", {}, String(r.notApplicable)),
+    ));
+  });
+  renderTrend();
+}
+
+// renderTrend plots the passed and failed checks of the listed reports, oldest first
+function renderTrend() {
+  const svg = $("trend");
+  const ns = "http://www.w3.org/2000/svg";
+  svg.replaceChildren();
+  const points = visibleReports().slice().reverse();
+  if (points.length < 2) {
+    return;
+  }
+  const width = svg.width.baseVal.value;
+  const height = svg.height.baseVal.value;
+  const pad = 20;
+  const max = Math.max(1, ...points.map((p) => Math.max(p.pass, p.fail)));
+  const x = (i) => pad + (i * (width - 2 * pad)) / (points.length - 1);
+  const y = (v) => height - pad - (v * (height - 2 * pad)) / max;
+  [["pass", "#080"], ["fail", "#b00"]].forEach(([field, color]) => {
+    const line = document.createElementNS(ns, "polyline");
+    line.setAttribute("fill", "none");
+    line.setAttribute("stroke", color);
+    line.setAttribute("stroke-width", "2");
+    line.setAttribute("points", points.map((p, i) => `${x(i)},${y(p[field])}`).join(" "));
+    const title = document.createElementNS(ns, "title");
+    title.textContent = field;
+    line.append(title);
+    svg.append(line);
+  });
+}
+
+async function showReport(name) {
+  let report;
+  try {
+    report = await api("/" + encodeURIComponent(name));
+  } catch (err) {
+    showError(`Error loading report ${name}: ${err.message}`);
+    return;
+  }
+  $("diff").hidden = true;
+  $("detail").hidden = false;
+  $("detail-title").textContent = `${name} (${report.version || ""})`;
+  const render = () => {
+    const state = $("state-filter").value;
+    const body = $("detail-body");
+    body.replaceChildren();
+    (report.results || []).forEach((group) => {
+      const groupChecks = (group.checks || []).filter((c) => !state || c.state === state);
+      if (groupChecks.length === 0) {
+        return;
+      }
+      body.append(el("h3", {}, `${group.id} ${group.description || ""}`));
+      groupChecks.forEach((check) => {
+        body.append(el("details", {},
+          el("summary", {},
+            el("span", { class: "state-" + check.state }, check.state), ` ${check.id} ${check.description}`),
+          el("p", {}, "Nodes: " + (check.nodes || []).join(", ")),
+          el("pre", {}, check.audit || ""),
+          el("p", {}, "Expected: " + (check.expected_result || "")),
+          el("p", {}, "Remediation: " + (check.remediation || "")),
+        ));
+      });
+    });
+  };
+  $("state-filter").onchange = render;
+  render();
+}
+
+async function showDiff() {
+  // diff from the older to the newer of the two selected reports
+  const names = reports.filter((r) => selected.has(r.name)).map((r) => r.name).reverse();
+  let before, after;
+  try {
+    [before, after] = await Promise.all(names.map((n) => api("/" + encodeURIComponent(n))));
+  } catch (err) {
+    showError("Error loading reports to diff: " + err.message);
+    return;
+  }
+  const beforeChecks = new Map(checks(before).map((c) => [c.id, c]));
+  const afterChecks = new Map(checks(after).map((c) => [c.id, c]));
+  const ids = [...new Set([...beforeChecks.keys(), ...afterChecks.keys()])].sort();
+  const body = $("diff-body");
+  body.replaceChildren();
+  ids.forEach((id) => {
+    const b = beforeChecks.get(id);
+    const a = afterChecks.get(id);
+    const bState = b ? b.state : "absent";
+    const aState = a ? a.state : "absent";
+    if (bState === aState) {
+      return;
+    }
+    body.append(el("tr", {},
+      el("td", {}, id),
+      el("td", {}, (a || b).description || ""),
+      el("td", { class: "state-" + bState }, bState),
+      el("td", { class: "state-" + aState }, aState),
+    ));
+  });
+  if (!body.children.length) {
+    body.append(el("tr", {}, el("td", { colspan: "4" }, "No check changed state")));
+  }
+  $("detail").hidden = true;
+  $("diff").hidden = false;
+  $("diff-title").textContent = `${names[0]} → ${names[1]}`;
+}
+
+$("token-form").addEventListener("submit", (event) => {
+  event.preventDefault();
+  const token = $("token").value.trim();
+  token ? sessionStorage.setItem(tokenKey, token) : sessionStorage.removeItem(tokenKey);
+  $("token").value = "";
+  loadReports();
+});
+$("scan-filter").addEventListener("change", renderReports);
+$("diff-button").addEventListener("click", showDiff);
+
+// deep links from alerts and notifications: ?scan=<name> or ?report=<name>
+const params = new URLSearchParams(window.location.search);
+loadReports().then(() => {
+  if (params.get("scan")) {
+    $("scan-filter").value = params.get("scan");
+    renderReports();
+  }
+  if (params.get("report")) {
+    showReport(params.get("report"));
+  }
+});
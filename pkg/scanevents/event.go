@@ -0,0 +1,120 @@
+// Package scanevents exports the lifecycle of the scans and the summaries of their results as JSON lines shaped
+// like Kubernetes audit events (audit.k8s.io/v1), for the SIEMs already ingesting the audit logs of the nodes.
+package scanevents
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+// stages of the lifecycle of a scan run, set in the StageAnnotation of its events
+const (
+	StageStarted   = "started"
+	StageCompleted = "completed"
+	StageFailed    = "failed"
+	StageCancelled = "cancelled"
+)
+
+// annotations of the events, the fields of the audit events hold the request on the ClusterScan
+const (
+	StageAnnotation            = "cis.cattle.io/scan-stage"
+	ProfileAnnotation          = "cis.cattle.io/scan-profile"
+	RunAnnotation              = "cis.cattle.io/scan-run"
+	MessageAnnotation          = "cis.cattle.io/message"
+	SummaryAnnotation          = "cis.cattle.io/summary"
+	ComplianceScoreAnnotation  = "cis.cattle.io/compliance-score"
+	ReportAnnotation           = "cis.cattle.io/report"
+	PostureDriftAnnotation     = "cis.cattle.io/posture-drift"
+	eventUsername              = "system:cis-operator"
+	eventAPIVersion            = "audit.k8s.io/v1"
+	eventLevel                 = "Metadata"
+	eventStage                 = "ResponseComplete"
+	clusterScanResource        = "clusterscans"
+	clusterScanAPIGroup        = "cis.cattle.io"
+	clusterScanAPIGroupVersion = "v1"
+)
+
+// Event is the subset of the audit.k8s.io/v1 Event written for the scans.
+type Event struct {
+	Kind                     string            `json:"kind"`
+	APIVersion               string            `json:"apiVersion"`
+	Level                    string            `json:"level"`
+	AuditID                  types.UID         `json:"auditID"`
+	Stage                    string            `json:"stage"`
+	RequestURI               string            `json:"requestURI"`
+	Verb                     string            `json:"verb"`
+	User                     UserInfo          `json:"user"`
+	ObjectRef                ObjectReference   `json:"objectRef"`
+	ResponseStatus           metav1.Status     `json:"responseStatus"`
+	RequestReceivedTimestamp metav1.MicroTime  `json:"requestReceivedTimestamp"`
+	StageTimestamp           metav1.MicroTime  `json:"stageTimestamp"`
+	Annotations              map[string]string `json:"annotations,omitempty"`
+}
+
+type UserInfo struct {
+	Username string `json:"username"`
+}
+
+type ObjectReference struct {
+	Resource    string    `json:"resource"`
+	Name        string    `json:"name"`
+	UID         types.UID `json:"uid,omitempty"`
+	APIGroup    string    `json:"apiGroup"`
+	APIVersion  string    `json:"apiVersion"`
+	Subresource string    `json:"subresource"`
+}
+
+// NewEvent returns the event of a stage of the run of the named ClusterScan, as an update of its status.
+func NewEvent(scanName string, scanUID types.UID, stage string, now time.Time) *Event {
+	timestamp := metav1.NewMicroTime(now)
+	return &Event{
+		Kind:       "Event",
+		APIVersion: eventAPIVersion,
+		Level:      eventLevel,
+		AuditID:    uuid.NewUUID(),
+		Stage:      eventStage,
+		RequestURI: "/apis/" + clusterScanAPIGroup + "/" + clusterScanAPIGroupVersion + "/" + clusterScanResource + "/" + scanName + "/status",
+		Verb:       "update",
+		User:       UserInfo{Username: eventUsername},
+		ObjectRef: ObjectReference{
+			Resource:    clusterScanResource,
+			Name:        scanName,
+			UID:         scanUID,
+			APIGroup:    clusterScanAPIGroup,
+			APIVersion:  clusterScanAPIGroupVersion,
+			Subresource: "status",
+		},
+		ResponseStatus:           metav1.Status{Code: 200},
+		RequestReceivedTimestamp: timestamp,
+		StageTimestamp:           timestamp,
+		Annotations:              map[string]string{StageAnnotation: stage},
+	}
+}
+
+// Exporter writes the events as JSON lines.
+type Exporter struct {
+	lock sync.Mutex
+	w    io.Writer
+}
+
+func NewExporter(w io.Writer) *Exporter {
+	return &Exporter{w: w}
+}
+
+// Export writes the event as a single line.
+func (e *Exporter) Export(event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	_, err = e.w.Write(append(data, '\n'))
+	return err
+}
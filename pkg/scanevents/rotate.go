@@ -0,0 +1,85 @@
+package scanevents
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// RotatingFile is a file rotated once it reaches its maximum size: path is renamed path.1, path.1 path.2 and so
+// on, up to maxBackups files, the oldest one is removed.
+type RotatingFile struct {
+	lock       sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// NewRotatingFile opens the file at path for appending, it is rotated before exceeding maxSize bytes.
+func NewRotatingFile(path string, maxSize int64, maxBackups int) (*RotatingFile, error) {
+	f := &RotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening %v: %w", f.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("error opening %v: %w", f.path, err)
+	}
+	f.file, f.size = file, info.Size()
+	return nil
+}
+
+// Write appends p to the file, rotating it first when p would take it over its maximum size. A single
+// write larger than the maximum size is written whole to a new file.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("error closing %v: %w", f.path, err)
+	}
+	if f.maxBackups == 0 {
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error removing %v: %w", f.path, err)
+		}
+		return f.open()
+	}
+	os.Remove(fmt.Sprintf("%v.%d", f.path, f.maxBackups))
+	for i := f.maxBackups - 1; i > 0; i-- {
+		if err := os.Rename(fmt.Sprintf("%v.%d", f.path, i), fmt.Sprintf("%v.%d", f.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error rotating %v: %w", f.path, err)
+		}
+	}
+	if err := os.Rename(f.path, f.path+".1"); err != nil {
+		return fmt.Errorf("error rotating %v: %w", f.path, err)
+	}
+	return f.open()
+}
+
+// Close closes the file.
+func (f *RotatingFile) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.file.Close()
+}
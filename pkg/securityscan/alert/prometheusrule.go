@@ -22,14 +22,16 @@ const templateName = "prometheusrule.template"
 
 func NewPrometheusRule(clusterscan *cisoperatorapiv1.ClusterScan, clusterscanprofile *cisoperatorapiv1.ClusterScanProfile, imageConfig *cisoperatorapiv1.ScanImageConfig) (*monitoringv1.PrometheusRule, error) {
 	configdata := map[string]interface{}{
-		"namespace":       cisoperatorapiv1.ClusterScanNS,
-		"name":            name.SafeConcatName("rancher-cis-alerts", clusterscan.Name),
-		"severity":        imageConfig.AlertSeverity,
-		"scanName":        clusterscan.Name,
-		"scanProfileName": clusterscanprofile.Name,
-		"alertOnFailure":  clusterscan.Spec.ScheduledScanConfig.ScanAlertRule.AlertOnFailure,
-		"alertOnComplete": clusterscan.Spec.ScheduledScanConfig.ScanAlertRule.AlertOnComplete,
-		"failOnWarn":      clusterscan.Spec.ScoreWarning == cisoperatorapiv1.ClusterScanFailOnWarning,
+		"namespace":        cisoperatorapiv1.ClusterScanNS,
+		"name":             name.SafeConcatName("rancher-cis-alerts", clusterscan.Name),
+		"severity":         imageConfig.AlertSeverity,
+		"scanName":         clusterscan.Name,
+		"scanProfileName":  clusterscanprofile.Name,
+		"alertOnFailure":   clusterscan.Spec.ScheduledScanConfig.ScanAlertRule.AlertOnFailure,
+		"alertOnComplete":  clusterscan.Spec.ScheduledScanConfig.ScanAlertRule.AlertOnComplete,
+		"failOnWarn":       clusterscan.Spec.ScoreWarning == cisoperatorapiv1.ClusterScanFailOnWarning,
+		"regressionBudget": clusterscan.Spec.ScheduledScanConfig.RegressionBudget != nil,
+		"reportsURL":       imageConfig.ScanReportsURL(clusterscan.Name),
 	}
 	scanAlertRule, err := generatePrometheusRule(clusterscan, configdata)
 	if err != nil {
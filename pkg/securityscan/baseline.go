@@ -0,0 +1,47 @@
+package securityscan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+)
+
+// checkBaseline compares the check states of the last report of the scan against its baseline report,
+// setting the regressions and the RegressionDetected condition of its status
+func (c *Controller) checkBaseline(scan *v1.ClusterScan, states map[string]string) error {
+	scan.Status.Regressions = nil
+	if scan.Spec.BaselineReportName == "" {
+		return nil
+	}
+	baseline, err := c.cisFactory.Cis().V1().ClusterScanReport().Cache().Get(scan.Spec.BaselineReportName)
+	if errors.IsNotFound(err) {
+		logrus.Warnf("Baseline ClusterScanReport %v of scan %v not found", scan.Spec.BaselineReportName, scan.Name)
+		v1.ClusterScanConditionRegressionDetected.Unknown(scan)
+		v1.ClusterScanConditionRegressionDetected.Message(scan, fmt.Sprintf("baseline ClusterScanReport %v not found", scan.Spec.BaselineReportName))
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error getting baseline ClusterScanReport %v: %w", scan.Spec.BaselineReportName, err)
+	}
+	reportJSON, err := baseline.Spec.GetReportJSON()
+	if err != nil {
+		return fmt.Errorf("error reading baseline ClusterScanReport %v: %w", baseline.Name, err)
+	}
+	baselineStates, err := engine.GetCheckStates(reportJSON)
+	if err != nil {
+		return fmt.Errorf("error reading check states of baseline ClusterScanReport %v: %w", baseline.Name, err)
+	}
+	scan.Status.Regressions = engine.GetRegressions(baselineStates, states)
+	if len(scan.Status.Regressions) == 0 {
+		v1.ClusterScanConditionRegressionDetected.False(scan)
+		v1.ClusterScanConditionRegressionDetected.Message(scan, fmt.Sprintf("no check passing in baseline %v fails", baseline.Name))
+		return nil
+	}
+	v1.ClusterScanConditionRegressionDetected.True(scan)
+	v1.ClusterScanConditionRegressionDetected.Message(scan, fmt.Sprintf("checks passing in baseline %v fail: %v", baseline.Name, strings.Join(scan.Status.Regressions, ", ")))
+	return nil
+}
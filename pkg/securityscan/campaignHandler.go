@@ -0,0 +1,223 @@
+package securityscan
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+)
+
+// report events enqueue all the campaigns, campaign events recompute the progress of the campaign from the last
+// report of every scan it selects, append a burn-down point when a new report is in, and enqueue the campaign
+// again for its deadline so that it turns overdue without waiting for another report
+func (c *Controller) handleCampaigns(ctx context.Context) error {
+	campaigns := c.cisFactory.Cis().V1().ClusterScanCampaign()
+	reports := c.cisFactory.Cis().V1().ClusterScanReport()
+
+	reports.OnChange(ctx, c.Name, func(key string, obj *v1.ClusterScanReport) (*v1.ClusterScanReport, error) {
+		if obj == nil || obj.DeletionTimestamp != nil {
+			return obj, nil
+		}
+		campaignList, err := campaigns.Cache().List(labels.Everything())
+		if err != nil {
+			return obj, err
+		}
+		for _, campaign := range campaignList {
+			campaigns.Enqueue(campaign.Name)
+		}
+		return obj, nil
+	})
+
+	campaigns.OnChange(ctx, c.Name, func(key string, obj *v1.ClusterScanCampaign) (*v1.ClusterScanCampaign, error) {
+		if obj == nil || obj.DeletionTimestamp != nil {
+			c.campaignCompletion.DeleteLabelValues(key, c.getImageConfig().ClusterName)
+			c.campaignRemainingChecks.DeleteLabelValues(key, c.getImageConfig().ClusterName)
+			return obj, nil
+		}
+		if !c.ownsName(obj.Name) {
+			return obj, nil
+		}
+		deadline, err := validateClusterScanCampaign(obj)
+		if err != nil {
+			if v1.ClusterScanCampaignConditionCompleted.IsFalse(obj) && v1.ClusterScanCampaignConditionCompleted.GetMessage(obj) == err.Error() {
+				return obj, nil
+			}
+			objCopy := obj.DeepCopy()
+			v1.ClusterScanCampaignConditionCompleted.False(objCopy)
+			v1.ClusterScanCampaignConditionCompleted.Message(objCopy, err.Error())
+			return campaigns.UpdateStatus(objCopy)
+		}
+		states, lastReport, err := c.getCampaignCheckStates(obj)
+		if err != nil {
+			return obj, err
+		}
+
+		objCopy := obj.DeepCopy()
+		setCampaignProgress(objCopy, states, lastReport, time.Now())
+		completed := objCopy.Status.Fixed == objCopy.Status.Total
+		if completed {
+			v1.ClusterScanCampaignConditionCompleted.True(objCopy)
+			v1.ClusterScanCampaignConditionCompleted.Message(objCopy, "")
+		} else {
+			v1.ClusterScanCampaignConditionCompleted.False(objCopy)
+			v1.ClusterScanCampaignConditionCompleted.Message(objCopy, fmt.Sprintf("%d of %d checks remaining", len(objCopy.Status.Remaining), objCopy.Status.Total))
+		}
+		remaining := time.Until(deadline)
+		if !completed && remaining <= 0 {
+			v1.ClusterScanCampaignConditionOverdue.True(objCopy)
+			v1.ClusterScanCampaignConditionOverdue.Message(objCopy, fmt.Sprintf("deadline %v passed", obj.Spec.Deadline))
+		} else {
+			v1.ClusterScanCampaignConditionOverdue.False(objCopy)
+			v1.ClusterScanCampaignConditionOverdue.Message(objCopy, "")
+		}
+		if !completed && remaining > 0 {
+			campaigns.EnqueueAfter(obj.Name, remaining)
+		}
+
+		c.campaignCompletion.WithLabelValues(obj.Name, c.getImageConfig().ClusterName).Set(objCopy.Status.CompletionPercentage)
+		c.campaignRemainingChecks.WithLabelValues(obj.Name, c.getImageConfig().ClusterName).Set(float64(len(objCopy.Status.Remaining)))
+
+		if reflect.DeepEqual(obj.Status, objCopy.Status) {
+			return obj, nil
+		}
+		logrus.Debugf("ClusterScanCampaign %v fixed %v of %v checks", obj.Name, objCopy.Status.Fixed, objCopy.Status.Total)
+		return campaigns.UpdateStatus(objCopy)
+	})
+	return nil
+}
+
+// validateClusterScanCampaign checks the campaign names the checks to fix and a valid scan selector, and returns
+// its deadline
+func validateClusterScanCampaign(campaign *v1.ClusterScanCampaign) (time.Time, error) {
+	if len(campaign.Spec.TestIDs) == 0 {
+		return time.Time{}, fmt.Errorf("no testIDs")
+	}
+	if campaign.Spec.ScanSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(campaign.Spec.ScanSelector); err != nil {
+			return time.Time{}, fmt.Errorf("invalid scanSelector: %w", err)
+		}
+	}
+	return parseCampaignDeadline(campaign.Spec.Deadline)
+}
+
+// parseCampaignDeadline parses a deadline as RFC3339 or as a date, which is due at its end in UTC
+func parseCampaignDeadline(deadline string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, deadline); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", deadline)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid deadline %q, must be YYYY-MM-DD or RFC3339", deadline)
+	}
+	return t.AddDate(0, 0, 1), nil
+}
+
+// getCampaignCheckStates returns the states of the target checks of the campaign, each in the most recent of the last
+// reports of the selected scans the check is in, and the name of the most recent of these reports
+func (c *Controller) getCampaignCheckStates(campaign *v1.ClusterScanCampaign) (map[string]string, string, error) {
+	selector := labels.Everything()
+	if campaign.Spec.ScanSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(campaign.Spec.ScanSelector)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	scanList, err := c.scans.Cache().List(selector)
+	if err != nil {
+		return nil, "", fmt.Errorf("error listing ClusterScans: %w", err)
+	}
+	var latestReports []*v1.ClusterScanReport
+	for _, scan := range scanList {
+		report, err := c.getLatestClusterScanReport(scan)
+		if err != nil {
+			return nil, "", err
+		}
+		if report != nil {
+			latestReports = append(latestReports, report)
+		}
+	}
+	// oldest first, so that the states of the more recent reports win
+	sort.Slice(latestReports, func(i, j int) bool {
+		return latestReports[i].CreationTimestamp.Before(&latestReports[j].CreationTimestamp)
+	})
+
+	targets := map[string]bool{}
+	for _, testID := range campaign.Spec.TestIDs {
+		targets[testID] = true
+	}
+	states := map[string]string{}
+	lastReport := ""
+	for _, report := range latestReports {
+		reportJSON, err := report.Spec.GetReportJSON()
+		if err != nil {
+			logrus.Errorf("Skipping ClusterScanReport %v of ClusterScanCampaign %v: %v", report.Name, campaign.Name, err)
+			continue
+		}
+		reportStates, err := engine.GetCheckStates(reportJSON)
+		if err != nil {
+			logrus.Errorf("Skipping ClusterScanReport %v of ClusterScanCampaign %v: %v", report.Name, campaign.Name, err)
+			continue
+		}
+		for id, state := range reportStates {
+			if targets[id] {
+				states[id] = state
+			}
+		}
+		lastReport = report.Name
+	}
+	return states, lastReport, nil
+}
+
+// setCampaignProgress sets the progress of the campaign from the states of its target checks, a check not in
+// any report is not run, and appends a burn-down point when the last report changed
+func setCampaignProgress(campaign *v1.ClusterScanCampaign, states map[string]string, lastReport string, now time.Time) {
+	status := &campaign.Status
+	status.Total = 0
+	status.Fixed = 0
+	status.Remaining = nil
+	seen := map[string]bool{}
+	for _, testID := range campaign.Spec.TestIDs {
+		if seen[testID] {
+			continue
+		}
+		seen[testID] = true
+		status.Total++
+		state, ok := states[testID]
+		if !ok {
+			state = "not run"
+		}
+		if state == "pass" {
+			status.Fixed++
+			continue
+		}
+		if status.Remaining == nil {
+			status.Remaining = map[string]string{}
+		}
+		status.Remaining[testID] = state
+	}
+	status.CompletionPercentage = math.Round(float64(status.Fixed)*10000/float64(status.Total)) / 100
+
+	if lastReport == "" || lastReport == status.LastReportName {
+		return
+	}
+	status.LastReportName = lastReport
+	burnDown := append(status.BurnDown, v1.ClusterScanCampaignBurnDownPoint{
+		Timestamp:  now.Round(time.Second).Format(time.RFC3339),
+		ReportName: lastReport,
+		Remaining:  len(status.Remaining),
+	})
+	if len(burnDown) > v1.MaxCampaignBurnDownPoints {
+		burnDown = burnDown[len(burnDown)-v1.MaxCampaignBurnDownPoints:]
+	}
+	status.BurnDown = burnDown
+}
@@ -0,0 +1,158 @@
+package securityscan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// node roles a canary scan samples from in turn, so that even a few nodes cover the checks of every role
+var canaryNodeRoleLabels = [][]string{
+	{"node-role.kubernetes.io/etcd"},
+	{"node-role.kubernetes.io/controlplane", "node-role.kubernetes.io/control-plane", "node-role.kubernetes.io/master"},
+}
+
+// isCanaryScan is true for the scans of a subset of the nodes in scope, sampled or selected by name, kubelet version
+// or OS image
+func isCanaryScan(scan *v1.ClusterScan) bool {
+	return scan.Spec.MaxNodes > 0 || len(scan.Spec.NodeNames) > 0 || scan.Spec.KubeletVersionRange != "" || len(scan.Spec.OSImages) > 0
+}
+
+// getNodesInScope returns the nodes matching the nodeSelector of the scan that its plugins run on: the linux nodes,
+// and the windows nodes when they are scanned
+func (c *Controller) getNodesInScope(scan *v1.ClusterScan, scanWindowsNodes bool) ([]corev1.Node, error) {
+	nodes, err := c.nodes.Cache().List(labels.SelectorFromSet(scan.Spec.NodeSelector))
+	if err != nil {
+		return nil, err
+	}
+	var inScope []corev1.Node
+	for _, node := range nodes {
+		switch node.Labels[corev1.LabelOSStable] {
+		case "linux":
+		case "windows":
+			if !scanWindowsNodes {
+				continue
+			}
+		default:
+			continue
+		}
+		inScope = append(inScope, *node)
+	}
+	return inScope, nil
+}
+
+// sampleScanNodes returns the nodes a canary scan runs on, nil for a scan of all the nodes in scope. The nodeNames
+// of the scan not in scope are left out, none is returned for a canary scan when none is in scope.
+// The nodes are first filtered on their kubelet version and OS image.
+func (c *Controller) sampleScanNodes(scan *v1.ClusterScan, scanWindowsNodes bool) ([]string, error) {
+	if !isCanaryScan(scan) {
+		return nil, nil
+	}
+	nodes, err := c.getNodesInScope(scan, scanWindowsNodes)
+	if err != nil {
+		return nil, err
+	}
+	filtered, err := filterNodesByVersion(nodes, scan.Spec.KubeletVersionRange, scan.Spec.OSImages)
+	if err != nil {
+		return nil, err
+	}
+	return sampleNodes(filtered, scan.Spec.NodeNames, scan.Spec.MaxNodes), nil
+}
+
+// filterNodesByVersion returns the nodes whose kubelet version is in kubeletVersionRange and whose OS image contains
+// one of osImages, when set. The pre-release part of the kubelet versions is ignored, e.g. v1.27.4-eks-8ccc7ba is
+// matched as 1.27.4, and the nodes whose kubelet version is not semver are left out.
+func filterNodesByVersion(nodes []corev1.Node, kubeletVersionRange string, osImages []string) ([]corev1.Node, error) {
+	if kubeletVersionRange == "" && len(osImages) == 0 {
+		return nodes, nil
+	}
+	var inRange semver.Range
+	if kubeletVersionRange != "" {
+		var err error
+		inRange, err = semver.ParseRange(kubeletVersionRange)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kubeletVersionRange %q: %w", kubeletVersionRange, err)
+		}
+	}
+	var filtered []corev1.Node
+	for _, node := range nodes {
+		if inRange != nil {
+			version, err := semver.ParseTolerant(node.Status.NodeInfo.KubeletVersion)
+			if err != nil {
+				continue
+			}
+			version.Pre = nil
+			version.Build = nil
+			if !inRange(version) {
+				continue
+			}
+		}
+		if len(osImages) > 0 && !containsAny(node.Status.NodeInfo.OSImage, osImages) {
+			continue
+		}
+		filtered = append(filtered, node)
+	}
+	return filtered, nil
+}
+
+func containsAny(s string, substrings []string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+// sampleNodes returns the names of the given nodes, or of the ones in nodeNames if set, sorted. When there are more
+// than maxNodes, one node of every role is taken in turn, etcd, control plane then worker, until maxNodes are.
+func sampleNodes(nodes []corev1.Node, nodeNames []string, maxNodes int) []string {
+	wanted := map[string]bool{}
+	for _, nodeName := range nodeNames {
+		wanted[nodeName] = true
+	}
+	byRole := make([][]string, len(canaryNodeRoleLabels)+1)
+	count := 0
+	for _, node := range nodes {
+		if len(wanted) > 0 && !wanted[node.Name] {
+			continue
+		}
+		role := getCanaryNodeRole(&node)
+		byRole[role] = append(byRole[role], node.Name)
+		count++
+	}
+	if maxNodes <= 0 || maxNodes > count {
+		maxNodes = count
+	}
+	for _, names := range byRole {
+		sort.Strings(names)
+	}
+	var sampled []string
+	for i := 0; len(sampled) < maxNodes; i++ {
+		for _, names := range byRole {
+			if i < len(names) && len(sampled) < maxNodes {
+				sampled = append(sampled, names[i])
+			}
+		}
+	}
+	sort.Strings(sampled)
+	return sampled
+}
+
+// getCanaryNodeRole returns the index of the role of the node in canaryNodeRoleLabels, the last index for a worker
+func getCanaryNodeRole(node *corev1.Node) int {
+	for role, roleLabels := range canaryNodeRoleLabels {
+		for _, label := range roleLabels {
+			if _, ok := node.Labels[label]; ok {
+				return role
+			}
+		}
+	}
+	return len(canaryNodeRoleLabels)
+}
@@ -0,0 +1,92 @@
+package securityscan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rancher/wrangler/pkg/name"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// scan events tear down the runs of the scans with spec.cancel set, and reschedule the scheduled
+// scans once spec.cancel is unset
+func (c *Controller) handleClusterScanCancellations(ctx context.Context) error {
+	scans := c.cisFactory.Cis().V1().ClusterScan()
+
+	scans.OnChange(ctx, c.Name, func(key string, obj *v1.ClusterScan) (*v1.ClusterScan, error) {
+		if obj == nil || obj.DeletionTimestamp != nil {
+			return obj, nil
+		}
+		if !c.ownsName(obj.Name) {
+			return obj, nil
+		}
+		if !obj.Spec.Cancel {
+			if !v1.ClusterScanConditionCancelled.IsTrue(obj) || !isScheduledScan(obj) {
+				return obj, nil
+			}
+			scanLog(obj).Infof("Scan %v is no longer cancelled, rescheduling it", obj.Name)
+			scanCopy := obj.DeepCopy()
+			v1.ClusterScanConditionCancelled.False(scanCopy)
+			v1.ClusterScanConditionCancelled.Message(scanCopy, "")
+			if err := c.rescheduleScan(scanCopy); err != nil {
+				return obj, err
+			}
+			c.setClusterScanStatusDisplay(scanCopy)
+			return scans.UpdateStatus(scanCopy)
+		}
+
+		running := v1.ClusterScanConditionCreated.IsTrue(obj) && !v1.ClusterScanConditionComplete.IsTrue(obj)
+		if v1.ClusterScanConditionCancelled.IsTrue(obj) || (!running && !isQueuedScan(obj)) {
+			return obj, nil
+		}
+		scanCopy := obj.DeepCopy()
+		if running {
+			scanLog(obj).Infof("Cancelling running scan %v", obj.Name)
+			if err := c.teardownScan(obj); err != nil {
+				return obj, err
+			}
+			v1.ClusterScanConditionRunCompleted.True(scanCopy)
+			v1.ClusterScanConditionCancelled.Message(scanCopy, "ClusterScan cancelled while running")
+		} else {
+			scanLog(obj).Infof("Cancelling queued scan %v", obj.Name)
+			v1.ClusterScanConditionCancelled.Message(scanCopy, "ClusterScan cancelled before running")
+		}
+		v1.ClusterScanConditionCancelled.True(scanCopy)
+		v1.ClusterScanConditionPending.False(scanCopy)
+		v1.ClusterScanConditionComplete.True(scanCopy)
+		scanCopy.Status.QueuedAt = ""
+		scanCopy.Status.NextRetryAt = ""
+		scanCopy.Status.Progress = nil
+		scanCopy.Status.ObservedGeneration = scanCopy.Generation
+		c.setClusterScanStatusDisplay(scanCopy)
+		updated, err := scans.UpdateStatus(scanCopy)
+		if err != nil {
+			return obj, fmt.Errorf("error updating condition of cancelled cluster scan object: %v: %w", obj.Name, err)
+		}
+		if running {
+			c.releaseScan(obj.Name)
+		}
+		return updated, nil
+	})
+	return nil
+}
+
+// teardownScan deletes the job of a scan along with its pods, daemonsets and configmaps
+func (c *Controller) teardownScan(scan *v1.ClusterScan) error {
+	propagation := metav1.DeletePropagationBackground
+	err := c.jobs.Delete(v1.ClusterScanNS, name.SafeConcatName("security-scan-runner", scan.Name), &metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("error deleting job of scan %v: %w", scan.Name, err)
+	}
+	if err := c.ensureCleanup(scan); err != nil {
+		return err
+	}
+	err = c.services.Delete(v1.ClusterScanNS, v1.ClusterScanServiceName(scan.Name), &metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("error deleting service of scan %v: %w", scan.Name, err)
+	}
+	return nil
+}
@@ -8,6 +8,8 @@ import (
 
 	v1monitoringclient "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned/typed/monitoring/v1"
 	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -23,13 +25,18 @@ import (
 	"github.com/rancher/wrangler/pkg/start"
 
 	"sync"
+	"sync/atomic"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	cisoperatorapiv1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/attestation"
 	cisoperatorctl "github.com/rancher/cis-operator/pkg/generated/controllers/cis.cattle.io"
 	cisoperatorctlv1 "github.com/rancher/cis-operator/pkg/generated/controllers/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/scanevents"
+	"github.com/rancher/cis-operator/pkg/securityscan/imageverify"
 	"github.com/rancher/cis-operator/pkg/securityscan/scan"
+	"github.com/rancher/cis-operator/pkg/tracing"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -38,7 +45,8 @@ type Controller struct {
 	Name              string
 	ClusterProvider   string
 	KubernetesVersion string
-	ImageConfig       *cisoperatorapiv1.ScanImageConfig
+	// configuration of the flags, the handlers read it with the OperatorConfig applied with getImageConfig
+	ImageConfig *cisoperatorapiv1.ScanImageConfig
 
 	kcs              *kubernetes.Clientset
 	cfg              *rest.Config
@@ -49,8 +57,20 @@ type Controller struct {
 	apply            apply.Apply
 	monitoringClient v1monitoringclient.MonitoringV1Interface
 
-	mu              *sync.Mutex
-	currentScanName string
+	// ImageConfig with the OperatorConfig applied, see getImageConfig
+	imageConfig *atomic.Pointer[cisoperatorapiv1.ScanImageConfig]
+
+	// set once the caches are synced and the handlers started, see CheckCachesSynced
+	synced *atomic.Bool
+
+	mu *sync.Mutex
+	// runs whose results the metric series hold, by scan_name, scan_profile_name and cluster_name, and profile of the
+	// series of the scheduled scans, by scan_name and cluster_name, guarded by metricsMu
+	metricsMu       *sync.Mutex
+	metricsRuns     map[string]scanMetricsRun
+	metricsProfiles map[string]string
+	// scans launched by this controller and not complete yet, guarded by mu
+	launchedScans map[string]bool
 
 	numTestsFailed   *prometheus.GaugeVec
 	numScansComplete *prometheus.CounterVec
@@ -60,20 +80,55 @@ type Controller struct {
 	numTestsPassed   *prometheus.GaugeVec
 	numTestsWarn     *prometheus.GaugeVec
 
-	scans                      cisoperatorctlv1.ClusterScanController
-	jobs                       batchctlv1.JobController
-	configmaps                 corectlv1.ConfigMapController
-	configMapCache             corectlv1.ConfigMapCache
-	services                   corectlv1.ServiceController
-	pods                       corectlv1.PodController
-	podCache                   corectlv1.PodCache
-	daemonsets                 appsctlv1.DaemonSetController
-	daemonsetCache             appsctlv1.DaemonSetCache
-	securityScanJobTolerations []corev1.Toleration
+	numTeamTestsFailed  *prometheus.GaugeVec
+	numTeamTestsTotal   *prometheus.GaugeVec
+	numFailureAgeDays   *prometheus.GaugeVec
+	numCheckMTTRSeconds *prometheus.GaugeVec
+	numDriftedChecks    *prometheus.GaugeVec
+	numRegressions      *prometheus.GaugeVec
+	numPostureDrift     *prometheus.GaugeVec
+	budgetExceeded      *prometheus.GaugeVec
+	trendScore          *prometheus.GaugeVec
+	score               *prometheus.GaugeVec
+	trendNumTestsFailed *prometheus.GaugeVec
+	profileRenames      *prometheus.GaugeVec
+	numAPIRequests      *prometheus.GaugeVec
+	numPods             *prometheus.GaugeVec
+	runDurationSeconds  *prometheus.GaugeVec
+	apiRequestsTotal    *prometheus.CounterVec
+	podsTotal           *prometheus.CounterVec
+
+	deprecatedUsages *prometheus.GaugeVec
+
+	campaignCompletion      *prometheus.GaugeVec
+	campaignRemainingChecks *prometheus.GaugeVec
+
+	numSinkDeliveries    *prometheus.CounterVec
+	sinkDeliveryDuration *prometheus.HistogramVec
+
+	scans          cisoperatorctlv1.ClusterScanController
+	jobs           batchctlv1.JobController
+	configmaps     corectlv1.ConfigMapController
+	configMapCache corectlv1.ConfigMapCache
+	services       corectlv1.ServiceController
+	pods           corectlv1.PodController
+	podCache       corectlv1.PodCache
+	daemonsets     appsctlv1.DaemonSetController
+	daemonsetCache appsctlv1.DaemonSetCache
+	scanPodConfig  *cisoperatorapiv1.ScanPodConfig
+	imageVerifier  *imageverify.Verifier
+	reportSigner   *attestation.Signer
+
+	scanEventExporter *scanevents.Exporter
+	tracer            *tracing.Exporter
+	scanCosts         *scanCosts
+	remediationCache  cisoperatorctlv1.ClusterScanRemediationCache
+	nodes             corectlv1.NodeController
+	fleetClient       dynamic.Interface
 }
 
 func NewController(ctx context.Context, cfg *rest.Config, namespace, name string,
-	imgConfig *cisoperatorapiv1.ScanImageConfig, securityScanJobTolerations []corev1.Toleration) (ctl *Controller, err error) {
+	imgConfig *cisoperatorapiv1.ScanImageConfig, scanPodConfig *cisoperatorapiv1.ScanPodConfig) (ctl *Controller, err error) {
 	if cfg == nil {
 		cfg, err = rest.InClusterConfig()
 		if err != nil {
@@ -81,11 +136,22 @@ func NewController(ctx context.Context, cfg *rest.Config, namespace, name string
 		}
 	}
 	ctl = &Controller{
-		Namespace:   namespace,
-		Name:        name,
-		ImageConfig: imgConfig,
-		mu:          &sync.Mutex{},
+		Namespace:       namespace,
+		Name:            name,
+		ImageConfig:     imgConfig,
+		imageConfig:     &atomic.Pointer[cisoperatorapiv1.ScanImageConfig]{},
+		synced:          &atomic.Bool{},
+		mu:              &sync.Mutex{},
+		launchedScans:   map[string]bool{},
+		metricsMu:       &sync.Mutex{},
+		metricsRuns:     map[string]scanMetricsRun{},
+		metricsProfiles: map[string]string{},
+		scanCosts:       newScanCosts(),
 	}
+	ctl.imageConfig.Store(imgConfig)
+	// the requests of all the clients are accounted to the running scans
+	cfg = rest.CopyConfig(cfg)
+	cfg.Wrap(ctl.scanCosts.wrapTransport)
 
 	ctl.kcs, err = kubernetes.NewForConfig(cfg)
 	if err != nil {
@@ -129,7 +195,8 @@ func NewController(ctx context.Context, cfg *rest.Config, namespace, name string
 		return nil, fmt.Errorf("Error building core NewFactoryFromConfig: %w", err)
 	}
 
-	ctl.appsFactory, err = appsctl.NewFactoryFromConfig(cfg)
+	// the daemonsets of the scans live in the operator namespace, watched with the permissions of its Role
+	ctl.appsFactory, err = appsctl.NewFactoryFromConfigWithOptions(cfg, &appsctl.FactoryOptions{Namespace: cisoperatorapiv1.ClusterScanNS})
 	if err != nil {
 		return nil, fmt.Errorf("Error building apps NewFactoryFromConfig: %w", err)
 	}
@@ -139,6 +206,13 @@ func NewController(ctx context.Context, cfg *rest.Config, namespace, name string
 		return nil, fmt.Errorf("Error building v1 monitoring client from config: %w", err)
 	}
 
+	if imgConfig.FleetHub {
+		ctl.fleetClient, err = dynamic.NewForConfig(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("Error building Fleet client from config: %w", err)
+		}
+	}
+
 	err = initializeMetrics(ctl)
 	if err != nil {
 		return nil, fmt.Errorf("Error registering CIS Metrics: %w", err)
@@ -153,28 +227,161 @@ func NewController(ctx context.Context, cfg *rest.Config, namespace, name string
 	ctl.podCache = ctl.coreFactory.Core().V1().Pod().Cache()
 	ctl.daemonsets = ctl.appsFactory.Apps().V1().DaemonSet()
 	ctl.daemonsetCache = ctl.appsFactory.Apps().V1().DaemonSet().Cache()
-	ctl.securityScanJobTolerations = securityScanJobTolerations
+	ctl.scanPodConfig = scanPodConfig
+	if imgConfig.RemediationEnabled {
+		ctl.remediationCache = ctl.cisFactory.Cis().V1().ClusterScanRemediation().Cache()
+	}
+	ctl.nodes = ctl.coreFactory.Core().V1().Node()
+	// registers the node informer before the factory starts, the scans are scoped and estimated from it
+	ctl.nodes.Cache()
+
+	if imgConfig.AirGapped {
+		logrus.Infof("Running in air-gapped mode, benchmarks are only read from the security-scan image and in-cluster ConfigMaps")
+		if imgConfig.Registry == "" {
+			logrus.Warnf("Air-gapped mode without an image registry override, the scan images must already be present on the nodes")
+		}
+	}
+
+	if imgConfig.ImageVerificationKey != "" {
+		ctl.imageVerifier, err = imageverify.NewVerifier([]byte(imgConfig.ImageVerificationKey))
+		if err != nil {
+			return nil, fmt.Errorf("Error loading image verification key: %w", err)
+		}
+		ctl.imageVerifier.Client = NewHTTPClient(scanPodConfig.Proxy)
+	}
+	if imgConfig.ScanEventLogPath != "" {
+		file, err := scanevents.NewRotatingFile(imgConfig.ScanEventLogPath, int64(imgConfig.ScanEventLogMaxSizeMB)*1024*1024, imgConfig.ScanEventLogMaxBackups)
+		if err != nil {
+			return nil, fmt.Errorf("Error opening scan event log: %w", err)
+		}
+		ctl.scanEventExporter = scanevents.NewExporter(file)
+	}
+	if imgConfig.OTLPEndpoint != "" {
+		ctl.tracer, err = tracing.NewExporter(imgConfig.OTLPEndpoint, imgConfig.OTLPHeaders, imgConfig.ClusterName, NewHTTPClient(scanPodConfig.Proxy))
+		if err != nil {
+			return nil, fmt.Errorf("Error configuring the OTLP trace exporter: %w", err)
+		}
+	}
+	if imgConfig.ReportSigningKey != "" {
+		ctl.reportSigner, err = attestation.NewSigner([]byte(imgConfig.ReportSigningKey))
+		if err != nil {
+			return nil, fmt.Errorf("Error loading report signing key: %w", err)
+		}
+	}
 	return ctl, nil
 }
 
 func (c *Controller) Start(ctx context.Context, threads int, _ time.Duration) error {
+	if c.tracer != nil {
+		go c.tracer.Run(ctx)
+	}
 	// register our handlers
+	if err := c.handleOperatorConfig(ctx); err != nil {
+		return err
+	}
 	if err := c.handleJobs(ctx); err != nil {
 		return err
 	}
 	if err := c.handlePods(ctx); err != nil {
 		return err
 	}
+	if err := c.handleScanCostPods(ctx); err != nil {
+		return err
+	}
 	if err := c.handleClusterScans(ctx); err != nil {
 		return err
 	}
 	if err := c.handleScheduledClusterScans(ctx); err != nil {
 		return err
 	}
+	if err := c.handleClusterScanCancellations(ctx); err != nil {
+		return err
+	}
+	if err := c.handleClusterScanRemovals(ctx); err != nil {
+		return err
+	}
+	if err := c.handleClusterScanTimeouts(ctx); err != nil {
+		return err
+	}
+	if err := c.handleScanJobRecovery(ctx); err != nil {
+		return err
+	}
+	if err := c.handleClusterScanReruns(ctx); err != nil {
+		return err
+	}
+	if err := c.handleClusterScanReportRetention(ctx); err != nil {
+		return err
+	}
 	if err := c.handleClusterScanMetrics(ctx); err != nil {
 		return err
 	}
-	return start.All(ctx, threads, c.cisFactory, c.coreFactory, c.batchFactory)
+	if err := c.handleScanSubscriptions(ctx); err != nil {
+		return err
+	}
+	if err := c.handleDefaultSkips(ctx); err != nil {
+		return err
+	}
+	if err := c.handleDefaultProfiles(ctx); err != nil {
+		return err
+	}
+	if err := c.handleScanEventExport(ctx); err != nil {
+		return err
+	}
+	if err := c.handleScanLifecycleEvents(ctx); err != nil {
+		return err
+	}
+	if err := c.handleCampaigns(ctx); err != nil {
+		return err
+	}
+	if err := c.handleClusterScanTemplates(ctx); err != nil {
+		return err
+	}
+	if err := c.handleDeprecations(ctx); err != nil {
+		return err
+	}
+	if c.getImageConfig().UpgradeScanName != "" && c.ownsName(c.getImageConfig().UpgradeScanName) {
+		go c.watchKubernetesUpgrades(ctx, c.KubernetesVersion)
+	}
+	if err := start.All(ctx, threads, c.cisFactory, c.coreFactory, c.batchFactory, c.appsFactory); err != nil {
+		return err
+	}
+	c.synced.Store(true)
+	return nil
+}
+
+// DefaultClusterScanProfile returns the profile the ClusterScans without one run on this cluster
+func (c *Controller) DefaultClusterScanProfile() (string, error) {
+	return c.getDefaultClusterScanProfile(c.ClusterProvider, c.KubernetesVersion)
+}
+
+// ReportCache returns the cache of the ClusterScanReports, it must be called before Start
+func (c *Controller) ReportCache() cisoperatorctlv1.ClusterScanReportCache {
+	return c.cisFactory.Cis().V1().ClusterScanReport().Cache()
+}
+
+// ProfileCache returns the cache of the ClusterScanProfiles, it must be called before Start
+func (c *Controller) ProfileCache() cisoperatorctlv1.ClusterScanProfileCache {
+	return c.cisFactory.Cis().V1().ClusterScanProfile().Cache()
+}
+
+// BenchmarkCache returns the cache of the ClusterScanBenchmarks, it must be called before Start
+func (c *Controller) BenchmarkCache() cisoperatorctlv1.ClusterScanBenchmarkCache {
+	return c.cisFactory.Cis().V1().ClusterScanBenchmark().Cache()
+}
+
+// ReportShardCache returns the cache of the ClusterScanReportShards, it must be called before Start
+func (c *Controller) ReportShardCache() cisoperatorctlv1.ClusterScanReportShardCache {
+	return c.cisFactory.Cis().V1().ClusterScanReportShard().Cache()
+}
+
+// ScanController returns the controller of the ClusterScans, its cache is started by Start
+func (c *Controller) ScanController() cisoperatorctlv1.ClusterScanController {
+	return c.cisFactory.Cis().V1().ClusterScan()
+}
+
+// KubeClient returns the Kubernetes clientset of the controller
+func (c *Controller) KubeClient() kubernetes.Interface {
+	return c.kcs
 }
 
 func (c *Controller) registerCRD(ctx context.Context) error {
@@ -224,6 +431,67 @@ func detectKubernetesVersion(_ context.Context, k8sClient kubernetes.Interface)
 	return v.GitVersion, nil
 }
 
+func (c *Controller) hasWindowsNodes(ctx context.Context) (bool, error) {
+	nodes, err := c.kcs.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: corev1.LabelOSStable + "=windows",
+		Limit:         1,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(nodes.Items) > 0, nil
+}
+
+// verifyScanImages checks the signatures of the images a scan is about to run, when verification is enabled
+func (c *Controller) verifyScanImages(ctx context.Context, scanWindowsNodes bool) error {
+	if c.imageVerifier == nil {
+		return nil
+	}
+	config := c.getImageConfig()
+	if config.AirGapped && config.Registry == "" {
+		return fmt.Errorf("image signature verification would reach the public registries, which is disabled in air-gapped mode: set an image registry override")
+	}
+	images := []string{
+		config.ImageRef(config.SecurityScanImage, config.SecurityScanImageTag),
+		config.ImageRef(config.SonobuoyImage, config.SonobuoyImageTag),
+	}
+	if scanWindowsNodes && config.WindowsSecurityScanImage != "" {
+		images = append(images, config.ImageRef(config.WindowsSecurityScanImage, config.WindowsSecurityScanImageTag))
+	}
+	for _, image := range images {
+		if err := c.imageVerifier.Verify(ctx, image); err != nil {
+			return err
+		}
+		logrus.Debugf("Verified signature of image %v", image)
+	}
+	return nil
+}
+
+// checkWindowsScan rejects the scans the windows plugin cannot run as specified on the windows nodes in their scope:
+// the extra volumes and the custom benchmark are only mounted in the linux plugin
+func (c *Controller) checkWindowsScan(scan *cisoperatorapiv1.ClusterScan, benchmark *cisoperatorapiv1.ClusterScanBenchmark, scanWindowsNodes bool) error {
+	if !scanWindowsNodes || c.getImageConfig().WindowsSecurityScanImage == "" {
+		return nil
+	}
+	if len(scan.Spec.Volumes) == 0 && benchmark.Spec.CustomBenchmarkConfigMapName == "" {
+		return nil
+	}
+	nodes, err := c.getNodesInScope(scan, true)
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if node.Labels[corev1.LabelOSStable] != "windows" {
+			continue
+		}
+		if len(scan.Spec.Volumes) > 0 {
+			return fmt.Errorf("volumes are not supported on windows nodes, node %v is in scope: restrict the scan with a nodeSelector", node.Name)
+		}
+		return fmt.Errorf("custom benchmark %v is not supported on windows nodes, node %v is in scope: restrict the scan with a nodeSelector", benchmark.Name, node.Name)
+	}
+	return nil
+}
+
 func initializeMetrics(ctl *Controller) error {
 	ctl.numTestsFailed = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -344,5 +612,383 @@ func initializeMetrics(ctl *Controller) error {
 		return err
 	}
 
+	ctl.numTeamTestsFailed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_scan_team_num_tests_fail",
+			Help: "Number of test failed in the CIS scans per owning team, partioned by scan_name, scan_profile_name, team",
+		},
+		[]string{
+			// scan_name will be set to "manual" for on-demand manual scans and the actual name set for the scheduled scans
+			"scan_name",
+			// name of the clusterScanProfile used for scanning
+			"scan_profile_name",
+			// team owning the checks according to the check ownership ConfigMap
+			"team",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.numTeamTestsFailed); err != nil {
+		return err
+	}
+
+	ctl.numTeamTestsTotal = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_scan_team_num_tests_total",
+			Help: "Total Number of tests run in the CIS scans per owning team, partioned by scan_name, scan_profile_name, team",
+		},
+		[]string{
+			// scan_name will be set to "manual" for on-demand manual scans and the actual name set for the scheduled scans
+			"scan_name",
+			// name of the clusterScanProfile used for scanning
+			"scan_profile_name",
+			// team owning the checks according to the check ownership ConfigMap
+			"team",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.numTeamTestsTotal); err != nil {
+		return err
+	}
+
+	ctl.numFailureAgeDays = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_scan_failure_age_days",
+			Help: "Days since the longest failing check of a section started failing, partioned by scan_name, scan_profile_name, section",
+		},
+		[]string{
+			// scan_name will be set to "manual" for on-demand manual scans and the actual name set for the scheduled scans
+			"scan_name",
+			// name of the clusterScanProfile used for scanning
+			"scan_profile_name",
+			// benchmark section of the failing checks, e.g. 1.2
+			"section",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.numFailureAgeDays); err != nil {
+		return err
+	}
+
+	ctl.numCheckMTTRSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_scan_check_mttr_seconds",
+			Help: "Mean time to remediate the failed checks of a section, from their first failure to their first pass, partioned by scan_name, scan_profile_name, section",
+		},
+		[]string{
+			// scan_name will be set to "manual" for on-demand manual scans and the actual name set for the scheduled scans
+			"scan_name",
+			// name of the clusterScanProfile used for scanning
+			"scan_profile_name",
+			// benchmark section of the remediated checks, e.g. 1.2
+			"section",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.numCheckMTTRSeconds); err != nil {
+		return err
+	}
+
+	ctl.numDriftedChecks = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_scan_num_drifted_checks",
+			Help: "Number of checks failing on some nodes of a role but passing on the others, partioned by scan_name, scan_profile_name, node_type",
+		},
+		[]string{
+			// scan_name will be set to "manual" for on-demand manual scans and the actual name set for the scheduled scans
+			"scan_name",
+			// name of the clusterScanProfile used for scanning
+			"scan_profile_name",
+			// role of the drifted nodes: etcd, master or node
+			"node_type",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.numDriftedChecks); err != nil {
+		return err
+	}
+
+	ctl.numRegressions = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_scan_num_regressions",
+			Help: "Number of checks passing in the baseline report of the scan and failing in its last report, partioned by scan_name, scan_profile_name",
+		},
+		[]string{
+			// scan_name will be set to "manual" for on-demand manual scans and the actual name set for the scheduled scans
+			"scan_name",
+			// name of the clusterScanProfile used for scanning
+			"scan_profile_name",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.numRegressions); err != nil {
+		return err
+	}
+
+	ctl.numPostureDrift = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_scan_num_posture_drift",
+			Help: "Number of checks of the last run of a continuous scan drifting from its reference, partioned by scan_name, scan_profile_name",
+		},
+		[]string{
+			// scan_name will be set to "manual" for on-demand manual scans and the actual name set for the scheduled scans
+			"scan_name",
+			// name of the clusterScanProfile used for scanning
+			"scan_profile_name",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.numPostureDrift); err != nil {
+		return err
+	}
+
+	ctl.budgetExceeded = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_scan_regression_budget_exceeded",
+			Help: "1 when more checks newly failed in the last run than the regression budget of the scan allows, 0 otherwise, partioned by scan_name, scan_profile_name",
+		},
+		[]string{
+			// scan_name will be set to "manual" for on-demand manual scans and the actual name set for the scheduled scans
+			"scan_name",
+			// name of the clusterScanProfile used for scanning
+			"scan_profile_name",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.budgetExceeded); err != nil {
+		return err
+	}
+
+	ctl.score = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_scan_score",
+			Help: "Compliance score of the last run, the percentage of the scored checks passing out of those passing or failing, partioned by scan_name, scan_profile_name",
+		},
+		[]string{
+			// scan_name will be set to "manual" for on-demand manual scans and the actual name set for the scheduled scans
+			"scan_name",
+			// name of the clusterScanProfile used for scanning
+			"scan_profile_name",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.score); err != nil {
+		return err
+	}
+
+	ctl.trendScore = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_scan_trend_score",
+			Help: "Percentage of the evaluated checks passing in the runs of the trend window, partioned by scan_name, scan_profile_name, runs_ago",
+		},
+		[]string{
+			// scan_name will be set to "manual" for on-demand manual scans and the actual name set for the scheduled scans
+			"scan_name",
+			// name of the clusterScanProfile used for scanning
+			"scan_profile_name",
+			// position of the run in the trend, 0 for the last run
+			"runs_ago",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.trendScore); err != nil {
+		return err
+	}
+
+	ctl.trendNumTestsFailed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_scan_trend_num_tests_fail",
+			Help: "Number of failed tests in the runs of the trend window, partioned by scan_name, scan_profile_name, runs_ago",
+		},
+		[]string{
+			// scan_name will be set to "manual" for on-demand manual scans and the actual name set for the scheduled scans
+			"scan_name",
+			// name of the clusterScanProfile used for scanning
+			"scan_profile_name",
+			// position of the run in the trend, 0 for the last run
+			"runs_ago",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.trendNumTestsFailed); err != nil {
+		return err
+	}
+
+	ctl.profileRenames = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_scan_profile_renames",
+			Help: "1 for every profile a scheduled scan ran on before its current one, whose series moved to the current one, partioned by scan_name, previous_scan_profile_name, scan_profile_name",
+		},
+		[]string{
+			"scan_name",
+			// name of a clusterScanProfile the scan ran on before
+			"previous_scan_profile_name",
+			// name of the clusterScanProfile of the last run
+			"scan_profile_name",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.profileRenames); err != nil {
+		return err
+	}
+
+	ctl.numAPIRequests = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_scan_api_requests",
+			Help: "Number of API server requests the operator issued for the last run of the scan, partioned by scan_name, scan_profile_name, verb",
+		},
+		[]string{
+			"scan_name",
+			"scan_profile_name",
+			// get, list, watch, create, update, patch, delete or deletecollection
+			"verb",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.numAPIRequests); err != nil {
+		return err
+	}
+
+	ctl.numPods = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_scan_pods",
+			Help: "Number of pods created by the last run of the scan, partioned by scan_name, scan_profile_name",
+		},
+		[]string{
+			"scan_name",
+			"scan_profile_name",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.numPods); err != nil {
+		return err
+	}
+
+	ctl.runDurationSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_scan_duration_seconds",
+			Help: "Time taken by the last run of the scan, from its launch to its completion, partioned by scan_name, scan_profile_name",
+		},
+		[]string{
+			"scan_name",
+			"scan_profile_name",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.runDurationSeconds); err != nil {
+		return err
+	}
+
+	ctl.apiRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cis_scan_api_requests_total",
+			Help: "Number of API server requests the operator issued for the completed runs of the scan, partioned by scan_name, scan_profile_name, verb",
+		},
+		[]string{
+			"scan_name",
+			"scan_profile_name",
+			"verb",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.apiRequestsTotal); err != nil {
+		return err
+	}
+
+	ctl.podsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cis_scan_pods_total",
+			Help: "Number of pods created by the completed runs of the scan, partioned by scan_name, scan_profile_name",
+		},
+		[]string{
+			"scan_name",
+			"scan_profile_name",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.podsTotal); err != nil {
+		return err
+	}
+
+	ctl.numSinkDeliveries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cis_scan_sink_deliveries_total",
+			Help: "Number of scan notifications delivered to the ScanSubscription sinks, partioned by sink, result",
+		},
+		[]string{
+			// name of the ScanSubscription
+			"sink",
+			// success or failure, once all the retries are exhausted
+			"result",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.numSinkDeliveries); err != nil {
+		return err
+	}
+
+	ctl.sinkDeliveryDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "cis_scan_sink_delivery_duration_seconds",
+			Help:    "Time taken to deliver a scan notification to a ScanSubscription sink, retries included, partioned by sink",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 12),
+		},
+		[]string{
+			// name of the ScanSubscription
+			"sink",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.sinkDeliveryDuration); err != nil {
+		return err
+	}
+
+	ctl.deprecatedUsages = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_deprecated_usages",
+			Help: "1 for every deprecated field, profile or benchmark used by a scan, partioned by scan_name, kind, name",
+		},
+		[]string{
+			"scan_name",
+			// field, ClusterScanProfile or ClusterScanBenchmark
+			"kind",
+			// path of the field or name of the profile or benchmark
+			"name",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.deprecatedUsages); err != nil {
+		return err
+	}
+
+	ctl.campaignCompletion = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_campaign_completion_percentage",
+			Help: "Percentage of the target checks of a ClusterScanCampaign passing, partioned by campaign_name",
+		},
+		[]string{
+			// name of the ClusterScanCampaign
+			"campaign_name",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.campaignCompletion); err != nil {
+		return err
+	}
+
+	ctl.campaignRemainingChecks = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cis_campaign_remaining_checks",
+			Help: "Number of the target checks of a ClusterScanCampaign not passing yet, its burn-down, partioned by campaign_name",
+		},
+		[]string{
+			// name of the ClusterScanCampaign
+			"campaign_name",
+			"cluster_name",
+		},
+	)
+	if err := prometheus.Register(ctl.campaignRemainingChecks); err != nil {
+		return err
+	}
+
 	return nil
 }
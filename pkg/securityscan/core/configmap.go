@@ -5,6 +5,7 @@ import (
 	_ "embed" // nolint
 	"encoding/json"
 	"text/template"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,17 +32,21 @@ const (
 	ConfigFileName      = "config.json"
 )
 
-func NewConfigMaps(clusterscan *cisoperatorapiv1.ClusterScan, clusterscanprofile *cisoperatorapiv1.ClusterScanProfile, clusterscanbenchmark *cisoperatorapiv1.ClusterScanBenchmark, _ string, imageConfig *cisoperatorapiv1.ScanImageConfig, configmapsClient wcorev1.ConfigMapController) (cmMap map[string]*corev1.ConfigMap, err error) {
+func NewConfigMaps(clusterscan *cisoperatorapiv1.ClusterScan, clusterscanprofile *cisoperatorapiv1.ClusterScanProfile, clusterscanbenchmark *cisoperatorapiv1.ClusterScanBenchmark, _ string, imageConfig *cisoperatorapiv1.ScanImageConfig, podConfig *cisoperatorapiv1.ScanPodConfig, configmapsClient wcorev1.ConfigMapController, scanWindowsNodes bool) (cmMap map[string]*corev1.ConfigMap, err error) {
 	cmMap = make(map[string]*corev1.ConfigMap)
 
+	// the windows plugin is only added when the cluster has windows nodes and an image to scan them with
+	scanWindowsNodes = scanWindowsNodes && imageConfig.WindowsSecurityScanImage != ""
+
 	configdata := map[string]interface{}{
 		"namespace":        cisoperatorapiv1.ClusterScanNS,
 		"name":             name.SafeConcatName(cisoperatorapiv1.ClusterScanConfigMap, clusterscan.Name),
 		"runName":          name.SafeConcatName("security-scan-runner", clusterscan.Name),
 		"appName":          "rancher-cis-benchmark",
-		"advertiseAddress": cisoperatorapiv1.ClusterScanService,
-		"sonobuoyImage":    imageConfig.SonobuoyImage + ":" + imageConfig.SonobuoyImageTag,
+		"advertiseAddress": cisoperatorapiv1.ClusterScanServiceName(clusterscan.Name),
+		"sonobuoyImage":    imageConfig.ImageRef(imageConfig.SonobuoyImage, imageConfig.SonobuoyImageTag),
 		"sonobuoyVersion":  imageConfig.SonobuoyImageTag,
+		"scanWindowsNodes": scanWindowsNodes,
 	}
 	configcm, err := generateConfigMap(clusterscan, "cisscanConfig.template", cisscanConfigTemplate, configdata)
 	if err != nil {
@@ -62,18 +67,77 @@ func NewConfigMaps(clusterscan *cisoperatorapiv1.ClusterScan, clusterscanprofile
 		customBenchmarkConfigMapName = customcm.Name
 	}
 
+	// tolerations and affinity are rendered as json, which the yaml plugin definition accepts inline. The operator
+	// default tolerations reach the plugins as they do the job, and the affinity is rendered per plugin OS.
+	var tolerations []string
+	for _, toleration := range append(append([]corev1.Toleration{}, podConfig.Tolerations...), clusterscan.Spec.Tolerations...) {
+		t, err := json.Marshal(toleration)
+		if err != nil {
+			return cmMap, err
+		}
+		tolerations = append(tolerations, string(t))
+	}
+	nodeAffinity, err := renderPluginNodeAffinity(getNodeAffinity(clusterscan, podConfig), "linux")
+	if err != nil {
+		return cmMap, err
+	}
+	windowsNodeAffinity, err := renderPluginNodeAffinity(getNodeAffinity(clusterscan, podConfig), "windows")
+	if err != nil {
+		return cmMap, err
+	}
+
+	var resources string
+	if r := getResources(clusterscan, podConfig); r != nil {
+		b, err := json.Marshal(r)
+		if err != nil {
+			return cmMap, err
+		}
+		resources = string(b)
+	}
+
+	// the dns config and host aliases are rendered as json too
+	var dnsConfig, hostAliases string
+	if clusterscan.Spec.DNSConfig != nil {
+		b, err := json.Marshal(clusterscan.Spec.DNSConfig)
+		if err != nil {
+			return cmMap, err
+		}
+		dnsConfig = string(b)
+	}
+	if len(clusterscan.Spec.HostAliases) > 0 {
+		b, err := json.Marshal(clusterscan.Spec.HostAliases)
+		if err != nil {
+			return cmMap, err
+		}
+		hostAliases = string(b)
+	}
+
 	plugindata := map[string]interface{}{
 		"namespace":                    cisoperatorapiv1.ClusterScanNS,
 		"name":                         name.SafeConcatName(cisoperatorapiv1.ClusterScanPluginsConfigMap, clusterscan.Name),
 		"runName":                      name.SafeConcatName("security-scan-runner", clusterscan.Name),
 		"appName":                      "rancher-cis-benchmark",
-		"serviceaccount":               cisoperatorapiv1.ClusterScanSA,
-		"securityScanImage":            imageConfig.SecurityScanImage + ":" + imageConfig.SecurityScanImageTag,
+		"serviceaccount":               getServiceAccountName(clusterscan),
+		"securityScanImage":            imageConfig.ImageRef(imageConfig.SecurityScanImage, imageConfig.SecurityScanImageTag),
 		"benchmarkVersion":             clusterscanprofile.Spec.BenchmarkVersion,
 		"isCustomBenchmark":            isCustomBenchmark,
 		"configDir":                    cisoperatorapiv1.CustomBenchmarkBaseDir,
 		"customBenchmarkConfigMapName": customBenchmarkConfigMapName,
 		"customBenchmarkConfigMapData": customBenchmarkConfigMapData,
+		"scanWindowsNodes":             scanWindowsNodes,
+		"nodeSelector":                 clusterscan.Spec.NodeSelector,
+		"tolerations":                  tolerations,
+		"affinity":                     nodeAffinity,
+		"windowsAffinity":              windowsNodeAffinity,
+		"resources":                    resources,
+		"priorityClassName":            getPriorityClassName(clusterscan, podConfig),
+		"windowsSecurityScanImage":     imageConfig.ImageRef(imageConfig.WindowsSecurityScanImage, imageConfig.WindowsSecurityScanImageTag),
+		"imagePullSecrets":             imageConfig.ImagePullSecrets,
+		"proxyEnv":                     podConfig.Proxy.EnvVars(clusterscan.Name),
+		"volumes":                      getVolumes(clusterscan),
+		"dnsPolicy":                    string(clusterscan.Spec.DNSPolicy),
+		"dnsConfig":                    dnsConfig,
+		"hostAliases":                  hostAliases,
 	}
 	plugincm, err := generateConfigMap(clusterscan, "pluginConfig.template", pluginConfigTemplate, plugindata)
 	if err != nil {
@@ -82,10 +146,10 @@ func NewConfigMaps(clusterscan *cisoperatorapiv1.ClusterScan, clusterscanprofile
 	cmMap["plugincm"] = plugincm
 
 	var skipConfigcm *corev1.ConfigMap
-	if clusterscanprofile.Spec.SkipTests != nil && len(clusterscanprofile.Spec.SkipTests) > 0 {
+	if skipTests := clusterscanprofile.Spec.ActiveSkipTests(time.Now()); len(skipTests) > 0 {
 		//create user skip config map as well
 		// create the cm
-		skipDataBytes, err := getOverrideSkipInfoData(clusterscanprofile.Spec.SkipTests)
+		skipDataBytes, err := getOverrideSkipInfoData(skipTests)
 		if err != nil {
 			return cmMap, err
 		}
@@ -96,6 +160,147 @@ func NewConfigMaps(clusterscan *cisoperatorapiv1.ClusterScan, clusterscanprofile
 	return cmMap, nil
 }
 
+// scanVolume is a host path volume of the scan as rendered in the plugin definition
+type scanVolume struct {
+	Name      string
+	HostPath  string
+	MountPath string
+}
+
+func getVolumes(clusterscan *cisoperatorapiv1.ClusterScan) []scanVolume {
+	var volumes []scanVolume
+	for _, volume := range clusterscan.Spec.Volumes {
+		mountPath := volume.MountPath
+		if mountPath == "" {
+			mountPath = volume.HostPath
+		}
+		volumes = append(volumes, scanVolume{
+			// prefixed not to clash with the volumes of the plugin itself
+			Name:      name.SafeConcatName("scan-volume", volume.Name),
+			HostPath:  volume.HostPath,
+			MountPath: mountPath,
+		})
+	}
+	return volumes
+}
+
+func getNodeAffinity(clusterscan *cisoperatorapiv1.ClusterScan, podConfig *cisoperatorapiv1.ScanPodConfig) *corev1.NodeAffinity {
+	affinity := podConfig.NodeAffinity
+	if clusterscan.Spec.NodeAffinity != nil {
+		affinity = clusterscan.Spec.NodeAffinity
+	}
+	if len(clusterscan.Status.SampledNodes) == 0 {
+		return affinity
+	}
+	return withSampledNodes(affinity, clusterscan.Status.SampledNodes)
+}
+
+// renderPluginNodeAffinity renders the affinity of the plugin of the nodes of an OS, empty if it has none. The
+// requirements on the OS label are dropped, the nodeSelector of each plugin already picks its OS, so that an affinity
+// to e.g. the linux nodes the job runs on does not keep the windows plugin from scheduling.
+func renderPluginNodeAffinity(affinity *corev1.NodeAffinity, os string) (string, error) {
+	affinity = withoutOSRequirements(affinity, os)
+	if affinity == nil {
+		return "", nil
+	}
+	a, err := json.Marshal(corev1.Affinity{NodeAffinity: affinity})
+	if err != nil {
+		return "", err
+	}
+	return string(a), nil
+}
+
+// withoutOSRequirements returns a copy of the affinity without the match expressions on the OS label other than the
+// os, nil if nothing is left. A required term left empty would match no node, it drops the whole required selector
+// since its terms are ORed.
+func withoutOSRequirements(affinity *corev1.NodeAffinity, os string) *corev1.NodeAffinity {
+	if affinity == nil {
+		return nil
+	}
+	affinity = affinity.DeepCopy()
+	if required := affinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+		for i := range required.NodeSelectorTerms {
+			term := &required.NodeSelectorTerms[i]
+			term.MatchExpressions = filterOSRequirements(term.MatchExpressions, os)
+			if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+				affinity.RequiredDuringSchedulingIgnoredDuringExecution = nil
+				break
+			}
+		}
+	}
+	var preferred []corev1.PreferredSchedulingTerm
+	for _, term := range affinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		term.Preference.MatchExpressions = filterOSRequirements(term.Preference.MatchExpressions, os)
+		if len(term.Preference.MatchExpressions) > 0 || len(term.Preference.MatchFields) > 0 {
+			preferred = append(preferred, term)
+		}
+	}
+	affinity.PreferredDuringSchedulingIgnoredDuringExecution = preferred
+	if affinity.RequiredDuringSchedulingIgnoredDuringExecution == nil && len(preferred) == 0 {
+		return nil
+	}
+	return affinity
+}
+
+// filterOSRequirements drops the requirements on the OS label, those only matching the os are kept
+func filterOSRequirements(requirements []corev1.NodeSelectorRequirement, os string) []corev1.NodeSelectorRequirement {
+	var filtered []corev1.NodeSelectorRequirement
+	for _, requirement := range requirements {
+		if requirement.Key == corev1.LabelOSStable && (requirement.Operator != corev1.NodeSelectorOpIn ||
+			len(requirement.Values) != 1 || requirement.Values[0] != os) {
+			continue
+		}
+		filtered = append(filtered, requirement)
+	}
+	return filtered
+}
+
+// withSampledNodes returns a copy of the affinity also requiring the nodes to be among the sampled nodes of a canary
+// scan, in every one of its node selector terms since the terms are ORed
+func withSampledNodes(affinity *corev1.NodeAffinity, sampledNodes []string) *corev1.NodeAffinity {
+	if affinity == nil {
+		affinity = &corev1.NodeAffinity{}
+	} else {
+		affinity = affinity.DeepCopy()
+	}
+	if affinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		affinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	required := affinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(required.NodeSelectorTerms) == 0 {
+		required.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	for i := range required.NodeSelectorTerms {
+		required.NodeSelectorTerms[i].MatchFields = append(required.NodeSelectorTerms[i].MatchFields, corev1.NodeSelectorRequirement{
+			Key:      "metadata.name",
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   sampledNodes,
+		})
+	}
+	return affinity
+}
+
+func getResources(clusterscan *cisoperatorapiv1.ClusterScan, podConfig *cisoperatorapiv1.ScanPodConfig) *corev1.ResourceRequirements {
+	if clusterscan.Spec.Resources != nil {
+		return clusterscan.Spec.Resources
+	}
+	return podConfig.Resources
+}
+
+func getServiceAccountName(clusterscan *cisoperatorapiv1.ClusterScan) string {
+	if clusterscan.Spec.ServiceAccountName != "" {
+		return clusterscan.Spec.ServiceAccountName
+	}
+	return cisoperatorapiv1.ClusterScanSA
+}
+
+func getPriorityClassName(clusterscan *cisoperatorapiv1.ClusterScan, podConfig *cisoperatorapiv1.ScanPodConfig) string {
+	if clusterscan.Spec.PriorityClassName != "" {
+		return clusterscan.Spec.PriorityClassName
+	}
+	return podConfig.PriorityClassName
+}
+
 func generateConfigMap(clusterscan *cisoperatorapiv1.ClusterScan, name string, text string, data map[string]interface{}) (*corev1.ConfigMap, error) {
 	configcm := &corev1.ConfigMap{}
 
@@ -16,7 +16,7 @@ func NewService(clusterscan *cisoperatorapiv1.ClusterScan, _ *cisoperatorapiv1.C
 
 	servicedata := map[string]interface{}{
 		"namespace": cisoperatorapiv1.ClusterScanNS,
-		"name":      cisoperatorapiv1.ClusterScanService,
+		"name":      cisoperatorapiv1.ClusterScanServiceName(clusterscan.Name),
 		"runName":   name.SafeConcatName("security-scan-runner", clusterscan.Name),
 		"appName":   "rancher-cis-benchmark",
 	}
@@ -0,0 +1,68 @@
+package securityscan
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	cisoperatorctlv1 "github.com/rancher/cis-operator/pkg/generated/controllers/cis.cattle.io/v1"
+)
+
+// default profiles ConfigMap events warn about the providers mapped to a ClusterScanProfile that does not exist, the
+// scans without a profile on these clusters would fail to launch
+func (c *Controller) handleDefaultProfiles(ctx context.Context) error {
+	profiles := c.cisFactory.Cis().V1().ClusterScanProfile()
+	configmaps := c.coreFactory.Core().V1().ConfigMap()
+
+	configmaps.OnChange(ctx, c.Name, func(key string, obj *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+		if key != v1.ClusterScanNS+"/"+c.getImageConfig().DefaultProfilesConfigMap || obj == nil || obj.DeletionTimestamp != nil {
+			return obj, nil
+		}
+		if _, ok := obj.Data["default"]; !ok {
+			logrus.Warnf("Default profiles ConfigMap %v has no default entry, the scans without a profile fail on the clusters of the providers it does not map", obj.Name)
+		}
+		return obj, warnMissingDefaultProfiles(profiles.Cache(), "Default profiles ConfigMap "+obj.Name, obj.Data)
+	})
+	return nil
+}
+
+// warnMissingDefaultProfiles warns about the providers of the default profile entries of the source mapped to a
+// ClusterScanProfile that does not exist
+func warnMissingDefaultProfiles(profiles cisoperatorctlv1.ClusterScanProfileCache, source string, entries map[string]string) error {
+	providers := make([]string, 0, len(entries))
+	for provider := range entries {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	for _, provider := range providers {
+		for _, profileName := range getDefaultProfileNames(entries[provider]) {
+			_, err := profiles.Get(profileName)
+			if errors.IsNotFound(err) {
+				logrus.Warnf("%v maps %v to ClusterScanProfile %v, which does not exist", source, provider, profileName)
+			} else if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getDefaultProfileNames returns the profiles of an entry of the default profiles ConfigMap, either a profile name or
+// lines of k8sRange:profile
+func getDefaultProfileNames(entry string) []string {
+	var names []string
+	for _, line := range strings.Split(entry, "\n") {
+		if i := strings.Index(line, ":"); i >= 0 {
+			line = line[i+1:]
+		}
+		if name := strings.TrimSpace(line); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,96 @@
+package securityscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// default skips ConfigMap events resync all the profiles following the defaults, profile events set the
+// defaultSkipTests of the profiles following the defaults to the default skips of their benchmark, and
+// clear them from the profiles no longer following them
+func (c *Controller) handleDefaultSkips(ctx context.Context) error {
+	if c.getImageConfig().DefaultSkipsConfigMap == "" {
+		return nil
+	}
+	profiles := c.cisFactory.Cis().V1().ClusterScanProfile()
+	configmaps := c.coreFactory.Core().V1().ConfigMap()
+
+	configmaps.OnChange(ctx, c.Name, func(key string, obj *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+		if key != v1.ClusterScanNS+"/"+c.getImageConfig().DefaultSkipsConfigMap {
+			return obj, nil
+		}
+		profileList, err := profiles.Cache().List(labels.Everything())
+		if err != nil {
+			return obj, err
+		}
+		for _, profile := range profileList {
+			if profile.Spec.FollowDefaults || len(profile.Spec.DefaultSkipTests) > 0 {
+				profiles.Enqueue(profile.Name)
+			}
+		}
+		return obj, nil
+	})
+
+	profiles.OnChange(ctx, c.Name, func(key string, obj *v1.ClusterScanProfile) (*v1.ClusterScanProfile, error) {
+		if obj == nil || obj.DeletionTimestamp != nil {
+			return obj, nil
+		}
+		if !c.ownsName(obj.Name) {
+			return obj, nil
+		}
+		var defaultSkips []string
+		if obj.Spec.FollowDefaults {
+			skips, err := c.getDefaultSkips()
+			if err != nil {
+				return obj, err
+			}
+			defaultSkips = skips[obj.Spec.BenchmarkVersion]
+		}
+		if reflect.DeepEqual(defaultSkips, obj.Spec.DefaultSkipTests) || (len(defaultSkips) == 0 && len(obj.Spec.DefaultSkipTests) == 0) {
+			return obj, nil
+		}
+		logrus.Infof("Syncing the default skips of ClusterScanProfile %v to %v", obj.Name, defaultSkips)
+		objCopy := obj.DeepCopy()
+		objCopy.Spec.DefaultSkipTests = defaultSkips
+		updated, err := profiles.Update(objCopy)
+		if err != nil {
+			return obj, fmt.Errorf("error syncing the default skips of ClusterScanProfile %v: %w", obj.Name, err)
+		}
+		return updated, nil
+	})
+	return nil
+}
+
+// getDefaultSkips reads the default skips ConfigMap: each key is a benchmark version, each value the check IDs
+// skipped by default for the benchmark, separated by commas or whitespace. A missing ConfigMap skips nothing.
+func (c *Controller) getDefaultSkips() (map[string][]string, error) {
+	cm, err := c.configMapCache.Get(v1.ClusterScanNS, c.getImageConfig().DefaultSkipsConfigMap)
+	if errors.IsNotFound(err) {
+		logrus.Warnf("Default skips ConfigMap %v not found, the profiles following the defaults skip no test by default", c.getImageConfig().DefaultSkipsConfigMap)
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Error %w getting default skips ConfigMap %v", err, c.getImageConfig().DefaultSkipsConfigMap)
+	}
+	skips := map[string][]string{}
+	for benchmark, ids := range cm.Data {
+		seen := map[string]bool{}
+		for _, id := range strings.FieldsFunc(ids, func(r rune) bool { return r == ',' || r == ' ' || r == '\n' || r == '\t' }) {
+			if !seen[id] {
+				seen[id] = true
+				skips[benchmark] = append(skips[benchmark], id)
+			}
+		}
+		sort.Strings(skips[benchmark])
+	}
+	return skips, nil
+}
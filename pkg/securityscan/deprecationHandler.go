@@ -0,0 +1,170 @@
+package securityscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	cisscan "github.com/rancher/cis-operator/pkg/securityscan/scan"
+)
+
+// reason of the Warning condition of the scans using deprecated fields, profiles or benchmarks
+const deprecatedReason = "Deprecated"
+
+// deprecation is a deprecated field, profile or benchmark used by a scan
+type deprecation struct {
+	// field, ClusterScanProfile or ClusterScanBenchmark
+	Kind string
+	// path of the field or name of the object
+	Name    string
+	Message string
+}
+
+// scan events set the Warning condition of the scans using deprecated fields, or profiles and benchmarks annotated
+// with cis.cattle.io/deprecated, and export them as the cis_deprecated_usages metric, so that their usages are found
+// before their removal. Profile and benchmark events enqueue all the scans again.
+func (c *Controller) handleDeprecations(ctx context.Context) error {
+	scans := c.cisFactory.Cis().V1().ClusterScan()
+	profiles := c.cisFactory.Cis().V1().ClusterScanProfile()
+	benchmarks := c.cisFactory.Cis().V1().ClusterScanBenchmark()
+	var lock sync.Mutex
+	exported := map[string][]deprecation{}
+
+	enqueueScans := func() error {
+		scanList, err := scans.Cache().List(labels.Everything())
+		if err != nil {
+			return err
+		}
+		for _, scan := range scanList {
+			scans.Enqueue(scan.Name)
+		}
+		return nil
+	}
+	profiles.OnChange(ctx, c.Name, func(key string, obj *v1.ClusterScanProfile) (*v1.ClusterScanProfile, error) {
+		return obj, enqueueScans()
+	})
+	benchmarks.OnChange(ctx, c.Name, func(key string, obj *v1.ClusterScanBenchmark) (*v1.ClusterScanBenchmark, error) {
+		return obj, enqueueScans()
+	})
+
+	scans.OnChange(ctx, c.Name, func(key string, obj *v1.ClusterScan) (*v1.ClusterScan, error) {
+		var deprecations []deprecation
+		if obj != nil && obj.DeletionTimestamp == nil {
+			if !c.ownsName(obj.Name) {
+				return obj, nil
+			}
+			deprecations = c.getScanDeprecations(obj)
+		}
+
+		lock.Lock()
+		last := exported[key]
+		if len(deprecations) > 0 {
+			exported[key] = deprecations
+		} else {
+			delete(exported, key)
+		}
+		lock.Unlock()
+		if !reflect.DeepEqual(last, deprecations) {
+			c.exportDeprecations(key, last, deprecations)
+		}
+		if obj == nil || obj.DeletionTimestamp != nil {
+			return obj, nil
+		}
+
+		objCopy := obj.DeepCopy()
+		if len(deprecations) > 0 {
+			messages := make([]string, 0, len(deprecations))
+			for _, d := range deprecations {
+				messages = append(messages, d.Message)
+			}
+			v1.ClusterScanConditionWarning.True(objCopy)
+			v1.ClusterScanConditionWarning.Reason(objCopy, deprecatedReason)
+			v1.ClusterScanConditionWarning.Message(objCopy, strings.Join(messages, "; "))
+		} else if v1.ClusterScanConditionWarning.IsTrue(obj) && v1.ClusterScanConditionWarning.GetReason(obj) == deprecatedReason {
+			v1.ClusterScanConditionWarning.False(objCopy)
+			v1.ClusterScanConditionWarning.Reason(objCopy, "")
+			v1.ClusterScanConditionWarning.Message(objCopy, "")
+		}
+		if reflect.DeepEqual(obj.Status, objCopy.Status) {
+			return obj, nil
+		}
+		if len(deprecations) > 0 {
+			logrus.Warnf("ClusterScan %v uses deprecated settings: %v", obj.Name, v1.ClusterScanConditionWarning.GetMessage(objCopy))
+		}
+		return scans.UpdateStatus(objCopy)
+	})
+	return nil
+}
+
+// getScanDeprecations returns the deprecated fields of the scan, and its profiles and their benchmarks annotated
+// with cis.cattle.io/deprecated. The profile of a scan without scanProfileName is the one its last run resolved.
+func (c *Controller) getScanDeprecations(scan *v1.ClusterScan) []deprecation {
+	var deprecations []deprecation
+	if config := scan.Spec.ScheduledScanConfig; config != nil && cisscan.HasTimezonePrefix(config.CronSchedule) {
+		deprecations = append(deprecations, deprecation{
+			Kind:    "field",
+			Name:    "spec.scheduledScanConfig.cronSchedule",
+			Message: "the CRON_TZ= and TZ= prefixes of spec.scheduledScanConfig.cronSchedule are deprecated, set spec.scheduledScanConfig.timezone instead",
+		})
+	}
+
+	profileName := scan.Spec.ScanProfileName
+	if profileName == "" {
+		profileName = scan.Status.LastRunScanProfileName
+	}
+	profileNames := append([]string{profileName}, scan.Spec.AdditionalProfileNames...)
+	seenBenchmarks := map[string]bool{}
+	for _, profileName := range profileNames {
+		if profileName == "" {
+			continue
+		}
+		profile, err := c.cisFactory.Cis().V1().ClusterScanProfile().Cache().Get(profileName)
+		if err != nil {
+			continue
+		}
+		if message, ok := profile.Annotations[v1.DeprecatedAnnotation]; ok {
+			deprecations = append(deprecations, getObjectDeprecation("ClusterScanProfile", profile.Name, message))
+		}
+		if seenBenchmarks[profile.Spec.BenchmarkVersion] {
+			continue
+		}
+		seenBenchmarks[profile.Spec.BenchmarkVersion] = true
+		benchmark, err := c.cisFactory.Cis().V1().ClusterScanBenchmark().Cache().Get(profile.Spec.BenchmarkVersion)
+		if err != nil {
+			continue
+		}
+		if message, ok := benchmark.Annotations[v1.DeprecatedAnnotation]; ok {
+			deprecations = append(deprecations, getObjectDeprecation("ClusterScanBenchmark", benchmark.Name, message))
+		}
+	}
+	return deprecations
+}
+
+func getObjectDeprecation(kind, name, message string) deprecation {
+	d := deprecation{
+		Kind:    kind,
+		Name:    name,
+		Message: fmt.Sprintf("%v %v is deprecated", kind, name),
+	}
+	if message = strings.TrimSpace(message); message != "" {
+		d.Message += ": " + message
+	}
+	return d
+}
+
+// exportDeprecations replaces the cis_deprecated_usages series of a scan
+func (c *Controller) exportDeprecations(scanName string, last, deprecations []deprecation) {
+	clusterName := c.getImageConfig().ClusterName
+	for _, d := range last {
+		c.deprecatedUsages.DeleteLabelValues(scanName, d.Kind, d.Name, clusterName)
+	}
+	for _, d := range deprecations {
+		c.deprecatedUsages.WithLabelValues(scanName, d.Kind, d.Name, clusterName).Set(1)
+	}
+}
@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"sort"
+
+	"github.com/rancher/security-scan/pkg/kb-summarizer/report"
+
+	cisoperatorapiv1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// GetRegressions returns the checks passing in the baseline and failing in the report, from their
+// check states as returned by GetCheckStates.
+func GetRegressions(baseline, current map[string]string) []string {
+	var regressions []string
+	for id, state := range current {
+		if state == string(report.Fail) && baseline[id] == string(report.Pass) {
+			regressions = append(regressions, id)
+		}
+	}
+	sort.Strings(regressions)
+	return regressions
+}
+
+// DiffCheckStates compares the check states of a report, by check ID as returned by GetCheckStates,
+// against those of the previous report.
+func DiffCheckStates(previousReport string, previous, current map[string]string) *cisoperatorapiv1.ClusterScanReportDiff {
+	diff := &cisoperatorapiv1.ClusterScanReportDiff{PreviousReport: previousReport}
+	ids := map[string]bool{}
+	for id := range previous {
+		ids[id] = true
+	}
+	for id := range current {
+		ids[id] = true
+	}
+	fail, pass := string(report.Fail), string(report.Pass)
+	for id := range ids {
+		state, previousState := current[id], previous[id]
+		switch {
+		case state == previousState:
+		case state == fail:
+			diff.NewlyFailing = append(diff.NewlyFailing, id)
+		case state == pass && previousState == fail:
+			diff.NewlyPassing = append(diff.NewlyPassing, id)
+		default:
+			diff.Changed = append(diff.Changed, cisoperatorapiv1.ClusterScanCheckChange{
+				TestID:        id,
+				PreviousState: previousState,
+				State:         state,
+			})
+		}
+	}
+	sort.Strings(diff.NewlyFailing)
+	sort.Strings(diff.NewlyPassing)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return diff.Changed[i].TestID < diff.Changed[j].TestID
+	})
+	return diff
+}
@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"sort"
+
+	"github.com/rancher/security-scan/pkg/kb-summarizer/report"
+
+	cisoperatorapiv1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// GetDrift returns the checks failing on some nodes of a role but passing on the others, e.g. one
+// worker out of fifty failing 4.1.1. The summarizer reports those checks as mixed, listing the
+// failing nodes, and the nodes of every role in the report itself.
+func GetDrift(reportJSON []byte) ([]cisoperatorapiv1.ClusterScanDrift, error) {
+	r, err := report.Get(reportJSON)
+	if err != nil || r == nil {
+		return nil, err
+	}
+	var drift []cisoperatorapiv1.ClusterScanDrift
+	for _, group := range r.Results {
+		for _, check := range group.Checks {
+			if check.State != report.Mixed {
+				continue
+			}
+			failing := map[string]bool{}
+			for _, node := range check.Nodes {
+				failing[node] = true
+			}
+			for _, nodeType := range check.NodeType {
+				roleNodes := r.Nodes[nodeType]
+				var failingNodes []string
+				for _, node := range roleNodes {
+					if failing[node] {
+						failingNodes = append(failingNodes, node)
+					}
+				}
+				if len(failingNodes) == 0 || len(failingNodes) == len(roleNodes) {
+					continue
+				}
+				sort.Strings(failingNodes)
+				drift = append(drift, cisoperatorapiv1.ClusterScanDrift{
+					TestID:       check.Id,
+					NodeType:     string(nodeType),
+					FailingNodes: failingNodes,
+					NodeCount:    len(roleNodes),
+				})
+			}
+		}
+	}
+	return drift, nil
+}
+
+// CountDriftedChecks returns the number of drifted checks per node role.
+func CountDriftedChecks(drift []cisoperatorapiv1.ClusterScanDrift) map[string]int {
+	if len(drift) == 0 {
+		return nil
+	}
+	counts := map[string]int{}
+	for _, d := range drift {
+		counts[d.NodeType]++
+	}
+	return counts
+}
@@ -0,0 +1,240 @@
+// Package engine holds the scan orchestration shared by the operator and the headless Runner:
+// building the workloads that run a scan and reading back their results.
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/rancher/security-scan/pkg/kb-summarizer/report"
+	wcorev1 "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cisoperatorapi "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io"
+	cisoperatorapiv1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	ciscore "github.com/rancher/cis-operator/pkg/securityscan/core"
+	cisjob "github.com/rancher/cis-operator/pkg/securityscan/job"
+)
+
+// ScanConfig is everything needed to build the workloads of a scan.
+type ScanConfig struct {
+	Scan             *cisoperatorapiv1.ClusterScan
+	Profile          *cisoperatorapiv1.ClusterScanProfile
+	Benchmark        *cisoperatorapiv1.ClusterScanBenchmark
+	ControllerName   string
+	ImageConfig      *cisoperatorapiv1.ScanImageConfig
+	PodConfig        *cisoperatorapiv1.ScanPodConfig
+	ScanWindowsNodes bool
+}
+
+// NewScanObjects returns the configmaps, service and job running the scan.
+func NewScanObjects(config *ScanConfig, configmapsClient wcorev1.ConfigMapController) ([]runtime.Object, error) {
+	cmMap, err := ciscore.NewConfigMaps(config.Scan, config.Profile, config.Benchmark, config.ControllerName, config.ImageConfig, config.PodConfig, configmapsClient, config.ScanWindowsNodes)
+	if err != nil {
+		return nil, fmt.Errorf("error when creating ConfigMaps: %w", err)
+	}
+	service, err := ciscore.NewService(config.Scan, config.Profile, config.ControllerName)
+	if err != nil {
+		return nil, fmt.Errorf("error when creating Service: %w", err)
+	}
+	objects := []runtime.Object{
+		cisjob.New(config.Scan, config.Profile, config.Benchmark, config.ControllerName, config.ImageConfig, configmapsClient, config.PodConfig),
+		cmMap["configcm"],
+		cmMap["plugincm"],
+	}
+	if skipConfigcm, ok := cmMap["skipConfigcm"]; ok {
+		objects = append(objects, skipConfigcm)
+	}
+	return append(objects, service), nil
+}
+
+// OutputConfigMapName is the name of the configmap the scan runner writes its results to.
+func OutputConfigMapName(scanName string) string {
+	return strings.Join([]string{`cisscan-output-for`, scanName}, "-")
+}
+
+// SonobuoyWorkerPlugins are the sonobuoy plugins launched as daemonsets by a scan, the windows one only on clusters
+// with windows nodes
+var SonobuoyWorkerPlugins = []string{"rancher-kube-bench", "rancher-kube-bench-windows"}
+
+// GetRunnerPodScan returns the scan of the runner pod owning an object, from the scan label of the pod, empty for the
+// objects without owner or owned by no runner pod. Sonobuoy makes the daemonsets of the plugins owned by the runner
+// pod of their scan.
+func GetRunnerPodScan(owners []metav1.OwnerReference, getPod func(name string) (*corev1.Pod, error)) string {
+	for _, owner := range owners {
+		if owner.Kind != "Pod" {
+			continue
+		}
+		pod, err := getPod(owner.Name)
+		if err != nil || pod.UID != owner.UID {
+			continue
+		}
+		if scanName := pod.Labels[cisoperatorapi.LabelClusterScan]; scanName != "" {
+			return scanName
+		}
+	}
+	return ""
+}
+
+// ScanConfigMapNames returns the names of the configmaps created for a scan, its output included.
+func ScanConfigMapNames(scanName string) map[string]bool {
+	return map[string]bool{
+		name.SafeConcatName(cisoperatorapiv1.ClusterScanConfigMap, scanName):         true,
+		name.SafeConcatName(cisoperatorapiv1.ClusterScanPluginsConfigMap, scanName):  true,
+		name.SafeConcatName(cisoperatorapiv1.ClusterScanUserSkipConfigMap, scanName): true,
+		name.SafeConcatName(cisoperatorapiv1.CustomBenchmarkConfigMap, scanName):     true,
+		OutputConfigMapName(scanName): true,
+	}
+}
+
+// GetSummary parses the summary counts out of the runner output, nil if the output is empty.
+func GetSummary(outputBytes []byte) (*cisoperatorapiv1.ClusterScanSummary, error) {
+	r, err := report.Get(outputBytes)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, nil
+	}
+	return &cisoperatorapiv1.ClusterScanSummary{
+		Total:         r.Total,
+		Pass:          r.Pass,
+		Fail:          r.Fail,
+		Skip:          r.Skip,
+		Warn:          r.Warn,
+		NotApplicable: r.NotApplicable,
+	}, nil
+}
+
+// GetFailedChecks returns the IDs of the checks failed in a report.
+func GetFailedChecks(reportJSON []byte) ([]string, error) {
+	r, err := report.Get(reportJSON)
+	if err != nil || r == nil {
+		return nil, err
+	}
+	var failed []string
+	for _, group := range r.Results {
+		for _, check := range group.Checks {
+			if check.State == report.Fail {
+				failed = append(failed, check.Id)
+			}
+		}
+	}
+	return failed, nil
+}
+
+// GetCheckStates returns the state of every check of a report, by check ID.
+func GetCheckStates(reportJSON []byte) (map[string]string, error) {
+	r, err := report.Get(reportJSON)
+	if err != nil || r == nil {
+		return nil, err
+	}
+	states := map[string]string{}
+	for _, group := range r.Results {
+		for _, check := range group.Checks {
+			states[check.Id] = string(check.State)
+		}
+	}
+	return states, nil
+}
+
+// GetReportJSON returns the report JSON stored in ClusterScanReports from the runner output.
+func GetReportJSON(outputBytes []byte) ([]byte, error) {
+	return report.GetJSONBytes(outputBytes)
+}
+
+// NormalizeReport returns the report JSON with its node lists sorted and without empty ones, the form the
+// shards of a report merge back into, so that the report attestations hold for the merged reports.
+func NormalizeReport(reportJSON []byte) ([]byte, error) {
+	r, err := report.Get(reportJSON)
+	if err != nil || r == nil {
+		return reportJSON, err
+	}
+	sortReportNodes(r)
+	return json.Marshal(r)
+}
+
+func sortReportNodes(r *report.Report) {
+	for nodeType, nodes := range r.Nodes {
+		if len(nodes) == 0 {
+			delete(r.Nodes, nodeType)
+			continue
+		}
+		sort.Strings(nodes)
+	}
+	for _, group := range r.Results {
+		for _, check := range group.Checks {
+			sort.Strings(check.Nodes)
+		}
+	}
+}
+
+// GetComplianceScore returns the compliance score of a report, out of its scored checks passing or failing.
+func GetComplianceScore(reportJSON []byte) (*cisoperatorapiv1.ClusterScanComplianceScore, error) {
+	r, err := report.Get(reportJSON)
+	if err != nil || r == nil {
+		return nil, err
+	}
+	score := &cisoperatorapiv1.ClusterScanComplianceScore{Score: 100}
+	for _, group := range r.Results {
+		for _, check := range group.Checks {
+			if !check.Scored {
+				continue
+			}
+			switch check.State {
+			case report.Pass:
+				score.Passed++
+				score.Total++
+			case report.Fail, report.Mixed:
+				score.Total++
+			}
+		}
+	}
+	if score.Total > 0 {
+		score.Score = math.Round(float64(score.Passed)*10000/float64(score.Total)) / 100
+	}
+	return score, nil
+}
+
+// GetRemediations returns the remediation of the failing checks of a report, the mixed ones included.
+func GetRemediations(reportJSON []byte) ([]cisoperatorapiv1.ClusterScanCheckRemediation, error) {
+	r, err := report.Get(reportJSON)
+	if err != nil || r == nil {
+		return nil, err
+	}
+	var remediations []cisoperatorapiv1.ClusterScanCheckRemediation
+	for _, group := range r.Results {
+		for _, check := range group.Checks {
+			if (check.State != report.Fail && check.State != report.Mixed) || check.Remediation == "" {
+				continue
+			}
+			remediations = append(remediations, cisoperatorapiv1.ClusterScanCheckRemediation{
+				TestID:      check.Id,
+				State:       string(check.State),
+				Description: check.Description,
+				Remediation: check.Remediation,
+			})
+		}
+	}
+	return remediations, nil
+}
+
+// OmitRemediations returns the report JSON without the remediation texts of its checks.
+func OmitRemediations(reportJSON []byte) ([]byte, error) {
+	r, err := report.Get(reportJSON)
+	if err != nil || r == nil {
+		return reportJSON, err
+	}
+	for _, group := range r.Results {
+		for _, check := range group.Checks {
+			check.Remediation = ""
+		}
+	}
+	return json.Marshal(r)
+}
@@ -0,0 +1,91 @@
+package engine
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/rancher/security-scan/pkg/kb-summarizer/report"
+)
+
+// IncludeChecks returns the report JSON limited to the included checks, with its counts recomputed. An
+// included ID matches the check of that ID and the checks of the section of that ID, e.g. 4.2 matches 4.2.6.
+func IncludeChecks(reportJSON []byte, include []string) ([]byte, error) {
+	r, err := report.Get(reportJSON)
+	if err != nil || r == nil {
+		return reportJSON, err
+	}
+	groups := []*report.Group{}
+	for _, group := range r.Results {
+		var checks []*report.Check
+		for _, check := range group.Checks {
+			if IsIncludedCheck(check.Id, include) {
+				checks = append(checks, check)
+			}
+		}
+		if len(checks) > 0 {
+			group.Checks = checks
+			groups = append(groups, group)
+		}
+	}
+	r.Results = groups
+	countChecks(r)
+	return json.Marshal(r)
+}
+
+// SkipChecks returns the report JSON with the checks of the given IDs skipped, with its counts recomputed, as if
+// the node checks had skipped them.
+func SkipChecks(reportJSON []byte, skip []string) ([]byte, error) {
+	if len(skip) == 0 {
+		return reportJSON, nil
+	}
+	r, err := report.Get(reportJSON)
+	if err != nil || r == nil {
+		return reportJSON, err
+	}
+	skipped := map[string]bool{}
+	for _, id := range skip {
+		skipped[id] = true
+	}
+	for _, group := range r.Results {
+		for _, check := range group.Checks {
+			if skipped[check.Id] {
+				check.State = report.Skip
+				check.Nodes = nil
+			}
+		}
+	}
+	countChecks(r)
+	return json.Marshal(r)
+}
+
+// countChecks recomputes the counts of the report from the states of its checks
+func countChecks(r *report.Report) {
+	r.Total, r.Pass, r.Fail, r.Skip, r.Warn, r.NotApplicable = 0, 0, 0, 0, 0, 0
+	for _, group := range r.Results {
+		for _, check := range group.Checks {
+			r.Total++
+			switch check.State {
+			case report.Pass:
+				r.Pass++
+			case report.Fail, report.Mixed:
+				r.Fail++
+			case report.Skip:
+				r.Skip++
+			case report.Warn:
+				r.Warn++
+			case report.NotApplicable:
+				r.NotApplicable++
+			}
+		}
+	}
+}
+
+// IsIncludedCheck returns whether the check ID is one of the included IDs or in one of their sections.
+func IsIncludedCheck(id string, include []string) bool {
+	for _, included := range include {
+		if id == included || strings.HasPrefix(id, included+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"sort"
+
+	"github.com/rancher/security-scan/pkg/kb-summarizer/report"
+)
+
+// GetNodeFailingChecks returns the IDs of the checks failing on every node of the report, an empty list for the nodes
+// passing all the checks. A failing check fails on all the nodes of its roles, a mixed one on the nodes it lists, and
+// a check warning on all the nodes of its roles when failOnWarn is set. The report must not be sharded, or merged with
+// its shards.
+func GetNodeFailingChecks(reportJSON []byte, failOnWarn bool) (map[string][]string, error) {
+	r, err := report.Get(reportJSON)
+	if err != nil || r == nil {
+		return nil, err
+	}
+	failing := map[string][]string{}
+	for _, nodes := range r.Nodes {
+		for _, node := range nodes {
+			failing[node] = []string{}
+		}
+	}
+	for _, group := range r.Results {
+		for _, check := range group.Checks {
+			var nodes []string
+			switch {
+			case check.State == report.Fail || (check.State == report.Warn && failOnWarn):
+				for _, nodeType := range check.NodeType {
+					nodes = append(nodes, r.Nodes[nodeType]...)
+				}
+			case check.State == report.Mixed:
+				nodes = check.Nodes
+			}
+			seen := map[string]bool{}
+			for _, node := range nodes {
+				if seen[node] {
+					continue
+				}
+				seen[node] = true
+				failing[node] = append(failing[node], check.Id)
+			}
+		}
+	}
+	for _, checks := range failing {
+		sort.Strings(checks)
+	}
+	return failing, nil
+}
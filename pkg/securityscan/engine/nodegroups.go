@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"github.com/rancher/security-scan/pkg/kb-summarizer/report"
+
+	cisoperatorapiv1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// SummarizeNodeGroups fills the summary of every node group from a report. A check counts for a
+// group when the group has nodes of a role it applies to, and fails for the group when it fails
+// on one of those nodes: the summarizer lists the failing nodes of the mixed checks.
+func SummarizeNodeGroups(reportJSON []byte, groups []cisoperatorapiv1.ClusterScanNodeGroup) error {
+	r, err := report.Get(reportJSON)
+	if err != nil || r == nil {
+		return err
+	}
+	nodeRoles := map[string]map[report.NodeType]bool{}
+	for nodeType, nodes := range r.Nodes {
+		for _, node := range nodes {
+			if nodeRoles[node] == nil {
+				nodeRoles[node] = map[report.NodeType]bool{}
+			}
+			nodeRoles[node][nodeType] = true
+		}
+	}
+	for i := range groups {
+		summary := cisoperatorapiv1.ClusterScanSummary{}
+		for _, group := range r.Results {
+			for _, check := range group.Checks {
+				groupNodes := checkNodesInGroup(check, groups[i].Nodes, nodeRoles)
+				if len(groupNodes) == 0 {
+					continue
+				}
+				state := check.State
+				if state == report.Mixed || (state == report.Fail && len(check.Nodes) > 0) {
+					state = report.Pass
+					for _, node := range check.Nodes {
+						if groupNodes[node] {
+							state = report.Fail
+							break
+						}
+					}
+				}
+				summary.Total++
+				switch state {
+				case report.Pass:
+					summary.Pass++
+				case report.Fail:
+					summary.Fail++
+				case report.Skip:
+					summary.Skip++
+				case report.Warn:
+					summary.Warn++
+				case report.NotApplicable:
+					summary.NotApplicable++
+				}
+			}
+		}
+		groups[i].Summary = summary
+	}
+	return nil
+}
+
+// checkNodesInGroup returns the nodes of the group having a role the check applies to
+func checkNodesInGroup(check *report.Check, nodes []string, nodeRoles map[string]map[report.NodeType]bool) map[string]bool {
+	inGroup := map[string]bool{}
+	for _, node := range nodes {
+		for _, nodeType := range check.NodeType {
+			if nodeRoles[node][nodeType] {
+				inGroup[node] = true
+				break
+			}
+		}
+	}
+	return inGroup
+}
@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"strings"
+
+	"github.com/rancher/security-scan/pkg/kb-summarizer/report"
+
+	cisoperatorapiv1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// UnownedTeam is the team the checks not mapped to any team are rolled up under.
+const UnownedTeam = "unowned"
+
+// CheckOwners maps check IDs and sections, e.g. "1.2.3" or "1.2", to the team owning them.
+type CheckOwners map[string]string
+
+// ParseCheckOwners reads the ownership ConfigMap data: each key is a team, each value the
+// check IDs and sections owned by the team, separated by commas or whitespace.
+func ParseCheckOwners(data map[string]string) CheckOwners {
+	owners := CheckOwners{}
+	for team, ids := range data {
+		for _, id := range strings.FieldsFunc(ids, func(r rune) bool { return r == ',' || r == ' ' || r == '\n' || r == '\t' }) {
+			owners[strings.TrimSuffix(id, ".")] = team
+		}
+	}
+	return owners
+}
+
+// Team returns the owner of a check, from its ID or the most specific section containing it.
+func (o CheckOwners) Team(checkID string) string {
+	for id := checkID; id != ""; {
+		if team, ok := o[id]; ok {
+			return team
+		}
+		i := strings.LastIndex(id, ".")
+		if i < 0 {
+			break
+		}
+		id = id[:i]
+	}
+	return UnownedTeam
+}
+
+// GetTeamSummaries rolls the checks of a report up per owning team.
+func GetTeamSummaries(reportJSON []byte, owners CheckOwners) (map[string]cisoperatorapiv1.ClusterScanSummary, error) {
+	r, err := report.Get(reportJSON)
+	if err != nil {
+		return nil, err
+	}
+	summaries := map[string]cisoperatorapiv1.ClusterScanSummary{}
+	if r == nil {
+		return summaries, nil
+	}
+	for _, group := range r.Results {
+		for _, check := range group.Checks {
+			team := owners.Team(check.Id)
+			summary := summaries[team]
+			summary.Total++
+			switch check.State {
+			case report.Pass:
+				summary.Pass++
+			case report.Fail:
+				summary.Fail++
+			case report.Skip:
+				summary.Skip++
+			case report.Warn:
+				summary.Warn++
+			case report.NotApplicable:
+				summary.NotApplicable++
+			}
+			summaries[team] = summary
+		}
+	}
+	return summaries, nil
+}
@@ -0,0 +1,217 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	corectl "github.com/rancher/wrangler/pkg/generated/controllers/core"
+	wcorev1 "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/name"
+
+	cisoperatorapi "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io"
+	cisoperatorapiv1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	cisscan "github.com/rancher/cis-operator/pkg/securityscan/scan"
+)
+
+const (
+	// HeadlessControllerName labels the workloads launched by a Runner
+	HeadlessControllerName = "cis-headless"
+
+	defaultPollInterval = 5 * time.Second
+)
+
+// Result is the in-memory outcome of a headless scan.
+type Result struct {
+	Summary    *cisoperatorapiv1.ClusterScanSummary
+	ReportJSON []byte
+}
+
+// Runner runs scans straight against a cluster, without the operator or the cis.cattle.io CRDs.
+// The scan namespace, service account and RBAC are expected to exist, as installed by the chart.
+type Runner struct {
+	ImageConfig  *cisoperatorapiv1.ScanImageConfig
+	PodConfig    *cisoperatorapiv1.ScanPodConfig
+	PollInterval time.Duration
+
+	kcs        kubernetes.Interface
+	configmaps wcorev1.ConfigMapController
+}
+
+// NewRunner builds a Runner for the cluster described by cfg.
+func NewRunner(cfg *rest.Config, imageConfig *cisoperatorapiv1.ScanImageConfig, podConfig *cisoperatorapiv1.ScanPodConfig) (*Runner, error) {
+	kcs, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	coreFactory, err := corectl.NewFactoryFromConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("Error building core NewFactoryFromConfig: %w", err)
+	}
+	if podConfig == nil {
+		podConfig = &cisoperatorapiv1.ScanPodConfig{}
+	}
+	return &Runner{
+		ImageConfig:  imageConfig,
+		PodConfig:    podConfig,
+		PollInterval: defaultPollInterval,
+		kcs:          kcs,
+		configmaps:   coreFactory.Core().V1().ConfigMap(),
+	}, nil
+}
+
+// Run launches the scan, waits for it to finish and returns its results. All the workloads
+// created for the scan are removed before returning, use ctx to bound how long to wait.
+func (r *Runner) Run(ctx context.Context, scan *cisoperatorapiv1.ClusterScan, profile *cisoperatorapiv1.ClusterScanProfile, benchmark *cisoperatorapiv1.ClusterScanBenchmark) (*Result, error) {
+	if scan.Name == "" {
+		return nil, fmt.Errorf("scan name is required")
+	}
+	if err := cisscan.ValidateVolumes(scan.Spec.Volumes, r.PodConfig.HostPathAllowlist); err != nil {
+		return nil, err
+	}
+	defer r.cleanup(scan)
+
+	objects, err := NewScanObjects(&ScanConfig{
+		Scan:           scan,
+		Profile:        profile,
+		Benchmark:      benchmark,
+		ControllerName: HeadlessControllerName,
+		ImageConfig:    r.ImageConfig,
+		PodConfig:      r.PodConfig,
+	}, r.configmaps)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range objects {
+		if err := r.create(ctx, obj); err != nil {
+			return nil, err
+		}
+	}
+
+	logrus.Infof("Waiting for headless scan %v to complete", scan.Name)
+	if err := r.waitForCompletion(ctx, scan.Name); err != nil {
+		return nil, err
+	}
+
+	cm, err := r.kcs.CoreV1().ConfigMaps(cisoperatorapiv1.ClusterScanNS).Get(ctx, OutputConfigMapName(scan.Name), metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching scan output: %w", err)
+	}
+	outputBytes := []byte(cm.Data[cisoperatorapiv1.DefaultScanOutputFileName])
+	summary, err := GetSummary(outputBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error reading scan summary: %w", err)
+	}
+	if summary == nil {
+		return nil, fmt.Errorf("got empty report from scan %v", scan.Name)
+	}
+	reportJSON, err := GetReportJSON(outputBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error reading scan report: %w", err)
+	}
+	return &Result{Summary: summary, ReportJSON: reportJSON}, nil
+}
+
+func (r *Runner) create(ctx context.Context, obj runtime.Object) error {
+	var err error
+	switch o := obj.(type) {
+	case *corev1.ConfigMap:
+		_, err = r.kcs.CoreV1().ConfigMaps(o.Namespace).Create(ctx, o, metav1.CreateOptions{})
+	case *corev1.Service:
+		_, err = r.kcs.CoreV1().Services(o.Namespace).Create(ctx, o, metav1.CreateOptions{})
+	case *batchv1.Job:
+		// there is no ClusterScan object to own the job
+		o.OwnerReferences = nil
+		_, err = r.kcs.BatchV1().Jobs(o.Namespace).Create(ctx, o, metav1.CreateOptions{})
+	default:
+		err = fmt.Errorf("unexpected scan object %T", obj)
+	}
+	return err
+}
+
+// waitForCompletion waits for the runner pod to be marked done by sonobuoy
+func (r *Runner) waitForCompletion(ctx context.Context, scanName string) error {
+	selector := labels.Set{
+		cisoperatorapi.LabelController:  HeadlessControllerName,
+		cisoperatorapi.LabelClusterScan: scanName,
+	}.String()
+	return wait.PollUntilContextCancel(ctx, r.PollInterval, false, func(ctx context.Context) (bool, error) {
+		pods, err := r.kcs.CoreV1().Pods(cisoperatorapiv1.ClusterScanNS).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return false, err
+		}
+		for _, pod := range pods.Items {
+			done, ok := pod.Annotations[cisoperatorapi.SonobuoyCompletionAnnotation]
+			if !ok {
+				continue
+			}
+			if done != "true" {
+				return false, fmt.Errorf("scan %v failed: %v", scanName, done)
+			}
+			return true, nil
+		}
+		job, err := r.kcs.BatchV1().Jobs(cisoperatorapiv1.ClusterScanNS).Get(ctx, name.SafeConcatName("security-scan-runner", scanName), metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		if job.Status.Failed > 0 {
+			return false, fmt.Errorf("scan %v failed: runner job failed", scanName)
+		}
+		return false, nil
+	})
+}
+
+func (r *Runner) cleanup(scan *cisoperatorapiv1.ClusterScan) {
+	ctx := context.Background()
+	ns := cisoperatorapiv1.ClusterScanNS
+	propagation := metav1.DeletePropagationBackground
+	deleteOptions := metav1.DeleteOptions{PropagationPolicy: &propagation}
+
+	if err := r.kcs.BatchV1().Jobs(ns).Delete(ctx, name.SafeConcatName("security-scan-runner", scan.Name), deleteOptions); err != nil && !errors.IsNotFound(err) {
+		logrus.Errorf("Error deleting job of headless scan %v: %v", scan.Name, err)
+	}
+	if err := r.kcs.CoreV1().Services(ns).Delete(ctx, cisoperatorapiv1.ClusterScanServiceName(scan.Name), deleteOptions); err != nil && !errors.IsNotFound(err) {
+		logrus.Errorf("Error deleting service of headless scan %v: %v", scan.Name, err)
+	}
+	// only the daemonsets owned by the runner pod of this scan, the operator may be running others
+	getPod := func(podName string) (*corev1.Pod, error) {
+		return r.kcs.CoreV1().Pods(ns).Get(ctx, podName, metav1.GetOptions{})
+	}
+	if dsList, err := r.kcs.AppsV1().DaemonSets(ns).List(ctx, metav1.ListOptions{}); err != nil {
+		logrus.Errorf("Error listing daemonsets of headless scan %v: %v", scan.Name, err)
+	} else {
+		for _, ds := range dsList.Items {
+			if GetRunnerPodScan(ds.OwnerReferences, getPod) != scan.Name {
+				continue
+			}
+			if err := r.kcs.AppsV1().DaemonSets(ns).Delete(ctx, ds.Name, deleteOptions); err != nil && !errors.IsNotFound(err) {
+				logrus.Errorf("Error deleting daemonset %v: %v", ds.Name, err)
+			}
+		}
+	}
+	cms, err := r.kcs.CoreV1().ConfigMaps(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logrus.Errorf("Error listing configmaps of headless scan %v: %v", scan.Name, err)
+		return
+	}
+	scanConfigMaps := ScanConfigMapNames(scan.Name)
+	for _, cm := range cms.Items {
+		if !scanConfigMaps[cm.Name] {
+			continue
+		}
+		if err := r.kcs.CoreV1().ConfigMaps(ns).Delete(ctx, cm.Name, deleteOptions); err != nil && !errors.IsNotFound(err) {
+			logrus.Errorf("Error deleting configmap %v: %v", cm.Name, err)
+		}
+	}
+}
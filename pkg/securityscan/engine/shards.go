@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/rancher/security-scan/pkg/kb-summarizer/report"
+
+	cisoperatorapiv1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// ShardReport splits the nodes of a report in shards of up to shardSize nodes. It returns the report
+// without any node, whose checks keep their state, and the shards holding the nodes of the report and
+// of its checks. Reports of shardSize nodes or fewer are not sharded, no shard is returned.
+func ShardReport(reportJSON []byte, shardSize int) ([]byte, []cisoperatorapiv1.ClusterScanReportShardSpec, error) {
+	r, err := report.Get(reportJSON)
+	if err != nil || r == nil {
+		return reportJSON, nil, err
+	}
+	nodeSet := map[string]bool{}
+	for _, nodes := range r.Nodes {
+		for _, node := range nodes {
+			nodeSet[node] = true
+		}
+	}
+	if shardSize <= 0 || len(nodeSet) <= shardSize {
+		return reportJSON, nil, nil
+	}
+	allNodes := make([]string, 0, len(nodeSet))
+	for node := range nodeSet {
+		allNodes = append(allNodes, node)
+	}
+	sort.Strings(allNodes)
+
+	shards := make([]cisoperatorapiv1.ClusterScanReportShardSpec, (len(allNodes)+shardSize-1)/shardSize)
+	shardOf := map[string]int{}
+	for i, node := range allNodes {
+		shardOf[node] = i / shardSize
+	}
+	for i := range shards {
+		shards[i] = cisoperatorapiv1.ClusterScanReportShardSpec{
+			Index:      i,
+			Nodes:      map[string][]string{},
+			CheckNodes: map[string][]string{},
+		}
+	}
+	for nodeType, nodes := range r.Nodes {
+		for _, node := range nodes {
+			shard := &shards[shardOf[node]]
+			shard.Nodes[string(nodeType)] = append(shard.Nodes[string(nodeType)], node)
+		}
+	}
+	r.Nodes = nil
+	for _, group := range r.Results {
+		for _, check := range group.Checks {
+			for _, node := range check.Nodes {
+				i, ok := shardOf[node]
+				if !ok {
+					// a node missing from the nodes of the report, keep it with the first shard
+					i = 0
+				}
+				shards[i].CheckNodes[check.Id] = append(shards[i].CheckNodes[check.Id], node)
+			}
+			check.Nodes = nil
+		}
+	}
+	parent, err := json.Marshal(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return parent, shards, nil
+}
+
+// MergeReportShards adds the nodes of the shards back to the report they were split from.
+func MergeReportShards(reportJSON []byte, shards []cisoperatorapiv1.ClusterScanReportShardSpec) ([]byte, error) {
+	r, err := report.Get(reportJSON)
+	if err != nil || r == nil {
+		return reportJSON, err
+	}
+	sort.Slice(shards, func(i, j int) bool {
+		return shards[i].Index < shards[j].Index
+	})
+	if r.Nodes == nil {
+		r.Nodes = map[report.NodeType][]string{}
+	}
+	for _, shard := range shards {
+		for nodeType, nodes := range shard.Nodes {
+			r.Nodes[report.NodeType(nodeType)] = append(r.Nodes[report.NodeType(nodeType)], nodes...)
+		}
+	}
+	for _, group := range r.Results {
+		for _, check := range group.Checks {
+			for _, shard := range shards {
+				check.Nodes = append(check.Nodes, shard.CheckNodes[check.Id]...)
+			}
+		}
+	}
+	sortReportNodes(r)
+	return json.Marshal(r)
+}
+
+// GetShardStatus summarizes a shard for the status of its report.
+func GetShardStatus(name string, shard *cisoperatorapiv1.ClusterScanReportShardSpec) cisoperatorapiv1.ClusterScanReportShardStatus {
+	nodeSet := map[string]bool{}
+	for _, nodes := range shard.Nodes {
+		for _, node := range nodes {
+			nodeSet[node] = true
+		}
+	}
+	return cisoperatorapiv1.ClusterScanReportShardStatus{
+		Name:          name,
+		Index:         shard.Index,
+		NodeCount:     len(nodeSet),
+		FailingChecks: len(shard.CheckNodes),
+	}
+}
@@ -0,0 +1,244 @@
+package engine
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rancher/security-scan/pkg/kb-summarizer/report"
+
+	cisoperatorapiv1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// StaticPods holds the command lines of the static control plane pods, by component then by node name.
+type StaticPods map[string]map[string][]string
+
+// StaticPodComponents are the control plane components whose checks can be evaluated against their static pods.
+var StaticPodComponents = []string{"kube-apiserver", "kube-controller-manager", "kube-scheduler", "etcd"}
+
+// the benchmark variables the audits of the components are written with
+var componentVariables = map[string]string{
+	"$apiserverbin":         "kube-apiserver",
+	"$controllermanagerbin": "kube-controller-manager",
+	"$schedulerbin":         "kube-scheduler",
+	"$etcdbin":              "etcd",
+}
+
+// the audits reading the command line of a process, e.g. /bin/ps -ef | grep kube-apiserver | grep -v grep
+// or /bin/ps -fC etcd
+var processAuditRegexp = regexp.MustCompile(`\bps\s.*?(?:grep|-[a-zA-Z]*C)\s+(\$?[\w-]+)`)
+
+type flagTest struct {
+	flag  string
+	op    string
+	value string
+}
+
+// the expected results of kube-bench, per operator
+var expectedResultRegexps = []struct {
+	op     string
+	regexp *regexp.Regexp
+}{
+	{"eq", regexp.MustCompile(`^'(--[^']+)' is equal to '(.*)'$`)},
+	{"noteq", regexp.MustCompile(`^'(--[^']+)' is not equal to '(.*)'$`)},
+	{"gte", regexp.MustCompile(`^'(--[^']+)' is greater or equal to (\S+)$`)},
+	{"gt", regexp.MustCompile(`^'(--[^']+)' is greater than (\S+)$`)},
+	{"lte", regexp.MustCompile(`^'(--[^']+)' is lower or equal to (\S+)$`)},
+	{"lt", regexp.MustCompile(`^'(--[^']+)' is lower than (\S+)$`)},
+	{"has", regexp.MustCompile(`^'(--[^']+)' has '(.*)'$`)},
+	{"nothas", regexp.MustCompile(`^'(--[^']+)' does not have '(.*)'$`)},
+	{"set", regexp.MustCompile(`^'(--[^']+)' is present$`)},
+	{"notset", regexp.MustCompile(`^'(--[^']+)' is not present$`)},
+}
+
+// EvaluateStaticPods evaluates again, against the command lines of the static pods, the control plane checks of
+// a report that did not pass on the nodes, e.g. because the node workers could not mount the host. Only the checks
+// auditing the flags of a component process are evaluated, the others keep the state found on the nodes. It returns
+// the report JSON with the states and counts updated, and the evaluation method of every check by check ID.
+func EvaluateStaticPods(reportJSON []byte, pods StaticPods) ([]byte, map[string]string, error) {
+	r, err := report.Get(reportJSON)
+	if err != nil || r == nil {
+		return reportJSON, nil, err
+	}
+	methods := map[string]string{}
+	for _, group := range r.Results {
+		for _, check := range group.Checks {
+			methods[check.Id] = cisoperatorapiv1.CheckEvaluationHost
+			if check.State != report.Fail && check.State != report.Warn && check.State != report.Mixed {
+				continue
+			}
+			component := getAuditComponent(check.Audit)
+			if component == "" || len(pods[component]) == 0 {
+				continue
+			}
+			tests, and, ok := parseExpectedResult(check.ExpectedResult)
+			if !ok {
+				continue
+			}
+			var failing []string
+			for node, args := range pods[component] {
+				if !evaluateFlagTests(tests, and, parseFlags(args)) {
+					failing = append(failing, node)
+				}
+			}
+			sort.Strings(failing)
+			state := report.Mixed
+			switch len(failing) {
+			case 0:
+				state = report.Pass
+			case len(pods[component]):
+				state = report.Fail
+			}
+			updateStateCount(r, check.State, -1)
+			updateStateCount(r, state, 1)
+			check.State = state
+			check.Nodes = failing
+			methods[check.Id] = cisoperatorapiv1.CheckEvaluationStaticPodAPI
+		}
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, methods, nil
+}
+
+// getAuditComponent returns the control plane component whose process command line the audit reads, empty if none
+func getAuditComponent(audit string) string {
+	match := processAuditRegexp.FindStringSubmatch(audit)
+	if match == nil {
+		return ""
+	}
+	if component, ok := componentVariables[match[1]]; ok {
+		return component
+	}
+	for _, component := range StaticPodComponents {
+		if match[1] == component {
+			return component
+		}
+	}
+	return ""
+}
+
+// parseExpectedResult parses the expected result of a check into its flag tests, and whether all of them or
+// any must pass. It returns false when a test is not about a flag, or the tests are both and'ed and or'ed.
+func parseExpectedResult(expected string) ([]flagTest, bool, bool) {
+	and := !strings.Contains(expected, " OR ")
+	if !and && strings.Contains(expected, " AND ") {
+		return nil, false, false
+	}
+	separator := " AND "
+	if !and {
+		separator = " OR "
+	}
+	var tests []flagTest
+	for _, part := range strings.Split(expected, separator) {
+		test, ok := parseFlagTest(strings.TrimSpace(part))
+		if !ok {
+			return nil, false, false
+		}
+		tests = append(tests, test)
+	}
+	return tests, and, len(tests) > 0
+}
+
+func parseFlagTest(expected string) (flagTest, bool) {
+	for _, e := range expectedResultRegexps {
+		match := e.regexp.FindStringSubmatch(expected)
+		if match == nil {
+			continue
+		}
+		test := flagTest{flag: match[1], op: e.op}
+		if len(match) > 2 {
+			test.value = match[2]
+		}
+		return test, true
+	}
+	return flagTest{}, false
+}
+
+// parseFlags returns the flags of a command line by name, the flags without value are set to true
+func parseFlags(args []string) map[string]string {
+	flags := map[string]string{}
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "--") {
+			continue
+		}
+		if flag, value, ok := strings.Cut(args[i], "="); ok {
+			flags[flag] = value
+			continue
+		}
+		if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			flags[args[i]] = args[i+1]
+			i++
+			continue
+		}
+		flags[args[i]] = "true"
+	}
+	return flags
+}
+
+func evaluateFlagTests(tests []flagTest, and bool, flags map[string]string) bool {
+	for _, test := range tests {
+		if evaluateFlagTest(test, flags) != and {
+			return !and
+		}
+	}
+	return and
+}
+
+// evaluateFlagTest compares a flag as kube-bench does, every test but notset requires the flag to be set
+func evaluateFlagTest(test flagTest, flags map[string]string) bool {
+	value, set := flags[test.flag]
+	if test.op == "notset" {
+		return !set
+	}
+	if !set {
+		return false
+	}
+	switch test.op {
+	case "set":
+		return true
+	case "eq":
+		return value == test.value
+	case "noteq":
+		return value != test.value
+	case "has":
+		return strings.Contains(value, test.value)
+	case "nothas":
+		return !strings.Contains(value, test.value)
+	}
+	actual, err := strconv.Atoi(value)
+	if err != nil {
+		return false
+	}
+	expected, err := strconv.Atoi(test.value)
+	if err != nil {
+		return false
+	}
+	switch test.op {
+	case "gt":
+		return actual > expected
+	case "gte":
+		return actual >= expected
+	case "lt":
+		return actual < expected
+	case "lte":
+		return actual <= expected
+	}
+	return false
+}
+
+// updateStateCount adds delta to the count of the report the state is counted in, the mixed checks are failing
+func updateStateCount(r *report.Report, state report.State, delta int) {
+	switch state {
+	case report.Pass:
+		r.Pass += delta
+	case report.Fail, report.Mixed:
+		r.Fail += delta
+	case report.Warn:
+		r.Warn += delta
+	}
+}
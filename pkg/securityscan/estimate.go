@@ -0,0 +1,75 @@
+package securityscan
+
+import (
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+const (
+	// used until there are reports to learn from
+	defaultScanBaseSeconds    = 60
+	defaultScanSecondsPerNode = 15
+	// number of recent reports the estimate is averaged over
+	scanEstimateHistory = 5
+)
+
+// countNodesInScope returns the number of nodes the scan will run on: its sampled nodes, or the nodes in its scope
+func (c *Controller) countNodesInScope(scan *v1.ClusterScan, scanWindowsNodes bool) (int, error) {
+	if len(scan.Status.SampledNodes) > 0 {
+		return len(scan.Status.SampledNodes), nil
+	}
+	nodes, err := c.getNodesInScope(scan, scanWindowsNodes)
+	if err != nil {
+		return 0, err
+	}
+	return len(nodes), nil
+}
+
+// estimateScanDuration estimates how long a scan of nodeCount nodes will take from the
+// duration of the latest reports of the same benchmark, scaled by their node counts.
+func (c *Controller) estimateScanDuration(benchmarkVersion string, nodeCount int) (time.Duration, error) {
+	reports, err := c.cisFactory.Cis().V1().ClusterScanReport().Cache().List(labels.Everything())
+	if err != nil {
+		return 0, err
+	}
+	var history []*v1.ClusterScanReport
+	for _, report := range reports {
+		if report.Spec.BenchmarkVersion == benchmarkVersion && report.Spec.DurationSeconds > 0 && report.Spec.NodeCount > 0 {
+			history = append(history, report)
+		}
+	}
+	if len(history) == 0 || nodeCount == 0 {
+		return time.Duration(defaultScanBaseSeconds+defaultScanSecondsPerNode*nodeCount) * time.Second, nil
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].CreationTimestamp.After(history[j].CreationTimestamp.Time)
+	})
+	if len(history) > scanEstimateHistory {
+		history = history[:scanEstimateHistory]
+	}
+	var secondsPerNode float64
+	for _, report := range history {
+		secondsPerNode += float64(report.Spec.DurationSeconds) / float64(report.Spec.NodeCount)
+	}
+	secondsPerNode /= float64(len(history))
+	return time.Duration(secondsPerNode*float64(nodeCount)) * time.Second, nil
+}
+
+// setScanEstimate publishes the expected duration and completion time of a scan launched now
+func (c *Controller) setScanEstimate(scan *v1.ClusterScan, benchmarkVersion string, scanWindowsNodes bool) error {
+	nodeCount, err := c.countNodesInScope(scan, scanWindowsNodes)
+	if err != nil {
+		return err
+	}
+	estimate, err := c.estimateScanDuration(benchmarkVersion, nodeCount)
+	if err != nil {
+		return err
+	}
+	scan.Status.EstimatedDurationSeconds = int64(estimate.Seconds())
+	scan.Status.EstimatedCompletionTimestamp = time.Now().Add(estimate).Round(time.Second).Format(time.RFC3339)
+	return nil
+}
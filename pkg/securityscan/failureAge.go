@@ -0,0 +1,113 @@
+package securityscan
+
+import (
+	"strings"
+	"time"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+)
+
+// updateFailingChecks returns the checks failed in the last report with the time they started failing:
+// checks failing since a previous run keep their start time, the others start failing now
+func updateFailingChecks(previous map[string]string, failed []string, now time.Time) map[string]string {
+	failing := make(map[string]string, len(failed))
+	for _, id := range failed {
+		if since, ok := previous[id]; ok {
+			failing[id] = since
+		} else {
+			failing[id] = now.Round(time.Second).Format(time.RFC3339)
+		}
+	}
+	return failing
+}
+
+// keepExcludedFailingChecks keeps the checks a partial scan left out failing since when they did
+func keepExcludedFailingChecks(failing, previous map[string]string, include []string) {
+	for id, since := range previous {
+		if !engine.IsIncludedCheck(id, include) {
+			failing[id] = since
+		}
+	}
+}
+
+// updateRemediatedChecks records the remediation of the checks failing in the previous report and passing in the last one
+func updateRemediatedChecks(remediated map[string]v1.CheckRemediation, previous map[string]string, states map[string]string, now time.Time) map[string]v1.CheckRemediation {
+	updated := make(map[string]v1.CheckRemediation, len(remediated))
+	for id, remediation := range remediated {
+		updated[id] = remediation
+	}
+	for id, since := range previous {
+		if states[id] != "pass" {
+			continue
+		}
+		sinceTime, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			continue
+		}
+		remediation := updated[id]
+		remediation.Count++
+		remediation.TotalSeconds += int64(now.Sub(sinceTime).Seconds())
+		remediation.LastFailedAt = since
+		remediation.LastRemediatedAt = now.Round(time.Second).Format(time.RFC3339)
+		updated[id] = remediation
+	}
+	return updated
+}
+
+// getMTTRBySection returns the mean time to remediate the checks of every section, in seconds
+func getMTTRBySection(remediated map[string]v1.CheckRemediation) map[string]float64 {
+	counts := map[string]int{}
+	totals := map[string]int64{}
+	for id, remediation := range remediated {
+		section := getCheckSection(id)
+		counts[section] += remediation.Count
+		totals[section] += remediation.TotalSeconds
+	}
+	mttr := map[string]float64{}
+	for section, count := range counts {
+		if count > 0 {
+			mttr[section] = float64(totals[section]) / float64(count)
+		}
+	}
+	return mttr
+}
+
+// getCheckSection returns the benchmark section of a check, e.g. 1.2 for 1.2.3
+func getCheckSection(id string) string {
+	if i := strings.LastIndex(id, "."); i > 0 {
+		return id[:i]
+	}
+	return id
+}
+
+func getFailureAgeDays(since string, now time.Time) float64 {
+	sinceTime, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return 0
+	}
+	return now.Sub(sinceTime).Hours() / 24
+}
+
+// getFailureAgeBySection returns the age in days of the longest failing check of every section
+func getFailureAgeBySection(failing map[string]string, now time.Time) map[string]float64 {
+	ages := map[string]float64{}
+	for id, since := range failing {
+		section := getCheckSection(id)
+		if days := getFailureAgeDays(since, now); days >= ages[section] {
+			ages[section] = days
+		}
+	}
+	return ages
+}
+
+// getAgingFailures returns the checks failing for at least minDays, with their age in whole days
+func getAgingFailures(failing map[string]string, minDays int, now time.Time) map[string]int {
+	aging := map[string]int{}
+	for id, since := range failing {
+		if days := int(getFailureAgeDays(since, now)); days >= minDays {
+			aging[id] = days
+		}
+	}
+	return aging
+}
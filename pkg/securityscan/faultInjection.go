@@ -0,0 +1,28 @@
+package securityscan
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// errInjectedFault is the error of the operations failed by the fault injection
+var errInjectedFault = errors.New("injected fault")
+
+// injectFault delays the operation by a random duration of up to FaultInjectionMaxDelay, then fails it at
+// FaultInjectionFailureRate. It is meant for test environments, to exercise the retries of the results and the sink
+// deliveries continuously, and injects nothing by default.
+func (c *Controller) injectFault(operation string) error {
+	config := c.getImageConfig()
+	if config.FaultInjectionMaxDelay > 0 {
+		time.Sleep(time.Duration(rand.Int63n(int64(config.FaultInjectionMaxDelay))))
+	}
+	if config.FaultInjectionFailureRate > 0 && rand.Float64() < config.FaultInjectionFailureRate {
+		logrus.Warnf("Fault injection: failing %v", operation)
+		return fmt.Errorf("%w: %v", errInjectedFault, operation)
+	}
+	return nil
+}
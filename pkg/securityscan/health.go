@@ -0,0 +1,54 @@
+package securityscan
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rancher/cis-operator/pkg/crds"
+)
+
+// errCachesNotSynced is the error of the readiness check until the caches of the controller are synced
+var errCachesNotSynced = errors.New("informer caches not synced yet")
+
+// CheckCachesSynced returns an error until the caches of the controller are synced and its handlers started
+func (c *Controller) CheckCachesSynced() error {
+	if !c.synced.Load() {
+		return errCachesNotSynced
+	}
+	return nil
+}
+
+// CheckCRDs returns an error when the API server does not serve all the CRDs of the operator, e.g. when they are not
+// installed or not established yet
+func (c *Controller) CheckCRDs() error {
+	// kinds served by group version
+	served := map[string]map[string]bool{}
+	missing := map[string]bool{}
+	for _, crd := range crds.List() {
+		groupVersion := crd.GVK.GroupVersion().String()
+		if served[groupVersion] == nil {
+			resources, err := c.kcs.Discovery().ServerResourcesForGroupVersion(groupVersion)
+			if err != nil {
+				return fmt.Errorf("error discovering the resources of %v: %w", groupVersion, err)
+			}
+			served[groupVersion] = map[string]bool{}
+			for _, resource := range resources.APIResources {
+				served[groupVersion][resource.Kind] = true
+			}
+		}
+		if !served[groupVersion][crd.GVK.Kind] {
+			missing[crd.GVK.Kind] = true
+		}
+	}
+	if len(missing) > 0 {
+		kinds := make([]string, 0, len(missing))
+		for kind := range missing {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		return fmt.Errorf("CRDs not served: %v", strings.Join(kinds, ", "))
+	}
+	return nil
+}
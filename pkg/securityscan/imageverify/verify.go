@@ -0,0 +1,260 @@
+// Package imageverify checks the cosign signatures of the scan images before they are run.
+// It supports key-based signatures stored in the image registry under the cosign
+// "sha256-<digest>.sig" tag convention, and requires images to be pinned by digest.
+package imageverify
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/rancher/cis-operator/pkg/fips"
+)
+
+const (
+	signatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	dockerHubRegistry = "registry-1.docker.io"
+	maxBlobSize       = 1 << 20
+)
+
+// ErrVerification is returned when an image has no signature valid for the configured key.
+var ErrVerification = errors.New("image signature verification failed")
+
+// Verifier verifies cosign signatures with a public key.
+type Verifier struct {
+	PublicKey crypto.PublicKey
+	Client    *http.Client
+}
+
+// NewVerifier builds a Verifier from a PEM encoded public key, as generated by cosign generate-key-pair.
+func NewVerifier(publicKeyPEM []byte) (*Verifier, error) {
+	block, _ := pem.Decode(publicKeyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in public key")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %w", err)
+	}
+	if err := fips.CheckPublicKey(key); err != nil {
+		return nil, err
+	}
+	return &Verifier{PublicKey: key, Client: http.DefaultClient}, nil
+}
+
+// Verify checks that the image ref, in the image@sha256:digest form, carries a signature valid for the key.
+func (v *Verifier) Verify(ctx context.Context, ref string) error {
+	img, err := parseReference(ref)
+	if err != nil {
+		return err
+	}
+	sigTag := strings.Replace(img.digest, ":", "-", 1) + ".sig"
+	var sigManifest struct {
+		Layers []struct {
+			Digest      string            `json:"digest"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"layers"`
+	}
+	if err := v.getJSON(ctx, img, "manifests/"+sigTag, &sigManifest); err != nil {
+		return fmt.Errorf("error fetching signatures of %v: %w", ref, err)
+	}
+	for _, layer := range sigManifest.Layers {
+		signature, ok := layer.Annotations[signatureAnnotation]
+		if !ok {
+			continue
+		}
+		payload, err := v.getBlob(ctx, img, layer.Digest)
+		if err != nil {
+			return fmt.Errorf("error fetching signature payload of %v: %w", ref, err)
+		}
+		if err := v.verifyPayload(img.digest, payload, signature); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: no valid signature found for %v", ErrVerification, ref)
+}
+
+func (v *Verifier) verifyPayload(digest string, payload []byte, signature string) error {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(payload)
+	switch key := v.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, hash[:], sig) {
+			return ErrVerification
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], sig); err != nil {
+			return ErrVerification
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, payload, sig) {
+			return ErrVerification
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+	// the signed payload must be about this very image, not any image signed with the same key
+	var simpleSigning struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+		} `json:"critical"`
+	}
+	if err := json.Unmarshal(payload, &simpleSigning); err != nil {
+		return err
+	}
+	if simpleSigning.Critical.Image.DockerManifestDigest != digest {
+		return ErrVerification
+	}
+	return nil
+}
+
+type reference struct {
+	registry   string
+	repository string
+	digest     string
+}
+
+func parseReference(ref string) (*reference, error) {
+	name, digest, ok := strings.Cut(ref, "@")
+	if !ok || !strings.HasPrefix(digest, "sha256:") {
+		return nil, fmt.Errorf("image %v is not pinned by digest", ref)
+	}
+	img := &reference{registry: dockerHubRegistry, repository: name, digest: digest}
+	if i := strings.Index(name, "/"); i > 0 {
+		if host := name[:i]; host == "localhost" || strings.ContainsAny(host, ".:") {
+			img.registry = host
+			img.repository = name[i+1:]
+		}
+	}
+	if img.registry == "docker.io" {
+		img.registry = dockerHubRegistry
+	}
+	// a tag is ignored once pinned by digest
+	if i := strings.LastIndex(img.repository, ":"); i > strings.LastIndex(img.repository, "/") {
+		img.repository = img.repository[:i]
+	}
+	if img.registry == dockerHubRegistry && !strings.Contains(img.repository, "/") {
+		img.repository = "library/" + img.repository
+	}
+	return img, nil
+}
+
+func (v *Verifier) getJSON(ctx context.Context, img *reference, path string, out interface{}) error {
+	body, err := v.get(ctx, img, path, "application/vnd.oci.image.manifest.v1+json, application/vnd.docker.distribution.manifest.v2+json")
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, out)
+}
+
+func (v *Verifier) getBlob(ctx context.Context, img *reference, digest string) ([]byte, error) {
+	body, err := v.get(ctx, img, "blobs/"+digest, "")
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(body)
+	if "sha256:"+hex.EncodeToString(hash[:]) != digest {
+		return nil, fmt.Errorf("digest mismatch for blob %v", digest)
+	}
+	return body, nil
+}
+
+// get issues a registry API request, going through the anonymous bearer token flow when challenged
+func (v *Verifier) get(ctx context.Context, img *reference, path, accept string) ([]byte, error) {
+	endpoint := fmt.Sprintf("https://%s/v2/%s/%s", img.registry, img.repository, path)
+	resp, err := v.do(ctx, endpoint, accept, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		token, err := v.token(ctx, challenge)
+		if err != nil {
+			return nil, err
+		}
+		resp, err = v.do(ctx, endpoint, accept, token)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %v: unexpected status %v", endpoint, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxBlobSize))
+}
+
+func (v *Verifier) do(ctx context.Context, endpoint, accept, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return v.Client.Do(req)
+}
+
+func (v *Verifier) token(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported registry authentication %q", scheme)
+	}
+	values := url.Values{}
+	var realm string
+	for _, param := range strings.Split(params, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		value = strings.Trim(value, `"`)
+		if key == "realm" {
+			realm = value
+		} else {
+			values.Set(key, value)
+		}
+	}
+	if realm == "" {
+		return "", fmt.Errorf("no realm in registry challenge %q", challenge)
+	}
+	resp, err := v.do(ctx, realm+"?"+values.Encode(), "", "")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error fetching registry token: %v", resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	return token.AccessToken, nil
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	batchv1 "k8s.io/api/batch/v1"
@@ -23,6 +24,9 @@ const (
 	defaultTerminationGracePeriodSeconds = int64(0)
 	defaultBackoffLimit                  = int32(0)
 	defaultTTLSecondsAfterFinished       = int32(0)
+
+	// non-root user the aggregator runs as by default
+	defaultAggregatorUser = int64(1000)
 )
 
 var (
@@ -51,8 +55,16 @@ func readFromEnv(key string, defaultValue int32) int32 {
 }
 
 func New(clusterscan *cisoperatorapiv1.ClusterScan, clusterscanprofile *cisoperatorapiv1.ClusterScanProfile, clusterscanbenchmark *cisoperatorapiv1.ClusterScanBenchmark,
-	controllerName string, imageConfig *cisoperatorapiv1.ScanImageConfig, configmapsClient wcorev1.ConfigMapController, tolerations []corev1.Toleration) *batchv1.Job {
-	privileged := true
+	controllerName string, imageConfig *cisoperatorapiv1.ScanImageConfig, configmapsClient wcorev1.ConfigMapController, podConfig *cisoperatorapiv1.ScanPodConfig) *batchv1.Job {
+	tolerations := append(append([]corev1.Toleration{}, podConfig.Tolerations...), clusterscan.Spec.Tolerations...)
+	nodeAffinity := podConfig.NodeAffinity
+	if clusterscan.Spec.NodeAffinity != nil {
+		nodeAffinity = clusterscan.Spec.NodeAffinity
+	}
+	topologySpreadConstraints := podConfig.TopologySpreadConstraints
+	if len(clusterscan.Spec.TopologySpreadConstraints) > 0 {
+		topologySpreadConstraints = clusterscan.Spec.TopologySpreadConstraints
+	}
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name.SafeConcatName("security-scan-runner", clusterscan.Name),
@@ -85,11 +97,11 @@ func New(clusterscan *cisoperatorapiv1.ClusterScan, clusterscanprofile *cisopera
 					},
 				},
 				Spec: corev1.PodSpec{
-					HostPID:                       true,
-					HostIPC:                       true,
-					ServiceAccountName:            cisoperatorapiv1.ClusterScanSA,
+					SecurityContext:               getAggregatorPodSecurityContext(podConfig),
+					ServiceAccountName:            getServiceAccountName(clusterscan),
 					TerminationGracePeriodSeconds: &TerminationGracePeriodSeconds,
 					Tolerations:                   tolerations,
+					TopologySpreadConstraints:     topologySpreadConstraints,
 					NodeSelector: labels.Set{
 						"kubernetes.io/os": "linux",
 					},
@@ -118,63 +130,18 @@ func New(clusterscan *cisoperatorapiv1.ClusterScan, clusterscanprofile *cisopera
 							EmptyDir: &corev1.EmptyDirVolumeSource{},
 						},
 					}, {
-						Name: `rke2-root`,
-						VolumeSource: corev1.VolumeSource{
-							HostPath: &corev1.HostPathVolumeSource{
-								Path: `/var/lib/rancher`,
-							},
-						},
-					}, {
-						Name: `rke2-root-config`,
-						VolumeSource: corev1.VolumeSource{
-							HostPath: &corev1.HostPathVolumeSource{
-								Path: `/etc/rancher`,
-							},
-						},
-					}, {
-						Name: `rke2-cni`,
-						VolumeSource: corev1.VolumeSource{
-							HostPath: &corev1.HostPathVolumeSource{
-								Path: `/etc/cni/net.d`,
-							},
-						},
-					}, {
-						Name: `etc-passwd`,
+						// writable scratch space, the root filesystem being read-only
+						Name: `tmp-volume`,
 						VolumeSource: corev1.VolumeSource{
-							HostPath: &corev1.HostPathVolumeSource{
-								Path: `/etc/passwd`,
-							},
-						},
-					}, {
-						Name: `etc-group`,
-						VolumeSource: corev1.VolumeSource{
-							HostPath: &corev1.HostPathVolumeSource{
-								Path: `/etc/group`,
-							},
-						},
-					}, {
-						Name: `var-log`,
-						VolumeSource: corev1.VolumeSource{
-							HostPath: &corev1.HostPathVolumeSource{
-								Path: `/var/log`,
-							},
-						},
-					}, {
-						Name: `run-log`,
-						VolumeSource: corev1.VolumeSource{
-							HostPath: &corev1.HostPathVolumeSource{
-								Path: `/run/log`,
-							},
+							EmptyDir: &corev1.EmptyDirVolumeSource{},
 						},
 					},
 					},
 					Containers: []corev1.Container{{
 						Name:            `rancher-cis-benchmark`,
-						Image:           imageConfig.SecurityScanImage + ":" + imageConfig.SecurityScanImageTag,
+						Image:           imageConfig.ImageRef(imageConfig.SecurityScanImage, imageConfig.SecurityScanImageTag),
 						ImagePullPolicy: corev1.PullIfNotPresent,
-						SecurityContext: &corev1.SecurityContext{
-							Privileged: &privileged,
-						},
+						SecurityContext: getAggregatorSecurityContext(podConfig),
 						Env: []corev1.EnvVar{{
 							Name:  `OVERRIDE_BENCHMARK_VERSION`,
 							Value: clusterscanprofile.Spec.BenchmarkVersion,
@@ -205,38 +172,38 @@ func New(clusterscan *cisoperatorapiv1.ClusterScan, clusterscanprofile *cisopera
 						}, {
 							Name:      `s-plugins-volume`,
 							MountPath: `/plugins.d`,
+						}, {
+							Name:      `tmp-volume`,
+							MountPath: `/tmp`,
 						}, {
 							Name:      `output-volume`,
 							MountPath: `/tmp/sonobuoy`,
-						}, {
-							Name:      `rke2-root`,
-							MountPath: `/var/lib/rancher`,
-						}, {
-							Name:      `rke2-root-config`,
-							MountPath: `/etc/rancher`,
-						}, {
-							Name:      `rke2-cni`,
-							MountPath: `/etc/cni/net.d`,
-						}, {
-							Name:      `etc-passwd`,
-							MountPath: `/etc/passwd`,
-						}, {
-							Name:      `etc-group`,
-							MountPath: `/etc/group`,
-						}, {
-							Name:      `var-log`,
-							MountPath: `/var/log/`,
-						}, {
-							Name:      `run-log`,
-							MountPath: `/run/log/`,
 						}},
 					}},
 				},
 			},
 		},
 	}
+	if nodeAffinity != nil {
+		job.Spec.Template.Spec.Affinity = &corev1.Affinity{NodeAffinity: nodeAffinity}
+	}
+	if clusterscan.Spec.Resources != nil {
+		job.Spec.Template.Spec.Containers[0].Resources = *clusterscan.Spec.Resources
+	} else if podConfig.Resources != nil {
+		job.Spec.Template.Spec.Containers[0].Resources = *podConfig.Resources
+	}
+
+	job.Spec.Template.Spec.PriorityClassName = getPriorityClassName(clusterscan, podConfig)
+	if clusterscan.Spec.DNSPolicy != "" {
+		job.Spec.Template.Spec.DNSPolicy = clusterscan.Spec.DNSPolicy
+	}
+	job.Spec.Template.Spec.DNSConfig = clusterscan.Spec.DNSConfig
+	job.Spec.Template.Spec.HostAliases = clusterscan.Spec.HostAliases
+	job.Spec.Template.Spec.ImagePullSecrets = imageConfig.ImagePullSecretRefs()
+	job.Spec.Template.Spec.Containers[0].Env = append(job.Spec.Template.Spec.Containers[0].Env, podConfig.Proxy.EnvVars(clusterscan.Name)...)
+
 	//add userskip configmap if present
-	if clusterscanprofile.Spec.SkipTests != nil && len(clusterscanprofile.Spec.SkipTests) > 0 {
+	if len(clusterscanprofile.Spec.ActiveSkipTests(time.Now())) > 0 {
 		skipVol := corev1.Volume{
 			Name: `user-skip-info-volume`,
 			VolumeSource: corev1.VolumeSource{
@@ -317,3 +284,58 @@ func loadCustomBenchmarkConfigMap(benchmark *cisoperatorapiv1.ClusterScanBenchma
 	}
 	return configmapCopy, nil
 }
+
+// getAggregatorPodSecurityContext returns the configured pod security context of the aggregator, or
+// the hardened default: a non-root user, the runtime default seccomp profile and the unprivileged
+// ports starting at 0, as the aggregator listens on 443
+func getAggregatorPodSecurityContext(podConfig *cisoperatorapiv1.ScanPodConfig) *corev1.PodSecurityContext {
+	if podConfig.AggregatorPodSecurityContext != nil {
+		return podConfig.AggregatorPodSecurityContext.DeepCopy()
+	}
+	runAsNonRoot := true
+	user := defaultAggregatorUser
+	return &corev1.PodSecurityContext{
+		RunAsNonRoot: &runAsNonRoot,
+		RunAsUser:    &user,
+		RunAsGroup:   &user,
+		FSGroup:      &user,
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+		Sysctls: []corev1.Sysctl{{
+			Name:  "net.ipv4.ip_unprivileged_port_start",
+			Value: "0",
+		}},
+	}
+}
+
+// getAggregatorSecurityContext returns the configured security context of the aggregator container, or
+// the hardened default: a read-only root filesystem, no privilege escalation and all capabilities dropped
+func getAggregatorSecurityContext(podConfig *cisoperatorapiv1.ScanPodConfig) *corev1.SecurityContext {
+	if podConfig.AggregatorSecurityContext != nil {
+		return podConfig.AggregatorSecurityContext.DeepCopy()
+	}
+	readOnlyRootFilesystem := true
+	allowPrivilegeEscalation := false
+	return &corev1.SecurityContext{
+		ReadOnlyRootFilesystem:   &readOnlyRootFilesystem,
+		AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+	}
+}
+
+func getPriorityClassName(clusterscan *cisoperatorapiv1.ClusterScan, podConfig *cisoperatorapiv1.ScanPodConfig) string {
+	if clusterscan.Spec.PriorityClassName != "" {
+		return clusterscan.Spec.PriorityClassName
+	}
+	return podConfig.PriorityClassName
+}
+
+func getServiceAccountName(clusterscan *cisoperatorapiv1.ClusterScan) string {
+	if clusterscan.Spec.ServiceAccountName != "" {
+		return clusterscan.Spec.ServiceAccountName
+	}
+	return cisoperatorapiv1.ClusterScanSA
+}
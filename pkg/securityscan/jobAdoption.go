@@ -0,0 +1,145 @@
+package securityscan
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rancher/wrangler/pkg/name"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+)
+
+// jobRecoveryGracePeriod is how long after its launch the job of a run may be missing from the cache before the
+// run is recovered without it
+const jobRecoveryGracePeriod = 2 * time.Minute
+
+// scan events recover the runs of the scans whose job is gone, e.g. deleted after its TTL or by hand while the operator
+// was down: the results are read from the output ConfigMap of the run when it is there, the run fails otherwise, and
+// is retried as per the retry policy of the scan. The runs launched before the operator started whose job is still
+// there are resumed, the job and pod events drive them as before.
+func (c *Controller) handleScanJobRecovery(ctx context.Context) error {
+	scans := c.cisFactory.Cis().V1().ClusterScan()
+	startTime := time.Now()
+	var lock sync.Mutex
+	resumed := map[string]string{}
+
+	scans.OnChange(ctx, c.Name, func(key string, obj *v1.ClusterScan) (*v1.ClusterScan, error) {
+		if obj == nil || obj.DeletionTimestamp != nil {
+			lock.Lock()
+			delete(resumed, key)
+			lock.Unlock()
+			return obj, nil
+		}
+		if !c.ownsName(obj.Name) {
+			return obj, nil
+		}
+		if !v1.ClusterScanConditionCreated.IsTrue(obj) || v1.ClusterScanConditionComplete.IsTrue(obj) {
+			return obj, nil
+		}
+		launchedAt, err := time.Parse(time.RFC3339, obj.Status.LastRunTimestamp)
+		if err != nil {
+			return obj, nil
+		}
+		if remaining := time.Until(launchedAt.Add(jobRecoveryGracePeriod)); remaining > 0 {
+			scans.EnqueueAfter(obj.Name, remaining)
+			return obj, nil
+		}
+
+		jobName := name.SafeConcatName("security-scan-runner", obj.Name)
+		job, err := c.jobs.Cache().Get(v1.ClusterScanNS, jobName)
+		if err == nil {
+			if launchedAt.Before(startTime) {
+				lock.Lock()
+				last := resumed[key]
+				resumed[key] = obj.Status.LastRunTimestamp
+				lock.Unlock()
+				if last != obj.Status.LastRunTimestamp {
+					scanLog(obj).Infof("Resuming the run of scan %v launched at %v with its job %v", obj.Name, obj.Status.LastRunTimestamp, job.Name)
+				}
+			}
+			return obj, nil
+		} else if !errors.IsNotFound(err) {
+			return obj, err
+		}
+
+		if v1.ClusterScanConditionRunCompleted.IsTrue(obj) {
+			return obj, c.syncScanRun(ctx, nil, obj.DeepCopy())
+		}
+		scanCopy := obj.DeepCopy()
+		v1.ClusterScanConditionRunCompleted.True(scanCopy)
+		setScanPhase(scanCopy, v1.ClusterScanPhaseReporting, time.Now())
+		if _, err := c.configMapCache.Get(v1.ClusterScanNS, engine.OutputConfigMapName(obj.Name)); err == nil {
+			scanLog(obj).Infof("Recovering the results of scan %v, its job %v is gone", obj.Name, jobName)
+		} else if errors.IsNotFound(err) {
+			scanLog(obj).Infof("Marking ClusterScanConditionFailed for scan: %v, its job %v is gone without results", obj.Name, jobName)
+			v1.ClusterScanConditionFailed.True(scanCopy)
+			v1.ClusterScanConditionFailed.Reason(scanCopy, v1.ClusterScanReasonJobLost)
+			v1.ClusterScanConditionFailed.Message(scanCopy, fmt.Sprintf("Job %v of the run is gone without results", jobName))
+		} else {
+			return obj, err
+		}
+		c.setClusterScanStatusDisplay(scanCopy)
+		return scans.UpdateStatus(scanCopy)
+	})
+	return nil
+}
+
+// getInFlightJob returns the job of the scan still running, when the operator launched it but stopped before
+// recording the run in the status of the scan
+func (c *Controller) getInFlightJob(scan *v1.ClusterScan) (*batchv1.Job, bool) {
+	job, err := c.jobs.Cache().Get(v1.ClusterScanNS, name.SafeConcatName("security-scan-runner", scan.Name))
+	if err != nil || job.DeletionTimestamp != nil {
+		return nil, false
+	}
+	owned := false
+	for _, owner := range job.OwnerReferences {
+		if owner.Kind == "ClusterScan" && owner.UID == scan.UID {
+			owned = true
+		}
+	}
+	if !owned {
+		return nil, false
+	}
+	for _, cond := range job.Status.Conditions {
+		if cond.Status == corev1.ConditionTrue && (cond.Type == batchv1.JobComplete || cond.Type == batchv1.JobFailed) {
+			return nil, false
+		}
+	}
+	return job, true
+}
+
+// adoptScanJob records the run of the in-flight job in the status of the pending scan, as when it was launched,
+// instead of launching another run
+func (c *Controller) adoptScanJob(ctx context.Context, scan *v1.ClusterScan, job *batchv1.Job) error {
+	profile, err := c.getClusterScanProfile(ctx, scan)
+	if err != nil {
+		return fmt.Errorf("error getting the ClusterScanProfile of the job %v of scan %v: %w", job.Name, scan.Name, err)
+	}
+	scanLog(scan).Infof("Adopting job %v of scan %v launched at %v", job.Name, scan.Name, job.CreationTimestamp.String())
+	scan.Status.LastRunTimestamp = job.CreationTimestamp.UTC().Format(time.RFC3339)
+	recordProfileRename(scan, profile.Name, job.CreationTimestamp.Time)
+	scan.Status.LastRunScanProfileName = profile.Name
+	if scan.Status.NextRetryAt != "" {
+		scan.Status.Attempts++
+		scan.Status.NextRetryAt = ""
+	} else {
+		scan.Status.Attempts = 1
+	}
+	scan.Status.Progress = nil
+	setScanPhase(scan, v1.ClusterScanPhaseLaunching, job.CreationTimestamp.Time)
+	v1.ClusterScanConditionCreated.True(scan)
+	v1.ClusterScanConditionRunCompleted.Unknown(scan)
+	v1.ClusterScanConditionRunCompleted.Message(scan, "Creating Job to run the CIS scan")
+	c.setClusterScanStatusDisplay(scan)
+	scan.Status.QueuedAt = ""
+	c.mu.Lock()
+	c.launchedScans[scan.Name] = true
+	c.mu.Unlock()
+	return nil
+}
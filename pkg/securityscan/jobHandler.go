@@ -3,31 +3,31 @@ package securityscan
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/sirupsen/logrus"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 
-	"github.com/rancher/security-scan/pkg/kb-summarizer/report"
-	reportLibrary "github.com/rancher/security-scan/pkg/kb-summarizer/report"
 	batchctlv1 "github.com/rancher/wrangler/pkg/generated/controllers/batch/v1"
 
 	"time"
 
 	cisoperatorapi "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io"
 	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/attestation"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+	cisscan "github.com/rancher/cis-operator/pkg/securityscan/scan"
 	"github.com/rancher/wrangler/pkg/name"
 )
 
-var sonobuoyWorkerLabel = map[string]string{"sonobuoy-plugin": "rancher-kube-bench"}
-
 // job events (successful completions) should remove the job after validatinf Done annotation and Output CM
 func (c *Controller) handleJobs(ctx context.Context) error {
 	scans := c.cisFactory.Cis().V1().ClusterScan()
-	reports := c.cisFactory.Cis().V1().ClusterScanReport()
 	jobs := c.batchFactory.Batch().V1().Job()
 
 	jobs.OnChange(ctx, c.Name, func(key string, obj *batchv1.Job) (*batchv1.Job, error) {
@@ -48,6 +48,9 @@ func (c *Controller) handleJobs(ctx context.Context) error {
 			logrus.Errorf("malformed scan, deleting the job %v", obj.Name)
 			return obj, c.deleteJob(jobs, obj, metav1.DeletePropagationBackground)
 		}
+		if !c.ownsName(scanName) {
+			return obj, nil
+		}
 		// get the scan being run
 		scan, err := scans.Get(scanName, metav1.GetOptions{})
 		switch {
@@ -58,131 +61,375 @@ func (c *Controller) handleJobs(ctx context.Context) error {
 		case err != nil:
 			return obj, err
 		}
+		return obj, c.syncScanRun(ctx, obj, scan)
+	})
+	return nil
+}
 
-		// if the scan has completed then delete the job
-		if v1.ClusterScanConditionComplete.IsTrue(scan) {
-			if !v1.ClusterScanConditionFailed.IsTrue(scan) {
-				logrus.Infof("Marking ClusterScanConditionAlerted for scan: %v", scanName)
-				v1.ClusterScanConditionAlerted.Unknown(scan)
-			}
-			scan.Status.ObservedGeneration = scan.Generation
-			c.setClusterScanStatusDisplay(scan)
+// syncScanRun completes the run of a scan once its job is done: it reads the results and creates the reports,
+// retries a failed run, and deletes the job and the scan pods once the scan is complete. The job is nil when it
+// is gone, e.g. deleted after its TTL while the operator was down, the results are then read all the same.
+func (c *Controller) syncScanRun(ctx context.Context, job *batchv1.Job, scan *v1.ClusterScan) (err error) {
+	scanName := scan.Name
+	// if the scan has completed then delete the job
+	if v1.ClusterScanConditionComplete.IsTrue(scan) {
+		// the results of a run failed for exceeding its regression budget are exported all the same
+		if !v1.ClusterScanConditionFailed.IsTrue(scan) || isRegressionBudgetFailure(scan) {
+			scanLog(scan).Infof("Marking ClusterScanConditionAlerted for scan: %v", scanName)
+			v1.ClusterScanConditionAlerted.Unknown(scan)
+		}
+		scan.Status.ObservedGeneration = scan.Generation
+		c.setClusterScanStatusDisplay(scan)
 
-			if scan.Spec.ScheduledScanConfig != nil && scan.Spec.ScheduledScanConfig.CronSchedule != "" {
+		if isScheduledScan(scan) {
+			if !isSuspendedScan(scan) {
 				c.rescheduleScan(scan)
-				c.purgeOldClusterScanReports(scan)
 			}
-			err := c.deleteJob(jobs, obj, metav1.DeletePropagationBackground)
+			c.purgeOldClusterScanReports(scan)
+		}
+		if job != nil {
+			if err := c.deleteJob(c.jobs, job, metav1.DeletePropagationBackground); err != nil {
+				return fmt.Errorf("error deleting job: %w", err)
+			}
+		}
+		if err := c.ensureCleanup(scan); err != nil {
+			return err
+		}
+		//update scan
+		if _, err := c.scans.UpdateStatus(scan); err != nil {
+			return fmt.Errorf("error updating condition of cluster scan object: %v", scanName)
+		}
+		c.traceScanRun(scan, time.Now())
+		c.releaseScan(scan.Name)
+		return nil
+	}
+
+	if v1.ClusterScanConditionRunCompleted.IsTrue(scan) {
+		if v1.ClusterScanConditionFailed.IsTrue(scan) && canRetryScan(scan) {
+			return c.retryFailedScan(c.jobs, job, scan)
+		}
+		scancopy := scan.DeepCopy()
+		var reportName string
+		var states map[string]string
+
+		if !v1.ClusterScanConditionFailed.IsTrue(scan) {
+			reportStart := time.Now()
+			defer func() {
+				// the failed attempts are recorded too, a report retried after an error shows as several spans
+				c.traceScanPhase(scan, spanReport, reportStart, time.Now(), err, map[string]interface{}{"cis.report.name": reportName})
+			}()
+			summary, report, shards, additionalReports, err := c.getScanResults(ctx, scan)
+			if err != nil {
+				return fmt.Errorf("error %v reading results of cluster scan object: %v", err, scanName)
+			}
+			scancopy.Status.Summary = summary
+			scancopy.Status.TeamSummaries = report.Spec.TeamSummaries
+			scancopy.Status.DriftedChecks = engine.CountDriftedChecks(report.Spec.Drift)
+			scancopy.Status.ComplianceScore = report.Spec.ComplianceScore
+			reportJSON, err := report.Spec.GetReportJSON()
 			if err != nil {
-				return obj, fmt.Errorf("error deleting job: %w", err)
+				return fmt.Errorf("error %v reading report of cluster scan object: %v", err, scanName)
 			}
-			err = c.ensureCleanup(scan)
+			failed, err := engine.GetFailedChecks(reportJSON)
 			if err != nil {
-				return obj, err
+				return fmt.Errorf("error %v reading failed checks of cluster scan object: %v", err, scanName)
 			}
-			//update scan
-			_, err = scans.UpdateStatus(scan)
+			states, err = engine.GetCheckStates(reportJSON)
 			if err != nil {
-				return nil, fmt.Errorf("error updating condition of cluster scan object: %v", scanName)
+				return fmt.Errorf("error %v reading check states of cluster scan object: %v", err, scanName)
 			}
-			c.currentScanName = ""
-			return obj, nil
-		}
-
-		if v1.ClusterScanConditionRunCompleted.IsTrue(scan) {
-			scancopy := scan.DeepCopy()
-
-			if !v1.ClusterScanConditionFailed.IsTrue(scan) {
-				summary, report, err := c.getScanResults(ctx, scan)
+			report.Spec.Diff, err = c.diffPreviousReport(scan, states)
+			if err != nil {
+				return fmt.Errorf("error %v comparing the report of cluster scan object %v with its previous one", err, scanName)
+			}
+			checkRegressionBudget(scancopy, report.Spec.Diff)
+			if err := c.checkBaseline(scancopy, states); err != nil {
+				return fmt.Errorf("error %v comparing the report of cluster scan object %v with its baseline", err, scanName)
+			}
+			if err := c.checkPostureDrift(scancopy, states, report.Spec.Diff); err != nil {
+				return fmt.Errorf("error %v comparing the report of cluster scan object %v with its reference", err, scanName)
+			}
+			now := time.Now()
+			scancopy.Status.RemediatedChecks = updateRemediatedChecks(scan.Status.RemediatedChecks, scan.Status.FailingChecks, states, now)
+			scancopy.Status.FailingChecks = updateFailingChecks(scan.Status.FailingChecks, failed, now)
+			if len(scan.Spec.IncludeChecks) > 0 {
+				keepExcludedFailingChecks(scancopy.Status.FailingChecks, scan.Status.FailingChecks, scan.Spec.IncludeChecks)
+			}
+			createdReport, created, err := c.createOrGetClusterScanReport(report)
+			if err != nil {
+				return fmt.Errorf("error %v saving clusterscanreport object", err)
+			}
+			reportName = createdReport.Name
+			if created {
+				c.recordScanEvent(ctx, scan, corev1.EventTypeNormal, EventReasonReportCreated, fmt.Sprintf("Created ClusterScanReport %v", reportName))
+			}
+			if err := c.createClusterScanReportShards(createdReport, shards); err != nil {
+				return fmt.Errorf("error %v saving shards of clusterscanreport %v", err, reportName)
+			}
+			for _, additional := range additionalReports {
+				createdAdditional, created, err := c.createOrGetClusterScanReport(additional.report)
 				if err != nil {
-					return nil, fmt.Errorf("error %v reading results of cluster scan object: %v", err, scanName)
+					return fmt.Errorf("error %v saving clusterscanreport object of additional profile %v", err, additional.report.Spec.ScanProfileName)
 				}
-				scancopy.Status.Summary = summary
-				_, err = reports.Create(report)
-				if err != nil {
-					return nil, fmt.Errorf("error %v saving clusterscanreport object", err)
+				if created {
+					c.recordScanEvent(ctx, scan, corev1.EventTypeNormal, EventReasonReportCreated, fmt.Sprintf("Created ClusterScanReport %v for additional ClusterScanProfile %v", createdAdditional.Name, additional.report.Spec.ScanProfileName))
+				}
+				if err := c.createClusterScanReportShards(createdAdditional, additional.shards); err != nil {
+					return fmt.Errorf("error %v saving shards of clusterscanreport %v", err, createdAdditional.Name)
 				}
 			}
-			v1.ClusterScanConditionComplete.True(scancopy)
-			/* update scan */
-			_, err = scans.UpdateStatus(scancopy)
-			if err != nil {
-				return nil, fmt.Errorf("error updating condition of scan object: %v", scanName)
+			if scan.Spec.NodeAction != nil || scan.Status.NodeAction != nil {
+				nodeFailures, err := c.getNodeFailingChecks(scan, reportJSON, shards)
+				if err != nil {
+					return fmt.Errorf("error %v reading the failing checks of the nodes of cluster scan object: %v", err, scanName)
+				}
+				c.applyNodeAction(ctx, scancopy, nodeFailures)
 			}
-			logrus.Infof("Marking ClusterScanConditionComplete for scan: %v", scanName)
-			jobs.Enqueue(obj.Namespace, obj.Name)
+			scancopy.Status.Trend = appendTrendPoint(scan.Status.Trend, summary, reportName, now, c.getImageConfig().TrendWindow)
 		}
-		return obj, nil
-	})
+		v1.ClusterScanConditionComplete.True(scancopy)
+		scancopy.Status.Progress = nil
+		scancopy.Status.Cost = c.scanCosts.getRunCost(scan, time.Now())
+		/* update scan */
+		updated, err := c.scans.UpdateStatus(scancopy)
+		if err != nil {
+			return fmt.Errorf("error updating condition of scan object: %v", scanName)
+		}
+		scanLog(scancopy).Infof("Marking ClusterScanConditionComplete for scan: %v", scanName)
+		c.traceScanJob(scan, job)
+		notifyStart := time.Now()
+		c.notifyScanSubscriptions(scancopy, reportName)
+		if len(states) > 0 {
+			c.runRemediations(scancopy, reportName, states)
+		}
+		c.traceScanPhase(scancopy, spanNotify, notifyStart, time.Now(), nil, nil)
+		if job == nil {
+			// no job event follows, the scan is completed right away
+			return c.syncScanRun(ctx, nil, updated)
+		}
+		c.jobs.Enqueue(job.Namespace, job.Name)
+	}
 	return nil
 }
 
+// createOrGetClusterScanReport creates the report of a run, or returns the one created by an earlier attempt to
+// complete the run that failed past its creation, created is false then
+func (c *Controller) createOrGetClusterScanReport(report *v1.ClusterScanReport) (*v1.ClusterScanReport, bool, error) {
+	reports := c.cisFactory.Cis().V1().ClusterScanReport()
+	created, err := reports.Create(report)
+	if errors.IsAlreadyExists(err) {
+		existing, err := reports.Get(report.Name, metav1.GetOptions{})
+		return existing, false, err
+	}
+	return created, err == nil, err
+}
+
 func (c *Controller) deleteJob(jobController batchctlv1.JobController, job *batchv1.Job, deletionPropagation metav1.DeletionPropagation) error {
 	return jobController.Delete(job.Namespace, job.Name, &metav1.DeleteOptions{PropagationPolicy: &deletionPropagation})
 }
 
-func (c *Controller) getScanResults(ctx context.Context, scan *v1.ClusterScan) (*v1.ClusterScanSummary, *v1.ClusterScanReport, error) {
+func (c *Controller) getScanResults(ctx context.Context, scan *v1.ClusterScan) (*v1.ClusterScanSummary, *v1.ClusterScanReport, []v1.ClusterScanReportShardSpec, []additionalProfileReport, error) {
 	configmaps := c.coreFactory.Core().V1().ConfigMap()
 	//get the output configmap and create a report
-	outputConfigName := strings.Join([]string{`cisscan-output-for`, scan.Name}, "-")
+	outputConfigName := engine.OutputConfigMapName(scan.Name)
 	cm, err := configmaps.Cache().Get(v1.ClusterScanNS, outputConfigName)
 	if err != nil {
-		return nil, nil, fmt.Errorf("cisScanHandler: Updated: error fetching configmap %v: %v", outputConfigName, err)
+		return nil, nil, nil, nil, fmt.Errorf("cisScanHandler: Updated: error fetching configmap %v: %v", outputConfigName, err)
+	}
+	if err := c.injectFault("reading the node results of scan " + scan.Name); err != nil {
+		return nil, nil, nil, nil, err
 	}
 	outputBytes := []byte(cm.Data[v1.DefaultScanOutputFileName])
+	outputBytes, evaluationMethods, err := c.applyStaticPodFallback(ctx, scan, outputBytes)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("cisScanHandler: Updated: error evaluating the static pods for configmap %v: %v", outputConfigName, err)
+	}
+	if len(scan.Spec.IncludeChecks) > 0 {
+		outputBytes, err = engine.IncludeChecks(outputBytes, scan.Spec.IncludeChecks)
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("cisScanHandler: Updated: error limiting the report of configmap %v to its included checks: %v", outputConfigName, err)
+		}
+	}
+	profile, err := c.getClusterScanProfile(ctx, scan)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("Error %v loading v1.ClusterScanProfile for name %w", scan.Spec.ScanProfileName, err)
+	}
+	// the node checks of a scan with additional profiles only skip the tests all of them skip
+	var additionalProfiles []*v1.ClusterScanProfile
+	runOutputBytes := outputBytes
+	if len(scan.Spec.AdditionalProfileNames) > 0 {
+		additionalProfiles, err = c.getAdditionalProfiles(scan, profile)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		outputBytes, err = engine.SkipChecks(runOutputBytes, profile.Spec.ActiveSkipTests(time.Now()))
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("cisScanHandler: Updated: error skipping the tests of ClusterScanProfile %v: %v", profile.Name, err)
+		}
+	}
 	cisScanSummary, err := c.getScanSummary(outputBytes)
 	if err != nil {
-		return nil, nil, fmt.Errorf("cisScanHandler: Updated: error getting report from configmap %v: %v", outputConfigName, err)
+		return nil, nil, nil, nil, fmt.Errorf("cisScanHandler: Updated: error getting report from configmap %v: %v", outputConfigName, err)
 	}
 	if cisScanSummary == nil {
-		return nil, nil, fmt.Errorf("cisScanHandler: Updated: error: got empty report from configmap %v", outputConfigName)
+		return nil, nil, nil, nil, fmt.Errorf("cisScanHandler: Updated: error: got empty report from configmap %v", outputConfigName)
 	}
 
-	scanReport, err := c.createClusterScanReport(ctx, outputBytes, scan)
+	scanReport, shards, err := c.createClusterScanReport(ctx, outputBytes, scan, profile, false)
 	if err != nil {
-		return nil, nil, fmt.Errorf("cisScanHandler: Updated: error getting report from configmap %v: %v", outputConfigName, err)
+		return nil, nil, nil, nil, fmt.Errorf("cisScanHandler: Updated: error getting report from configmap %v: %v", outputConfigName, err)
 	}
+	scanReport.Spec.EvaluationMethods = evaluationMethods
 
-	return cisScanSummary, scanReport, nil
+	var additionalReports []additionalProfileReport
+	for _, additional := range additionalProfiles {
+		additionalBytes, err := engine.SkipChecks(runOutputBytes, additional.Spec.ActiveSkipTests(time.Now()))
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("cisScanHandler: Updated: error skipping the tests of ClusterScanProfile %v: %v", additional.Name, err)
+		}
+		additionalReport, additionalShards, err := c.createClusterScanReport(ctx, additionalBytes, scan, additional, true)
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("cisScanHandler: Updated: error getting report of ClusterScanProfile %v from configmap %v: %v", additional.Name, outputConfigName, err)
+		}
+		additionalReport.Spec.EvaluationMethods = evaluationMethods
+		additionalReports = append(additionalReports, additionalProfileReport{report: additionalReport, shards: additionalShards})
+	}
+
+	return cisScanSummary, scanReport, shards, additionalReports, nil
 }
 
 func (c *Controller) getScanSummary(outputBytes []byte) (*v1.ClusterScanSummary, error) {
-	r, err := report.Get(outputBytes)
-	if err != nil {
-		return nil, err
-	}
-	if r == nil {
-		return nil, nil
-	}
-	cisScanSummary := &v1.ClusterScanSummary{
-		Total:         r.Total,
-		Pass:          r.Pass,
-		Fail:          r.Fail,
-		Skip:          r.Skip,
-		Warn:          r.Warn,
-		NotApplicable: r.NotApplicable,
-	}
-	return cisScanSummary, nil
+	return engine.GetSummary(outputBytes)
 }
 
-func (c *Controller) createClusterScanReport(ctx context.Context, outputBytes []byte, scan *v1.ClusterScan) (*v1.ClusterScanReport, error) {
+// createClusterScanReport returns the report of the scan for the profile, and its shards when it is sharded. The
+// report of an additional profile is named after it and has no self-check.
+func (c *Controller) createClusterScanReport(ctx context.Context, outputBytes []byte, scan *v1.ClusterScan, profile *v1.ClusterScanProfile, additional bool) (*v1.ClusterScanReport, []v1.ClusterScanReportShardSpec, error) {
+	profileName := scan.Spec.ScanProfileName
+	if additional {
+		profileName = profile.Name
+	}
 	scanReport := &v1.ClusterScanReport{
 		ObjectMeta: metav1.ObjectMeta{
-			GenerateName: name.SafeConcatName("scan-report", scan.Name, scan.Spec.ScanProfileName) + "-",
+			// named after the run, so that a retried completion finds the reports it already created
+			Name: name.SafeConcatName("scan-report", scan.Name, profileName, name.Hex(scan.Status.LastRunTimestamp, 8)),
 		},
 	}
-	profile, err := c.getClusterScanProfile(ctx, scan)
-	if err != nil {
-		return nil, fmt.Errorf("Error %v loading v1.ClusterScanProfile for name %w", scan.Spec.ScanProfileName, err)
-	}
+	var err error
+	scanReport.Spec.ScanProfileName = profile.Name
+	scanReport.Spec.AdditionalProfile = additional
 	scanReport.Spec.BenchmarkVersion = profile.Spec.BenchmarkVersion
+	scanReport.Spec.IncludedChecks = scan.Spec.IncludeChecks
+	scanReport.Spec.Exemptions = getExemptionInventory(profile, time.Now())
 	scanReport.Spec.LastRunTimestamp = time.Now().String()
 
-	data, err := reportLibrary.GetJSONBytes(outputBytes)
+	data, err := engine.GetReportJSON(outputBytes)
 	if err != nil {
-		return nil, fmt.Errorf("Error %w loading scan report json bytes", err)
+		return nil, nil, fmt.Errorf("Error %w loading scan report json bytes", err)
+	}
+	if c.getImageConfig().OmitRemediations {
+		data, err = engine.OmitRemediations(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error %w omitting the remediations of the scan report json", err)
+		}
+	} else {
+		scanReport.Spec.Remediations, err = engine.GetRemediations(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error %w listing the remediations of the failing checks", err)
+		}
+	}
+	data, err = engine.NormalizeReport(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error %w normalizing scan report json", err)
+	}
+	if len(c.getImageConfig().ReportPlugins) > 0 {
+		data, scanReport.Spec.ProcessedBy, scanReport.Spec.PluginErrors = c.runReportPlugins(ctx, scan, profile, data)
+	}
+	if c.reportSigner != nil {
+		scanReport.Spec.Attestation, err = c.reportSigner.Attest(scan.Name, data, attestation.Predicate{
+			ScanName:         scan.Name,
+			ScanProfileName:  profile.Name,
+			BenchmarkVersion: profile.Spec.BenchmarkVersion,
+			LastRunTimestamp: scanReport.Spec.LastRunTimestamp,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error %w attesting scan report", err)
+		}
+	}
+
+	scanReport.Spec.ComplianceScore, err = engine.GetComplianceScore(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error %w computing the compliance score", err)
+	}
+
+	scanReport.Spec.Drift, err = engine.GetDrift(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error %w looking for drift across nodes", err)
+	}
+
+	owners, err := c.getCheckOwners()
+	if err != nil {
+		return nil, nil, err
+	}
+	if owners != nil {
+		scanReport.Spec.TeamSummaries, err = engine.GetTeamSummaries(data, owners)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error %w rolling the report up per team", err)
+		}
+	}
+
+	nodes, err := c.kcs.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set(scan.Spec.NodeSelector).String(),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error %w listing nodes in scope of the scan", err)
+	}
+	if len(scan.Spec.NodeSelector) > 0 {
+		scanReport.Spec.NodeSelector = scan.Spec.NodeSelector
+		for _, node := range nodes.Items {
+			scanReport.Spec.NodesInScope = append(scanReport.Spec.NodesInScope, node.Name)
+		}
+	}
+	// a canary scan only covers its sampled nodes
+	if len(scan.Status.SampledNodes) > 0 {
+		scanReport.Spec.PartialCoverage = true
+		scanReport.Spec.TotalNodeCount = len(nodes.Items)
+		scanReport.Spec.NodesInScope = scan.Status.SampledNodes
+		sampled := map[string]bool{}
+		for _, nodeName := range scan.Status.SampledNodes {
+			sampled[nodeName] = true
+		}
+		var sampledNodes []corev1.Node
+		for _, node := range nodes.Items {
+			if sampled[node.Name] {
+				sampledNodes = append(sampledNodes, node)
+			}
+		}
+		nodes.Items = sampledNodes
+	}
+	scanReport.Spec.NodeCount = len(nodes.Items)
+	scanReport.Spec.NodeGroups = getNodeGroups(scan, nodes.Items)
+	if err := engine.SummarizeNodeGroups(data, scanReport.Spec.NodeGroups); err != nil {
+		return nil, nil, fmt.Errorf("Error %w summarizing the report per node group", err)
+	}
+
+	// the nodes are sharded out of the report once everything above is computed from the full report
+	reportJSON, shards, err := engine.ShardReport(data, c.getImageConfig().ReportShardSize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error %w sharding scan report json", err)
+	}
+	scanReport.Spec.Sharded = len(shards) > 0
+	threshold := c.getImageConfig().ReportCompressionThreshold
+	if err := scanReport.Spec.SetReportJSON(reportJSON, threshold >= 0 && len(reportJSON) > threshold); err != nil {
+		return nil, nil, fmt.Errorf("Error %w compressing scan report json", err)
+	}
+
+	if c.getImageConfig().SelfCheck && !additional {
+		scanReport.Spec.SelfCheck = c.runSelfCheck(ctx, scan)
+	}
+	if startTime, err := time.Parse(time.RFC3339, scan.Status.LastRunTimestamp); err == nil {
+		scanReport.Spec.DurationSeconds = int64(time.Since(startTime).Seconds())
 	}
-	scanReport.Spec.ReportJSON = string(data[:])
 
 	ownerRef := metav1.OwnerReference{
 		APIVersion: "cis.cattle.io/v1",
@@ -192,19 +439,110 @@ func (c *Controller) createClusterScanReport(ctx context.Context, outputBytes []
 	}
 	scanReport.ObjectMeta.OwnerReferences = append(scanReport.ObjectMeta.OwnerReferences, ownerRef)
 
-	return scanReport, nil
+	return scanReport, shards, nil
+}
+
+// getNodeGroups groups the nodes by the value of each of the node group labels of the scan, then
+// by the group each of its node group dimensions renders for them
+func getNodeGroups(scan *v1.ClusterScan, nodes []corev1.Node) []v1.ClusterScanNodeGroup {
+	groupLabels := scan.Spec.NodeGroupLabels
+	if len(groupLabels) == 0 && len(scan.Spec.NodeGroupDimensions) == 0 {
+		groupLabels = v1.DefaultNodeGroupLabels
+	}
+	var groups []v1.ClusterScanNodeGroup
+	for _, label := range groupLabels {
+		groups = appendNodeGroups(groups, label, nodes, func(node *corev1.Node) string {
+			return node.Labels[label]
+		})
+	}
+	for _, dimension := range scan.Spec.NodeGroupDimensions {
+		tmpl, err := cisscan.ParseNodeGroupTemplate(dimension)
+		if err != nil {
+			logrus.Errorf("Skipping invalid nodeGroupDimension %v of scan %v: %v", dimension.Name, scan.Name, err)
+			continue
+		}
+		groups = appendNodeGroups(groups, dimension.Name, nodes, func(node *corev1.Node) string {
+			group, err := tmpl.Group(node)
+			if err != nil {
+				logrus.Debugf("Leaving node %v out of nodeGroupDimension %v: %v", node.Name, dimension.Name, err)
+			}
+			return group
+		})
+	}
+	return groups
+}
+
+// appendNodeGroups appends the groups of nodes sharing the same non-empty groupOf value, sorted by value
+func appendNodeGroups(groups []v1.ClusterScanNodeGroup, label string, nodes []corev1.Node, groupOf func(node *corev1.Node) string) []v1.ClusterScanNodeGroup {
+	groupNodes := map[string][]string{}
+	for i := range nodes {
+		if value := groupOf(&nodes[i]); value != "" {
+			groupNodes[value] = append(groupNodes[value], nodes[i].Name)
+		}
+	}
+	values := make([]string, 0, len(groupNodes))
+	for value := range groupNodes {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	for _, value := range values {
+		sort.Strings(groupNodes[value])
+		groups = append(groups, v1.ClusterScanNodeGroup{Label: label, Value: value, Nodes: groupNodes[value]})
+	}
+	return groups
+}
+
+// getExemptionInventory lists the tests the profile skips, with the owner and expiry of the active exemptions
+func getExemptionInventory(profile *v1.ClusterScanProfile, now time.Time) []v1.ClusterScanExemption {
+	var inventory []v1.ClusterScanExemption
+	for _, testID := range profile.Spec.SkipTests {
+		inventory = append(inventory, v1.ClusterScanExemption{
+			TestID: testID,
+			Reason: fmt.Sprintf("skipTests of ClusterScanProfile %v", profile.Name),
+		})
+	}
+	for _, testID := range profile.Spec.DefaultSkipTests {
+		inventory = append(inventory, v1.ClusterScanExemption{
+			TestID: testID,
+			Reason: fmt.Sprintf("default skips of benchmark %v", profile.Spec.BenchmarkVersion),
+		})
+	}
+	for _, exemption := range profile.Spec.Exemptions {
+		if !exemption.Expired(now) {
+			inventory = append(inventory, exemption)
+		}
+	}
+	return inventory
+}
+
+// getCheckOwners reads the check ownership ConfigMap, nil if none is configured
+func (c *Controller) getCheckOwners() (engine.CheckOwners, error) {
+	if c.getImageConfig().CheckOwnersConfigMap == "" {
+		return nil, nil
+	}
+	cm, err := c.configMapCache.Get(v1.ClusterScanNS, c.getImageConfig().CheckOwnersConfigMap)
+	if errors.IsNotFound(err) {
+		logrus.Warnf("Check ownership ConfigMap %v not found, the reports are not rolled up per team", c.getImageConfig().CheckOwnersConfigMap)
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Error %w getting check ownership ConfigMap %v", err, c.getImageConfig().CheckOwnersConfigMap)
+	}
+	return engine.ParseCheckOwners(cm.Data), nil
 }
 
 func (c *Controller) ensureCleanup(scan *v1.ClusterScan) error {
 	var err error
-	// Delete the dameonset
-	dsPrefix := "sonobuoy-rancher-kube-bench-daemon-set"
-	dsList, err := c.daemonsetCache.List(v1.ClusterScanNS, labels.Set(sonobuoyWorkerLabel).AsSelector())
+	podPrefix := name.SafeConcatName("security-scan-runner", scan.Name)
+	// Delete the dameonsets owned by the runner pod of the scan
+	dsList, err := c.daemonsetCache.List(v1.ClusterScanNS, labels.Everything())
 	if err != nil {
 		return fmt.Errorf("cis: ensureCleanup: error listing daemonsets: %w", err)
 	}
+	getPod := func(podName string) (*corev1.Pod, error) {
+		return c.podCache.Get(v1.ClusterScanNS, podName)
+	}
 	for _, ds := range dsList {
-		if !strings.HasPrefix(ds.Name, dsPrefix) {
+		if engine.GetRunnerPodScan(ds.OwnerReferences, getPod) != scan.Name {
 			continue
 		}
 		if e := c.daemonsets.Delete(v1.ClusterScanNS, ds.Name, &metav1.DeleteOptions{}); e != nil && !errors.IsNotFound(e) {
@@ -213,7 +551,6 @@ func (c *Controller) ensureCleanup(scan *v1.ClusterScan) error {
 	}
 
 	// Delete the pod
-	podPrefix := name.SafeConcatName("security-scan-runner", scan.Name)
 	podList, err := c.podCache.List(v1.ClusterScanNS, labels.Set(SonobuoyMasterLabel).AsSelector())
 	if err != nil {
 		return fmt.Errorf("cis: ensureCleanup: error listing pods: %w", err)
@@ -232,8 +569,9 @@ func (c *Controller) ensureCleanup(scan *v1.ClusterScan) error {
 	if err != nil {
 		return fmt.Errorf("cis: ensureCleanup: error listing cm: %w", err)
 	}
+	scanConfigMaps := engine.ScanConfigMapNames(scan.Name)
 	for _, cm := range cms {
-		if !strings.Contains(cm.Name, scan.Name) {
+		if !scanConfigMaps[cm.Name] {
 			continue
 		}
 
@@ -0,0 +1,24 @@
+package securityscan
+
+import (
+	"github.com/sirupsen/logrus"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// scanLog returns the logger of the scan, with the scan, profile and phase fields of its run, so that the logs of a
+// run are found by field in the JSON logs
+func scanLog(scan *v1.ClusterScan) *logrus.Entry {
+	fields := logrus.Fields{"scan": scan.Name}
+	profile := scan.Status.LastRunScanProfileName
+	if profile == "" {
+		profile = scan.Spec.ScanProfileName
+	}
+	if profile != "" {
+		fields["profile"] = profile
+	}
+	if scan.Status.Progress != nil {
+		fields["phase"] = scan.Status.Progress.Phase
+	}
+	return logrus.WithFields(fields)
+}
@@ -0,0 +1,247 @@
+package securityscan
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+)
+
+// value of the taints and labels of the node actions
+const nodeActionValue = "true"
+
+// applyNodeAction taints or labels, when node actions are enabled on the operator, the scanned nodes failing the
+// critical checks of the node action of the scan, and removes the taint or label from the scanned nodes passing them
+// it recorded applying to. The nodes the action applies to are recorded in the status of the scan, the nodes not scanned by the run keep
+// theirs, and the previous action is undone once it changes or is removed from the scan. The failures are only
+// logged, the run is complete regardless.
+func (c *Controller) applyNodeAction(ctx context.Context, scan *v1.ClusterScan, nodeFailures map[string][]string) {
+	if !c.getImageConfig().NodeActionsEnabled {
+		return
+	}
+	last := scan.Status.NodeAction
+	if scan.Spec.NodeAction == nil {
+		if last != nil {
+			// the nodes it could not be undone from are retried by the next run
+			last = last.DeepCopy()
+			last.Nodes = c.undoNodeAction(scan.Name, last)
+			if len(last.Nodes) == 0 {
+				last = nil
+			}
+			scan.Status.NodeAction = last
+		}
+		return
+	}
+	status := getNodeActionStatus(scan.Spec.NodeAction)
+	if last != nil && (last.Action != status.Action || last.Key != status.Key || last.Effect != status.Effect) {
+		c.undoNodeAction(scan.Name, last)
+		last = nil
+	}
+	if last != nil {
+		for nodeName, checks := range last.Nodes {
+			if _, scanned := nodeFailures[nodeName]; scanned {
+				continue
+			}
+			if _, err := c.nodes.Cache().Get(nodeName); errors.IsNotFound(err) {
+				continue
+			}
+			status.Nodes[nodeName] = checks
+		}
+	}
+
+	critical := map[string]bool{}
+	for _, id := range scan.Spec.NodeAction.TestIDs {
+		critical[id] = true
+	}
+	var applied, removed []string
+	for nodeName, failing := range nodeFailures {
+		var checks []string
+		for _, id := range failing {
+			if critical[id] {
+				checks = append(checks, id)
+			}
+		}
+		if len(checks) == 0 && (last == nil || last.Nodes[nodeName] == nil) {
+			// only the taints and labels the scan recorded setting are removed
+			continue
+		}
+		changed, err := c.setNodeAction(nodeName, status, len(checks) > 0)
+		if err != nil {
+			logrus.Errorf("Error updating the %v of node %v for the nodeAction of scan %v: %v", describeNodeAction(status), nodeName, scan.Name, err)
+		}
+		if len(checks) > 0 {
+			// the action is retried by the next run if it failed
+			status.Nodes[nodeName] = checks
+			if changed {
+				applied = append(applied, nodeName)
+			}
+		} else if changed {
+			removed = append(removed, nodeName)
+		}
+	}
+	sort.Strings(applied)
+	sort.Strings(removed)
+	if len(applied) > 0 {
+		message := fmt.Sprintf("Set %v on nodes %v failing critical checks", describeNodeAction(status), strings.Join(applied, ", "))
+		logrus.Infof("Scan %v: %v", scan.Name, message)
+		c.recordScanEvent(ctx, scan, corev1.EventTypeWarning, EventReasonNodeAction, message)
+	}
+	if len(removed) > 0 {
+		message := fmt.Sprintf("Removed %v from nodes %v passing the critical checks", describeNodeAction(status), strings.Join(removed, ", "))
+		logrus.Infof("Scan %v: %v", scan.Name, message)
+		c.recordScanEvent(ctx, scan, corev1.EventTypeNormal, EventReasonNodeAction, message)
+	}
+	if len(status.Nodes) == 0 {
+		status.Nodes = nil
+	}
+	scan.Status.NodeAction = status
+}
+
+// undoNodeAction removes the taint or label of the node action from the nodes it applies to, and returns the nodes
+// it could not be removed from
+func (c *Controller) undoNodeAction(scanName string, status *v1.ClusterScanNodeActionStatus) map[string][]string {
+	remaining := map[string][]string{}
+	for nodeName, checks := range status.Nodes {
+		changed, err := c.setNodeAction(nodeName, status, false)
+		if err != nil {
+			logrus.Errorf("Error removing the %v of scan %v from node %v: %v", describeNodeAction(status), scanName, nodeName, err)
+			remaining[nodeName] = checks
+		} else if changed {
+			logrus.Infof("Removed the %v of scan %v from node %v", describeNodeAction(status), scanName, nodeName)
+		}
+	}
+	return remaining
+}
+
+// setNodeAction sets or removes the taint or label of the node action on the node, and returns whether the node
+// changed. A node that is gone is left alone.
+func (c *Controller) setNodeAction(nodeName string, status *v1.ClusterScanNodeActionStatus, set bool) (bool, error) {
+	changed := false
+	fromCache := true
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var node *corev1.Node
+		var err error
+		if fromCache {
+			node, err = c.nodes.Cache().Get(nodeName)
+			fromCache = false
+		} else {
+			node, err = c.nodes.Get(nodeName, metav1.GetOptions{})
+		}
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		node = node.DeepCopy()
+		if status.Action == v1.NodeActionLabel {
+			changed, err = setNodeLabel(node, status.Key, set)
+			if err != nil {
+				return err
+			}
+		} else {
+			changed = setNodeTaint(node, status.Key, status.Effect, set)
+		}
+		if !changed {
+			return nil
+		}
+		_, err = c.nodes.Update(node)
+		return err
+	})
+	return changed, err
+}
+
+// setNodeLabel sets or removes the label of the node action, a label of the same key with another value is not the
+// operator's and is neither overwritten nor removed
+func setNodeLabel(node *corev1.Node, key string, set bool) (bool, error) {
+	value, ok := node.Labels[key]
+	if ok && value != nodeActionValue {
+		if !set {
+			return false, nil
+		}
+		return false, fmt.Errorf("the node has the label %v=%v, it is not overwritten", key, value)
+	}
+	if !set {
+		if ok {
+			delete(node.Labels, key)
+		}
+		return ok, nil
+	}
+	if ok {
+		return false, nil
+	}
+	if node.Labels == nil {
+		node.Labels = map[string]string{}
+	}
+	node.Labels[key] = nodeActionValue
+	return true, nil
+}
+
+// setNodeTaint sets or removes the taint of the node action, the taints of the same key and effect with another
+// value are not the operator's and left alone
+func setNodeTaint(node *corev1.Node, key string, effect corev1.TaintEffect, set bool) bool {
+	for i, taint := range node.Spec.Taints {
+		if taint.Key != key || taint.Effect != effect || taint.Value != nodeActionValue {
+			continue
+		}
+		if set {
+			return false
+		}
+		node.Spec.Taints = append(node.Spec.Taints[:i:i], node.Spec.Taints[i+1:]...)
+		return true
+	}
+	if !set {
+		return false
+	}
+	node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{Key: key, Value: nodeActionValue, Effect: effect})
+	return true
+}
+
+// getNodeFailingChecks returns the checks failing on every scanned node of the report, the warnings counting as
+// failures with scoreWarning fail, with the nodes of its shards if it is sharded
+func (c *Controller) getNodeFailingChecks(scan *v1.ClusterScan, reportJSON []byte, shards []v1.ClusterScanReportShardSpec) (map[string][]string, error) {
+	if len(shards) > 0 {
+		var err error
+		reportJSON, err = engine.MergeReportShards(reportJSON, shards)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return engine.GetNodeFailingChecks(reportJSON, scan.Spec.ScoreWarning == v1.ClusterScanFailOnWarning)
+}
+
+// describeNodeAction returns the taint or label of the node action as kubectl shows it
+func describeNodeAction(status *v1.ClusterScanNodeActionStatus) string {
+	if status.Action == v1.NodeActionLabel {
+		return fmt.Sprintf("label %v=%v", status.Key, nodeActionValue)
+	}
+	return fmt.Sprintf("taint %v=%v:%v", status.Key, nodeActionValue, status.Effect)
+}
+
+// getNodeActionStatus returns the status of the node action with its defaults, applying to no node yet
+func getNodeActionStatus(action *v1.ClusterScanNodeAction) *v1.ClusterScanNodeActionStatus {
+	status := &v1.ClusterScanNodeActionStatus{
+		Action: action.Action,
+		Key:    action.Key,
+		Effect: action.Effect,
+		Nodes:  map[string][]string{},
+	}
+	if status.Action == "" {
+		status.Action = v1.NodeActionTaint
+	}
+	if status.Key == "" {
+		status.Key = v1.DefaultNodeActionKey
+	}
+	if status.Action == v1.NodeActionTaint && status.Effect == "" {
+		status.Effect = corev1.TaintEffectNoSchedule
+	}
+	return status
+}
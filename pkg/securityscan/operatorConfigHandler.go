@@ -0,0 +1,201 @@
+package securityscan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/logging"
+)
+
+// getImageConfig returns the configuration of the operator: the one of the flags with the OperatorConfig applied. The
+// returned configuration is never modified, a new one replaces it when the OperatorConfig changes.
+func (c *Controller) getImageConfig() *v1.ScanImageConfig {
+	return c.imageConfig.Load()
+}
+
+// OperatorConfig events apply the settings of the OperatorConfig named OperatorConfigName over those of the flags, the
+// scans launched and the reports created afterwards use them. The flags apply again once it is deleted, the other
+// OperatorConfigs are ignored.
+func (c *Controller) handleOperatorConfig(ctx context.Context) error {
+	operatorConfigs := c.cisFactory.Cis().V1().OperatorConfig()
+
+	// applied before the scans are handled, the events only come once the caches are synced
+	obj, err := operatorConfigs.Get(v1.OperatorConfigName, metav1.GetOptions{})
+	if err == nil {
+		if err := c.applyOperatorConfig(obj); err != nil {
+			logrus.Errorf("Invalid OperatorConfig %v, running with the configuration of the flags: %v", obj.Name, err)
+		}
+	} else if !errors.IsNotFound(err) {
+		return fmt.Errorf("error getting OperatorConfig %v: %w", v1.OperatorConfigName, err)
+	}
+
+	operatorConfigs.OnChange(ctx, c.Name, func(key string, obj *v1.OperatorConfig) (*v1.OperatorConfig, error) {
+		if key == v1.OperatorConfigName && (obj == nil || obj.DeletionTimestamp != nil) {
+			logrus.Infof("OperatorConfig %v deleted, running with the configuration of the flags", key)
+			previous := c.imageConfig.Swap(c.ImageConfig)
+			logging.SetLevel(c.ImageConfig.LogLevel)
+			c.enqueueReportsOnRetentionChange(previous)
+			return obj, nil
+		}
+		if obj == nil || obj.DeletionTimestamp != nil {
+			return obj, nil
+		}
+		objCopy := obj.DeepCopy()
+		objCopy.Status.ObservedGeneration = obj.Generation
+		previous := c.getImageConfig()
+		if key != v1.OperatorConfigName {
+			v1.OperatorConfigConditionApplied.False(objCopy)
+			v1.OperatorConfigConditionApplied.Reason(objCopy, "Ignored")
+			v1.OperatorConfigConditionApplied.Message(objCopy, fmt.Sprintf("only the OperatorConfig named %v is applied", v1.OperatorConfigName))
+		} else if err := c.applyOperatorConfig(obj); err != nil {
+			logrus.Errorf("Invalid OperatorConfig %v, keeping the configuration applied before: %v", obj.Name, err)
+			v1.OperatorConfigConditionApplied.False(objCopy)
+			v1.OperatorConfigConditionApplied.Reason(objCopy, "Invalid")
+			v1.OperatorConfigConditionApplied.Message(objCopy, err.Error())
+		} else {
+			c.enqueueReportsOnRetentionChange(previous)
+			if obj.Spec.DefaultProfiles != nil {
+				profiles := c.cisFactory.Cis().V1().ClusterScanProfile().Cache()
+				if err := warnMissingDefaultProfiles(profiles, "OperatorConfig "+obj.Name, obj.Spec.DefaultProfiles.Profiles); err != nil {
+					return obj, err
+				}
+			}
+			v1.OperatorConfigConditionApplied.True(objCopy)
+			v1.OperatorConfigConditionApplied.Reason(objCopy, "")
+			v1.OperatorConfigConditionApplied.Message(objCopy, "")
+		}
+		if equality.Semantic.DeepEqual(obj.Status, objCopy.Status) {
+			return obj, nil
+		}
+		if v1.OperatorConfigConditionApplied.IsTrue(objCopy) {
+			logrus.Infof("Applied OperatorConfig %v", obj.Name)
+		}
+		return operatorConfigs.UpdateStatus(objCopy)
+	})
+	return nil
+}
+
+// applyOperatorConfig replaces the configuration of the operator by the one of the flags with the settings of the
+// OperatorConfig, unless they are invalid
+func (c *Controller) applyOperatorConfig(obj *v1.OperatorConfig) error {
+	config, err := getOperatorConfig(c.ImageConfig, &obj.Spec)
+	if err != nil {
+		return err
+	}
+	c.imageConfig.Store(config)
+	return logging.SetLevel(config.LogLevel)
+}
+
+// getOperatorConfig returns a copy of the configuration of the flags with the settings of the OperatorConfig spec
+func getOperatorConfig(flags *v1.ScanImageConfig, spec *v1.OperatorConfigSpec) (*v1.ScanImageConfig, error) {
+	config := *flags
+	config.ImagePullSecrets = append([]string(nil), flags.ImagePullSecrets...)
+	config.ReportPlugins = append([]string(nil), flags.ReportPlugins...)
+	config.DefaultProfiles = nil
+
+	if spec.MaxConcurrentScans < 0 {
+		return nil, fmt.Errorf("invalid maxConcurrentScans %d, must be at least 1", spec.MaxConcurrentScans)
+	}
+	if spec.MaxConcurrentScans > 0 {
+		config.MaxConcurrentScans = spec.MaxConcurrentScans
+	}
+	if spec.LogLevel != "" {
+		if _, err := logging.ParseLevel(spec.LogLevel); err != nil {
+			return nil, err
+		}
+		config.LogLevel = spec.LogLevel
+	}
+	if images := spec.Images; images != nil {
+		overrideString(&config.SecurityScanImage, images.SecurityScanImage)
+		overrideString(&config.SecurityScanImageTag, images.SecurityScanImageTag)
+		overrideString(&config.WindowsSecurityScanImage, images.WindowsSecurityScanImage)
+		overrideString(&config.WindowsSecurityScanImageTag, images.WindowsSecurityScanImageTag)
+		overrideString(&config.SonobuoyImage, images.SonobuoyImage)
+		overrideString(&config.SonobuoyImageTag, images.SonobuoyImageTag)
+		overrideString(&config.Registry, images.Registry)
+		if len(images.ImagePullSecrets) > 0 {
+			config.ImagePullSecrets = append([]string(nil), images.ImagePullSecrets...)
+		}
+	}
+	if sinks := spec.Sinks; sinks != nil {
+		if sinks.RollupPeriodDays < 0 {
+			return nil, fmt.Errorf("invalid sinks.rollupPeriodDays %d, must not be negative", sinks.RollupPeriodDays)
+		}
+		overrideString(&config.ReportBaseURL, sinks.ReportBaseURL)
+		if sinks.RollupPeriodDays > 0 {
+			config.RollupPeriodDays = sinks.RollupPeriodDays
+		}
+	}
+	if metrics := spec.Metrics; metrics != nil {
+		overrideString(&config.AlertSeverity, metrics.AlertSeverity)
+		if metrics.TrendWindow != nil {
+			if *metrics.TrendWindow < 0 {
+				return nil, fmt.Errorf("invalid metrics.trendWindow %d, must not be negative", *metrics.TrendWindow)
+			}
+			config.TrendWindow = *metrics.TrendWindow
+		}
+	}
+	if defaults := spec.DefaultProfiles; defaults != nil {
+		overrideString(&config.DefaultProfilesConfigMap, defaults.ConfigMap)
+		for provider, entry := range defaults.Profiles {
+			if len(getDefaultProfileNames(entry)) == 0 {
+				return nil, fmt.Errorf("invalid defaultProfiles.profiles entry %v, it names no ClusterScanProfile", provider)
+			}
+			if config.DefaultProfiles == nil {
+				config.DefaultProfiles = map[string]string{}
+			}
+			config.DefaultProfiles[provider] = entry
+		}
+	}
+	if reports := spec.Reports; reports != nil {
+		if reports.RetentionDays != nil {
+			if *reports.RetentionDays < 0 {
+				return nil, fmt.Errorf("invalid reports.retentionDays %d, must not be negative", *reports.RetentionDays)
+			}
+			config.ReportRetentionDays = *reports.RetentionDays
+		}
+		if reports.CompressionThreshold != nil {
+			if *reports.CompressionThreshold < -1 {
+				return nil, fmt.Errorf("invalid reports.compressionThreshold %d, must be at least -1", *reports.CompressionThreshold)
+			}
+			config.ReportCompressionThreshold = *reports.CompressionThreshold
+		}
+		if reports.ShardSize != nil {
+			if *reports.ShardSize < 0 {
+				return nil, fmt.Errorf("invalid reports.shardSize %d, must not be negative", *reports.ShardSize)
+			}
+			config.ReportShardSize = *reports.ShardSize
+		}
+	}
+	return &config, nil
+}
+
+// enqueueReportsOnRetentionChange enqueues all the reports once the retention days of the operator change, for the
+// reports to expire by the new ones
+func (c *Controller) enqueueReportsOnRetentionChange(previous *v1.ScanImageConfig) {
+	if previous.ReportRetentionDays == c.getImageConfig().ReportRetentionDays {
+		return
+	}
+	reports := c.cisFactory.Cis().V1().ClusterScanReport()
+	reportList, err := reports.Cache().List(labels.Everything())
+	if err != nil {
+		logrus.Errorf("Error listing the ClusterScanReports to apply the retention days %d: %v", c.getImageConfig().ReportRetentionDays, err)
+		return
+	}
+	for _, report := range reportList {
+		reports.Enqueue(report.Name)
+	}
+}
+
+func overrideString(value *string, override string) {
+	if override != "" {
+		*value = override
+	}
+}
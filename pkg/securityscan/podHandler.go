@@ -3,12 +3,14 @@ package securityscan
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
 
 	corectlv1 "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
 	"github.com/rancher/wrangler/pkg/name"
@@ -33,9 +35,20 @@ func (c *Controller) handlePods(ctx context.Context) error {
 		if obj.Labels == nil || !podSelector.Matches(labels.Set(obj.Labels)) {
 			return obj, nil
 		}
+		if !c.ownsName(obj.Labels[cisoperatorapi.LabelClusterScan]) {
+			return obj, nil
+		}
+		if c.getImageConfig().AirGapped {
+			if message := getImagePullFailure(obj); message != "" {
+				return obj, c.failAirGappedScan(obj.Labels[cisoperatorapi.LabelClusterScan], message)
+			}
+		}
 		// Check the annotation to see if it's done processing
 		done, ok := obj.Annotations[cisoperatorapi.SonobuoyCompletionAnnotation]
 		if !ok {
+			if err := c.updateScanProgress(obj); err != nil {
+				logrus.Errorf("Error updating the progress of scan %v: %v", obj.Labels[cisoperatorapi.LabelClusterScan], err)
+			}
 			return nil, nil
 		}
 
@@ -68,12 +81,13 @@ func (c *Controller) handlePods(ctx context.Context) error {
 		scanCopy := scan.DeepCopy()
 		if !v1.ClusterScanConditionRunCompleted.IsTrue(scan) {
 			v1.ClusterScanConditionRunCompleted.True(scanCopy)
+			setScanPhase(scanCopy, v1.ClusterScanPhaseReporting, time.Now())
 			if done != "true" {
 				v1.ClusterScanConditionFailed.True(scanCopy)
 				if done != "error" {
 					v1.ClusterScanConditionFailed.Message(scanCopy, done)
 				}
-				logrus.Infof("Marking ClusterScanConditionFailed for scan: %v, error %v", scanName, done)
+				scanLog(scanCopy).Infof("Marking ClusterScanConditionFailed for scan: %v, error %v", scanName, done)
 			}
 			c.setClusterScanStatusDisplay(scanCopy)
 			//update scan
@@ -81,7 +95,7 @@ func (c *Controller) handlePods(ctx context.Context) error {
 			if err != nil {
 				return nil, fmt.Errorf("error updating condition of cluster scan object: %v", scanName)
 			}
-			logrus.Infof("Marking ClusterScanConditionRunCompleted for scan: %v", scanName)
+			scanLog(scanCopy).Infof("Marking ClusterScanConditionRunCompleted for scan: %v", scanName)
 			jobs.Enqueue(job.Namespace, job.Name)
 		}
 		return obj, nil
@@ -89,6 +103,45 @@ func (c *Controller) handlePods(ctx context.Context) error {
 	return nil
 }
 
+// getImagePullFailure returns why a container of the pod cannot pull its image, empty if none is failing to
+func getImagePullFailure(pod *corev1.Pod) string {
+	for _, status := range append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+		if waiting := status.State.Waiting; waiting != nil && (waiting.Reason == "ErrImagePull" || waiting.Reason == "ImagePullBackOff") {
+			return fmt.Sprintf("image %v of the runner pod cannot be pulled (%v) and air-gapped mode reaches no public registry: "+
+				"set --image-registry to an in-cluster mirror or load the image on the nodes", status.Image, waiting.Reason)
+		}
+	}
+	return ""
+}
+
+// failAirGappedScan fails the running scan whose pod cannot pull its image in air-gapped mode, rather than leaving it
+// to wait for its timeout
+func (c *Controller) failAirGappedScan(scanName, message string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		scan, err := c.scans.Get(scanName, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if !v1.ClusterScanConditionRunCompleted.IsUnknown(scan) {
+			return nil
+		}
+		scan = scan.DeepCopy()
+		v1.ClusterScanConditionRunCompleted.True(scan)
+		setScanPhase(scan, v1.ClusterScanPhaseReporting, time.Now())
+		v1.ClusterScanConditionFailed.True(scan)
+		v1.ClusterScanConditionFailed.Message(scan, message)
+		c.setClusterScanStatusDisplay(scan)
+		scanLog(scan).Errorf("Marking ClusterScanConditionFailed for scan: %v, %v", scanName, message)
+		if _, err := c.scans.UpdateStatus(scan); err != nil {
+			return err
+		}
+		c.jobs.Enqueue(v1.ClusterScanNS, name.SafeConcatName("security-scan-runner", scanName))
+		return nil
+	})
+}
+
 func deletePod(podController corectlv1.PodController, pod *corev1.Pod, deletionPropagation metav1.DeletionPropagation) error {
 	logrus.Infof("delete pod called %v", pod.Status.Conditions)
 	return podController.Delete(pod.Namespace, pod.Name, &metav1.DeleteOptions{PropagationPolicy: &deletionPropagation})
@@ -0,0 +1,72 @@
+package securityscan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+)
+
+// checkPostureDrift compares the check states of the last run of a continuous scan against the last report of
+// its reference scan, or against its previous run without one, setting the posture drift and the PostureDrifted
+// condition of its status
+func (c *Controller) checkPostureDrift(scan *v1.ClusterScan, states map[string]string, diff *v1.ClusterScanReportDiff) error {
+	scan.Status.PostureDrift = nil
+	if scan.Spec.Continuous == nil {
+		return nil
+	}
+	var reference string
+	if referenceScanName := scan.Spec.Continuous.ReferenceScanName; referenceScanName != "" {
+		referenceScan, err := c.cisFactory.Cis().V1().ClusterScan().Cache().Get(referenceScanName)
+		if errors.IsNotFound(err) {
+			unknownPostureDrift(scan, fmt.Sprintf("reference ClusterScan %v not found", referenceScanName))
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("error getting reference ClusterScan %v: %w", referenceScanName, err)
+		}
+		referenceReport, err := c.getLatestClusterScanReport(referenceScan)
+		if err != nil {
+			return err
+		}
+		if referenceReport == nil {
+			unknownPostureDrift(scan, fmt.Sprintf("reference ClusterScan %v has no report yet", referenceScanName))
+			return nil
+		}
+		reportJSON, err := referenceReport.Spec.GetReportJSON()
+		if err != nil {
+			return fmt.Errorf("error reading reference ClusterScanReport %v: %w", referenceReport.Name, err)
+		}
+		referenceStates, err := engine.GetCheckStates(reportJSON)
+		if err != nil {
+			return fmt.Errorf("error reading check states of reference ClusterScanReport %v: %w", referenceReport.Name, err)
+		}
+		// the checks left out of the runs are not compared
+		scan.Status.PostureDrift = engine.GetRegressions(referenceStates, states)
+		reference = referenceReport.Name
+	} else {
+		if diff == nil {
+			unknownPostureDrift(scan, "first run of the scan")
+			return nil
+		}
+		scan.Status.PostureDrift = diff.NewlyFailing
+		reference = diff.PreviousReport
+	}
+	if len(scan.Status.PostureDrift) == 0 {
+		v1.ClusterScanConditionPostureDrifted.False(scan)
+		v1.ClusterScanConditionPostureDrifted.Message(scan, fmt.Sprintf("no check passing in %v fails", reference))
+		return nil
+	}
+	logrus.Warnf("Posture of scan %v drifted from %v, failing checks: %v", scan.Name, reference, strings.Join(scan.Status.PostureDrift, ", "))
+	v1.ClusterScanConditionPostureDrifted.True(scan)
+	v1.ClusterScanConditionPostureDrifted.Message(scan, fmt.Sprintf("checks passing in %v fail: %v", reference, strings.Join(scan.Status.PostureDrift, ", ")))
+	return nil
+}
+
+func unknownPostureDrift(scan *v1.ClusterScan, message string) {
+	v1.ClusterScanConditionPostureDrifted.Unknown(scan)
+	v1.ClusterScanConditionPostureDrifted.Message(scan, message)
+}
@@ -0,0 +1,92 @@
+package securityscan
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// recordProfileRename records in the status of a scheduled scan launching a run on another profile than its previous
+// run, e.g. after the profile was renamed, the previous profile, keeping the last MaxProfileRenames. The manual scans
+// share their series per profile, a run on another profile is not a rename.
+func recordProfileRename(scan *v1.ClusterScan, profileName string, now time.Time) {
+	previous := scan.Status.LastRunScanProfileName
+	if !isScheduledScan(scan) || previous == "" || previous == profileName {
+		return
+	}
+	renames := append(scan.Status.ProfileRenames, v1.ClusterScanProfileRename{
+		From:      previous,
+		To:        profileName,
+		Timestamp: now.Round(time.Second).Format(time.RFC3339),
+	})
+	if len(renames) > v1.MaxProfileRenames {
+		renames = renames[len(renames)-v1.MaxProfileRenames:]
+	}
+	scan.Status.ProfileRenames = renames
+}
+
+// migrateProfileSeries deletes the series of the previous profile of a scheduled scan whose run is on another
+// profile, so that a dashboard doesn't show both for the same scan, and exports the previous profiles of the scan
+// mapped to its current one. It returns false, leaving the series alone, for a run older than the run the series of
+// the current profile hold. It must be called with metricsMu held.
+func (c *Controller) migrateProfileSeries(m *scanMetrics) bool {
+	if !m.scheduled {
+		return true
+	}
+	profileKey := m.scanName + "/" + m.clusterName
+	current, ok := c.metricsProfiles[profileKey]
+	if ok && current != m.scanProfileName {
+		currentKey := m.scanName + "/" + current + "/" + m.clusterName
+		if last, ok := c.metricsRuns[currentKey]; ok && last.startedAt.After(m.run.startedAt) {
+			return false
+		}
+		logrus.Infof("Moving the metric series of scan %v from ClusterScanProfile %v to %v", m.scanName, current, m.scanProfileName)
+		c.deleteProfileSeries(prometheus.Labels{"scan_name": m.scanName, "scan_profile_name": current, "cluster_name": m.clusterName})
+		delete(c.metricsRuns, currentKey)
+	}
+	c.metricsProfiles[profileKey] = m.scanProfileName
+
+	c.profileRenames.DeletePartialMatch(prometheus.Labels{"scan_name": m.scanName, "cluster_name": m.clusterName})
+	for _, rename := range m.profileRenames {
+		if rename.From != m.scanProfileName {
+			c.profileRenames.WithLabelValues(m.scanName, rename.From, m.scanProfileName, m.clusterName).Set(1)
+		}
+	}
+	return true
+}
+
+// deleteProfileSeries deletes the series of the scan metrics matching the labels
+func (c *Controller) deleteProfileSeries(labels prometheus.Labels) {
+	for _, vec := range []interface {
+		DeletePartialMatch(prometheus.Labels) int
+	}{
+		c.numTestsFailed,
+		c.numScansComplete,
+		c.numTestsTotal,
+		c.numTestsPassed,
+		c.numTestsSkipped,
+		c.numTestsNA,
+		c.numTestsWarn,
+		c.numTeamTestsFailed,
+		c.numTeamTestsTotal,
+		c.numFailureAgeDays,
+		c.numCheckMTTRSeconds,
+		c.numDriftedChecks,
+		c.numRegressions,
+		c.budgetExceeded,
+		c.numPostureDrift,
+		c.score,
+		c.trendScore,
+		c.trendNumTestsFailed,
+		c.numAPIRequests,
+		c.numPods,
+		c.runDurationSeconds,
+		c.apiRequestsTotal,
+		c.podsTotal,
+	} {
+		vec.DeletePartialMatch(labels)
+	}
+}
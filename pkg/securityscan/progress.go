@@ -0,0 +1,110 @@
+package securityscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	cisoperatorapi "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io"
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+)
+
+// annotation of the sonobuoy aggregator pod with the status of the plugins on every node
+const sonobuoyStatusAnnotation = "sonobuoy.hept.io/status"
+
+// statuses of a plugin on a node in the aggregator status, running otherwise
+const (
+	sonobuoyPluginComplete = "complete"
+	sonobuoyPluginFailed   = "failed"
+)
+
+type sonobuoyStatus struct {
+	Plugins []sonobuoyPluginStatus `json:"plugins"`
+}
+
+type sonobuoyPluginStatus struct {
+	Plugin string `json:"plugin"`
+	Node   string `json:"node"`
+	Status string `json:"status"`
+}
+
+// setScanPhase sets the phase of the progress of the scan, the time the phase started only changes with it
+func setScanPhase(scan *v1.ClusterScan, phase string, now time.Time) {
+	if scan.Status.Progress == nil {
+		scan.Status.Progress = &v1.ClusterScanProgress{}
+	}
+	if scan.Status.Progress.Phase != phase {
+		scan.Status.Progress.Phase = phase
+		scan.Status.Progress.PhaseStartedAt = now.Round(time.Second).Format(time.RFC3339)
+	}
+}
+
+// getNodeProgress counts the node workers of the scan done and failed, and their total, from the status annotation of
+// the aggregator pod, ok is false until the aggregator sets it
+func getNodeProgress(pod *corev1.Pod) (completed, failed, total int, ok bool, err error) {
+	annotation, ok := pod.Annotations[sonobuoyStatusAnnotation]
+	if !ok {
+		return 0, 0, 0, false, nil
+	}
+	status := sonobuoyStatus{}
+	if err := json.Unmarshal([]byte(annotation), &status); err != nil {
+		return 0, 0, 0, false, fmt.Errorf("error parsing %v annotation: %w", sonobuoyStatusAnnotation, err)
+	}
+	workerPlugins := map[string]bool{}
+	for _, plugin := range engine.SonobuoyWorkerPlugins {
+		workerPlugins[plugin] = true
+	}
+	for _, plugin := range status.Plugins {
+		if !workerPlugins[plugin.Plugin] {
+			continue
+		}
+		total++
+		switch plugin.Status {
+		case sonobuoyPluginComplete:
+			completed++
+		case sonobuoyPluginFailed:
+			completed++
+			failed++
+		}
+	}
+	return completed, failed, total, true, nil
+}
+
+// updateScanProgress sets the node progress of the scan running in the aggregator pod, moving it to the
+// ScanningNodes phase
+func (c *Controller) updateScanProgress(pod *corev1.Pod) error {
+	completed, failed, total, ok, err := getNodeProgress(pod)
+	if err != nil || !ok {
+		return err
+	}
+	scanName := pod.Labels[cisoperatorapi.LabelClusterScan]
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		scan, err := c.scans.Get(scanName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if !v1.ClusterScanConditionRunCompleted.IsUnknown(scan) {
+			return nil
+		}
+		progress := scan.Status.Progress
+		if progress != nil && progress.Phase == v1.ClusterScanPhaseScanningNodes &&
+			progress.NodesCompleted == completed && progress.NodesFailed == failed && progress.NodesTotal == total {
+			return nil
+		}
+		scan = scan.DeepCopy()
+		setScanPhase(scan, v1.ClusterScanPhaseScanningNodes, time.Now())
+		scan.Status.Progress.NodesCompleted = completed
+		scan.Status.Progress.NodesFailed = failed
+		scan.Status.Progress.NodesTotal = total
+		c.setClusterScanStatusDisplay(scan)
+		logrus.Debugf("Scan %v completed on %v of %v nodes", scanName, completed, total)
+		_, err = c.scans.UpdateStatus(scan)
+		return err
+	})
+}
@@ -0,0 +1,38 @@
+package securityscan
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+
+	cisoperatorapiv1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/fips"
+)
+
+// NewHTTPClient returns a client for the operator outbound requests, going through the configured
+// proxy if any, or else the one of the operator environment. TLS is restricted to the FIPS approved
+// settings in FIPS mode.
+func NewHTTPClient(proxy cisoperatorapiv1.ProxyConfig) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if fips.Enabled() {
+		transport.TLSClientConfig = &tls.Config{}
+		fips.ConfigureTLS(transport.TLSClientConfig)
+	}
+	if proxy.HTTPProxy != "" || proxy.HTTPSProxy != "" {
+		proxyFunc := (&httpproxy.Config{
+			HTTPProxy:  proxy.HTTPProxy,
+			HTTPSProxy: proxy.HTTPSProxy,
+			NoProxy:    proxy.NoProxy,
+		}).ProxyFunc()
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   time.Minute,
+	}
+}
@@ -0,0 +1,357 @@
+package securityscan
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rancher/wrangler/pkg/name"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	cisoperatorapi "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io"
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+)
+
+const (
+	defaultSinkPVCSize      = "1Gi"
+	defaultSinkPathTemplate = "{{.ScanName}}/{{.ReportName}}"
+	// how long a delivery waits for the job writing the files to the claim
+	sinkJobTimeout = 5 * time.Minute
+	// non-root user the sink job runs as, the claim is writable through the fsGroup
+	sinkJobUser = int64(1000)
+	// the files are staged in a ConfigMap, whose data the API server limits to 1MiB
+	maxSinkFilesBytes = 1 << 20
+)
+
+// sinkJobTTL leaves the completed sink jobs, and the ConfigMaps they own, around for an hour
+var sinkJobTTL int32 = 3600
+
+// sinkPath holds the values the path template of a claim target is rendered with
+type sinkPath struct {
+	ScanName        string
+	ScanProfileName string
+	ReportName      string
+	Timestamp       string
+}
+
+// writeSinkPVC writes the notification and the report to the claim of a subscription, done once the files are
+// written. The operator does not mount the claim itself: the files are staged in a ConfigMap that a job mounts along
+// with the claim to copy them to their directory.
+func (c *Controller) writeSinkPVC(sub *v1.ScanSubscription, scan *v1.ClusterScan, reportName string, data map[string]string) (bool, error) {
+	target := sub.Spec.Target.PersistentVolumeClaim
+	if target == nil {
+		return true, nil
+	}
+	dir, err := renderSinkPath(target, sinkPath{
+		ScanName:        scan.Name,
+		ScanProfileName: scan.Status.LastRunScanProfileName,
+		ReportName:      reportName,
+		Timestamp:       time.Now().UTC().Format("20060102T150405Z"),
+	})
+	if err != nil {
+		return false, err
+	}
+	if err := c.ensureSinkPVC(target); err != nil {
+		return false, err
+	}
+
+	files := map[string][]byte{}
+	notification, err := json.Marshal(data)
+	if err != nil {
+		return false, err
+	}
+	files["notification.json"] = notification
+	if reportName != "" {
+		reportFile, reportJSON, err := c.getSinkReportFile(reportName)
+		if err != nil {
+			return false, err
+		}
+		files[reportFile] = reportJSON
+	}
+	size := 0
+	for _, file := range files {
+		size += len(file)
+	}
+	if size > maxSinkFilesBytes {
+		return false, fmt.Errorf("the files written to PersistentVolumeClaim %v are %d bytes, more than the %d bytes staged in a ConfigMap: lower --report-compression-threshold to compress the report", target.ClaimName, size, maxSinkFilesBytes)
+	}
+
+	// one job per run of the scan, the run failed without a report has none
+	jobName := name.SafeConcatName("sink", sub.Name, scan.Name, name.Hex(scan.Status.LastRunTimestamp, 8))
+	job, err := c.jobs.Get(v1.ClusterScanNS, jobName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		job, err = c.createSinkJob(jobName, target.ClaimName, dir, files)
+	}
+	if err != nil {
+		return false, fmt.Errorf("error creating job %v writing to PersistentVolumeClaim %v: %w", jobName, target.ClaimName, err)
+	}
+	return c.checkSinkJob(job)
+}
+
+// renderSinkPath renders the directory of the files of a report, which must stay within the volume
+func renderSinkPath(target *v1.ScanSubscriptionPVCTarget, values sinkPath) (string, error) {
+	text := target.PathTemplate
+	if text == "" {
+		text = defaultSinkPathTemplate
+	}
+	tmpl, err := template.New("pathTemplate").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid pathTemplate: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, values); err != nil {
+		return "", fmt.Errorf("error rendering pathTemplate: %w", err)
+	}
+	dir := path.Clean(strings.TrimSpace(buf.String()))
+	if path.IsAbs(dir) || dir == "." || dir == ".." || strings.HasPrefix(dir, "../") {
+		return "", fmt.Errorf("pathTemplate renders %q, it must be a directory within the volume", buf.String())
+	}
+	return dir, nil
+}
+
+func (c *Controller) ensureSinkPVC(target *v1.ScanSubscriptionPVCTarget) error {
+	claims := c.kcs.CoreV1().PersistentVolumeClaims(v1.ClusterScanNS)
+	_, err := claims.Get(context.Background(), target.ClaimName, metav1.GetOptions{})
+	if !errors.IsNotFound(err) {
+		return err
+	}
+	size := target.Size
+	if size == "" {
+		size = defaultSinkPVCSize
+	}
+	quantity, err := resource.ParseQuantity(size)
+	if err != nil {
+		return fmt.Errorf("invalid size %q of PersistentVolumeClaim %v: %w", size, target.ClaimName, err)
+	}
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      target.ClaimName,
+			Namespace: v1.ClusterScanNS,
+			Labels: labels.Set{
+				cisoperatorapi.LabelController: c.Name,
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: quantity},
+			},
+		},
+	}
+	if target.StorageClassName != "" {
+		claim.Spec.StorageClassName = &target.StorageClassName
+	}
+	_, err = claims.Create(context.Background(), claim, metav1.CreateOptions{})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("error creating PersistentVolumeClaim %v: %w", target.ClaimName, err)
+	}
+	return nil
+}
+
+// getSinkReportFile returns the report JSON with its shards merged, compressed as in the report when it is
+// larger than the compression threshold
+func (c *Controller) getSinkReportFile(reportName string) (string, []byte, error) {
+	report, err := c.cisFactory.Cis().V1().ClusterScanReport().Get(reportName, metav1.GetOptions{})
+	if err != nil {
+		return "", nil, fmt.Errorf("error getting ClusterScanReport %v: %w", reportName, err)
+	}
+	reportJSON, err := report.Spec.GetReportJSON()
+	if err != nil {
+		return "", nil, fmt.Errorf("error reading ClusterScanReport %v: %w", reportName, err)
+	}
+	if report.Spec.Sharded {
+		shardList, err := c.cisFactory.Cis().V1().ClusterScanReportShard().List(metav1.ListOptions{})
+		if err != nil {
+			return "", nil, fmt.Errorf("error listing ClusterScanReportShards: %w", err)
+		}
+		var shards []v1.ClusterScanReportShardSpec
+		for _, shard := range shardList.Items {
+			if shard.Spec.ReportName == reportName {
+				shards = append(shards, shard.Spec)
+			}
+		}
+		if reportJSON, err = engine.MergeReportShards(reportJSON, shards); err != nil {
+			return "", nil, fmt.Errorf("error merging shards of ClusterScanReport %v: %w", reportName, err)
+		}
+	}
+	threshold := c.getImageConfig().ReportCompressionThreshold
+	if threshold < 0 || len(reportJSON) <= threshold {
+		return "report.json", reportJSON, nil
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(reportJSON); err != nil {
+		return "", nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return "", nil, err
+	}
+	return "report.json.gz", buf.Bytes(), nil
+}
+
+// createSinkJob creates the job copying the files to dir on the claim, and the ConfigMap staging them, owned
+// by the job. The job pod starts once the ConfigMap it mounts exists.
+func (c *Controller) createSinkJob(jobName, claimName, dir string, files map[string][]byte) (*batchv1.Job, error) {
+	backoffLimit := int32(2)
+	runAsNonRoot := true
+	user := sinkJobUser
+	allowPrivilegeEscalation := false
+	readOnlyRootFilesystem := true
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: v1.ClusterScanNS,
+			// not labelled with the controller, whose jobs are the scan runners
+			Labels: labels.Set{
+				"app.kubernetes.io/name":      "rancher-cis-benchmark",
+				"app.kubernetes.io/component": "sink",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			TTLSecondsAfterFinished: &sinkJobTTL,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:    corev1.RestartPolicyNever,
+					ImagePullSecrets: c.getImageConfig().ImagePullSecretRefs(),
+					NodeSelector: labels.Set{
+						"kubernetes.io/os": "linux",
+					},
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: &runAsNonRoot,
+						RunAsUser:    &user,
+						RunAsGroup:   &user,
+						FSGroup:      &user,
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
+						},
+					},
+					Containers: []corev1.Container{{
+						Name:            "sink",
+						Image:           c.getImageConfig().ImageRef(c.getImageConfig().SecurityScanImage, c.getImageConfig().SecurityScanImageTag),
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Command:         []string{"/bin/sh", "-c", `mkdir -p "/sink/$SINK_DIR" && cp -L /files/* "/sink/$SINK_DIR/"`},
+						Env: []corev1.EnvVar{{
+							Name:  "SINK_DIR",
+							Value: dir,
+						}},
+						SecurityContext: &corev1.SecurityContext{
+							ReadOnlyRootFilesystem:   &readOnlyRootFilesystem,
+							AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+							Capabilities: &corev1.Capabilities{
+								Drop: []corev1.Capability{"ALL"},
+							},
+						},
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      "files",
+							MountPath: "/files",
+							ReadOnly:  true,
+						}, {
+							Name:      "sink",
+							MountPath: "/sink",
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "files",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: jobName},
+							},
+						},
+					}, {
+						Name: "sink",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+						},
+					}},
+				},
+			},
+		},
+	}
+	created, err := c.jobs.Create(job)
+	if err != nil {
+		return nil, err
+	}
+	_, err = c.configmaps.Create(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: v1.ClusterScanNS,
+			Labels: labels.Set{
+				cisoperatorapi.LabelController: c.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "batch/v1",
+				Kind:       "Job",
+				Name:       created.Name,
+				UID:        created.UID,
+			}},
+		},
+		BinaryData: files,
+	})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return nil, err
+	}
+	return created, nil
+}
+
+// checkSinkJob returns whether the job writing the files completed. A job that failed, or did not complete within
+// sinkJobTimeout, is deleted so that the next attempt of the delivery creates it again.
+func (c *Controller) checkSinkJob(job *batchv1.Job) (bool, error) {
+	current, err := c.jobs.Get(job.Namespace, job.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	var jobErr error
+	for _, cond := range current.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			jobErr = fmt.Errorf("job %v failed: %v", job.Name, cond.Message)
+		}
+	}
+	if jobErr == nil && !current.CreationTimestamp.IsZero() && time.Since(current.CreationTimestamp.Time) > sinkJobTimeout {
+		jobErr = fmt.Errorf("job %v did not complete within %v", job.Name, sinkJobTimeout)
+	}
+	if jobErr == nil {
+		return false, nil
+	}
+	propagation := metav1.DeletePropagationBackground
+	if deleteErr := c.jobs.Delete(job.Namespace, job.Name, &metav1.DeleteOptions{PropagationPolicy: &propagation}); deleteErr != nil && !errors.IsNotFound(deleteErr) {
+		return false, fmt.Errorf("%v, error deleting it: %v", jobErr, deleteErr)
+	}
+	return false, jobErr
+}
+
+// validateScanSubscriptionTarget checks the settings of the claim target that can be checked before a delivery
+func validateScanSubscriptionTarget(target *v1.ScanSubscriptionTarget) error {
+	pvc := target.PersistentVolumeClaim
+	if pvc == nil {
+		return nil
+	}
+	if pvc.ClaimName == "" {
+		return fmt.Errorf("persistentVolumeClaim claimName is required")
+	}
+	if pvc.Size != "" {
+		if _, err := resource.ParseQuantity(pvc.Size); err != nil {
+			return fmt.Errorf("invalid persistentVolumeClaim size %q: %w", pvc.Size, err)
+		}
+	}
+	_, err := renderSinkPath(pvc, sinkPath{ScanName: "scan", ScanProfileName: "profile", ReportName: "report", Timestamp: "timestamp"})
+	return err
+}
@@ -0,0 +1,52 @@
+package securityscan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// checkRegressionBudget compares the checks newly failing in the last run of a scan against the regression
+// budget of its schedule: a run exceeding the budget is marked failed, one within it only warns
+func checkRegressionBudget(scan *v1.ClusterScan, diff *v1.ClusterScanReportDiff) {
+	if !hasRegressionBudget(scan) {
+		return
+	}
+	if diff == nil {
+		v1.ClusterScanConditionRegressionBudgetExceeded.Unknown(scan)
+		v1.ClusterScanConditionRegressionBudgetExceeded.Message(scan, "first run of the scan")
+		return
+	}
+	budget := *scan.Spec.ScheduledScanConfig.RegressionBudget
+	message := fmt.Sprintf("%d checks newly failing since %v, regression budget of %d", len(diff.NewlyFailing), diff.PreviousReport, budget)
+	if len(diff.NewlyFailing) > 0 {
+		message += ": " + strings.Join(diff.NewlyFailing, ", ")
+	}
+	if len(diff.NewlyFailing) > budget {
+		logrus.Errorf("Scan %v exceeded its regression budget, %v", scan.Name, message)
+		v1.ClusterScanConditionRegressionBudgetExceeded.True(scan)
+		v1.ClusterScanConditionRegressionBudgetExceeded.Message(scan, message)
+		v1.ClusterScanConditionFailed.True(scan)
+		v1.ClusterScanConditionFailed.Reason(scan, v1.ClusterScanReasonRegressionBudgetExceeded)
+		v1.ClusterScanConditionFailed.Message(scan, message)
+		return
+	}
+	if len(diff.NewlyFailing) > 0 {
+		logrus.Warnf("Scan %v is within its regression budget, %v", scan.Name, message)
+	}
+	v1.ClusterScanConditionRegressionBudgetExceeded.False(scan)
+	v1.ClusterScanConditionRegressionBudgetExceeded.Message(scan, message)
+}
+
+func hasRegressionBudget(scan *v1.ClusterScan) bool {
+	return scan.Spec.ScheduledScanConfig != nil && scan.Spec.ScheduledScanConfig.RegressionBudget != nil
+}
+
+// isRegressionBudgetFailure returns whether the last run of the scan only failed for exceeding its regression
+// budget, its results are then complete
+func isRegressionBudgetFailure(scan *v1.ClusterScan) bool {
+	return v1.ClusterScanConditionFailed.IsTrue(scan) && v1.ClusterScanConditionFailed.GetReason(scan) == v1.ClusterScanReasonRegressionBudgetExceeded
+}
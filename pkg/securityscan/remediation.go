@@ -0,0 +1,249 @@
+package securityscan
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rancher/wrangler/pkg/name"
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+const remediationLabel = "cis.cattle.io/remediation"
+
+// remediation jobs are kept for a day after they finish, for their logs
+var remediationJobTTL = int32(24 * 60 * 60)
+
+// runRemediations launches, when enabled on the operator, the workload of every ClusterScanRemediation selecting the
+// scan and remediating one of its failing checks, and records the execution in the status of the remediation
+func (c *Controller) runRemediations(scan *v1.ClusterScan, reportName string, states map[string]string) {
+	if !c.getImageConfig().RemediationEnabled {
+		return
+	}
+	remediationList, err := c.remediationCache.List(labels.Everything())
+	if err != nil {
+		logrus.Errorf("Error listing ClusterScanRemediations for scan %v: %v", scan.Name, err)
+		return
+	}
+	for _, remediation := range remediationList {
+		selector := labels.Everything()
+		if remediation.Spec.ScanSelector != nil {
+			selector, err = metav1.LabelSelectorAsSelector(remediation.Spec.ScanSelector)
+			if err != nil {
+				logrus.Errorf("Skipping ClusterScanRemediation %v with an invalid scanSelector: %v", remediation.Name, err)
+				continue
+			}
+		}
+		if !selector.Matches(labels.Set(scan.Labels)) {
+			continue
+		}
+		var failing []string
+		for _, testID := range remediation.Spec.TestIDs {
+			if states[testID] == "fail" || states[testID] == "mixed" {
+				failing = append(failing, testID)
+			}
+		}
+		if len(failing) == 0 {
+			continue
+		}
+		sort.Strings(failing)
+		execution := c.executeRemediation(remediation, scan, reportName, failing)
+		if err := c.recordRemediationExecution(remediation.Name, execution); err != nil {
+			logrus.Errorf("Error recording the execution of ClusterScanRemediation %v: %v", remediation.Name, err)
+		}
+	}
+}
+
+// executeRemediation launches the workload of the remediation, unless it is a dry run, and returns its execution
+func (c *Controller) executeRemediation(remediation *v1.ClusterScanRemediation, scan *v1.ClusterScan, reportName string, testIDs []string) v1.ClusterScanRemediationExecution {
+	execution := v1.ClusterScanRemediationExecution{
+		Timestamp:  time.Now().Round(time.Second).Format(time.RFC3339),
+		ScanName:   scan.Name,
+		ReportName: reportName,
+		TestIDs:    testIDs,
+		Workload:   getRemediationWorkload(remediation),
+		DryRun:     remediation.Spec.DryRun,
+	}
+	for _, container := range remediation.Spec.Template.Spec.Containers {
+		execution.Images = append(execution.Images, container.Image)
+	}
+	if err := validateClusterScanRemediation(remediation); err != nil {
+		execution.Error = err.Error()
+		return execution
+	}
+	if remediation.Spec.DryRun {
+		logrus.Infof("Dry run of ClusterScanRemediation %v for the checks %v failing in scan %v", remediation.Name, testIDs, scan.Name)
+		return execution
+	}
+
+	var err error
+	switch execution.Workload {
+	case v1.RemediationWorkloadDaemonSet:
+		execution.WorkloadName, err = c.applyRemediationDaemonSet(remediation)
+	default:
+		execution.WorkloadName, err = c.createRemediationJob(remediation, scan)
+	}
+	if err != nil {
+		execution.Error = err.Error()
+		logrus.Errorf("Error launching ClusterScanRemediation %v for scan %v: %v", remediation.Name, scan.Name, err)
+		return execution
+	}
+	logrus.Infof("Launched %v %v of ClusterScanRemediation %v for the checks %v failing in scan %v", execution.Workload, execution.WorkloadName, remediation.Name, testIDs, scan.Name)
+	return execution
+}
+
+func getRemediationWorkload(remediation *v1.ClusterScanRemediation) string {
+	if remediation.Spec.Workload == "" {
+		return v1.RemediationWorkloadJob
+	}
+	return remediation.Spec.Workload
+}
+
+// validateClusterScanRemediation checks the remediation names the checks it remediates and a workload to launch
+func validateClusterScanRemediation(remediation *v1.ClusterScanRemediation) error {
+	if len(remediation.Spec.TestIDs) == 0 {
+		return fmt.Errorf("no testIDs")
+	}
+	switch remediation.Spec.Workload {
+	case "", v1.RemediationWorkloadJob, v1.RemediationWorkloadDaemonSet:
+	default:
+		return fmt.Errorf("invalid workload %q, must be %v or %v", remediation.Spec.Workload, v1.RemediationWorkloadJob, v1.RemediationWorkloadDaemonSet)
+	}
+	podSpec := &remediation.Spec.Template.Spec
+	if len(podSpec.Containers) == 0 {
+		return fmt.Errorf("no container in the template")
+	}
+	// the workloads run in the operator namespace, they must not run as, or get the token of, a service account
+	// with permissions such as the operator's own
+	if podSpec.ServiceAccountName != "" || podSpec.DeprecatedServiceAccount != "" {
+		return fmt.Errorf("the template must not set a serviceAccountName, the workloads run without a service account token")
+	}
+	if podSpec.AutomountServiceAccountToken != nil && *podSpec.AutomountServiceAccountToken {
+		return fmt.Errorf("the template must not set automountServiceAccountToken, the workloads run without a service account token")
+	}
+	for _, volume := range podSpec.Volumes {
+		if volume.Projected == nil {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if source.ServiceAccountToken != nil {
+				return fmt.Errorf("the template must not mount a service account token, in volume %v", volume.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// getRemediationObjectMeta returns the metadata of the workloads of the remediation, owned by the remediation and
+// not labelled with the controller, whose jobs are the scan runners
+func getRemediationObjectMeta(remediation *v1.ClusterScanRemediation) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: v1.ClusterScanNS,
+		Labels: labels.Set{
+			"app.kubernetes.io/name":      "rancher-cis-benchmark",
+			"app.kubernetes.io/component": "remediation",
+			remediationLabel:              remediation.Name,
+		},
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: "cis.cattle.io/v1",
+			Kind:       "ClusterScanRemediation",
+			Name:       remediation.Name,
+			UID:        remediation.UID,
+		}},
+	}
+}
+
+// getRemediationPodTemplate returns the pod template of the remediation labelled as its workloads, run by the
+// default service account of the operator namespace without its token
+func getRemediationPodTemplate(remediation *v1.ClusterScanRemediation, restartPolicy corev1.RestartPolicy) corev1.PodTemplateSpec {
+	template := *remediation.Spec.Template.DeepCopy()
+	if template.Labels == nil {
+		template.Labels = map[string]string{}
+	}
+	template.Labels[remediationLabel] = remediation.Name
+	template.Spec.RestartPolicy = restartPolicy
+	automountServiceAccountToken := false
+	template.Spec.ServiceAccountName = ""
+	template.Spec.DeprecatedServiceAccount = ""
+	template.Spec.AutomountServiceAccountToken = &automountServiceAccountToken
+	return template
+}
+
+func (c *Controller) createRemediationJob(remediation *v1.ClusterScanRemediation, scan *v1.ClusterScan) (string, error) {
+	backoffLimit := int32(0)
+	job := &batchv1.Job{
+		ObjectMeta: getRemediationObjectMeta(remediation),
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			TTLSecondsAfterFinished: &remediationJobTTL,
+			Template:                getRemediationPodTemplate(remediation, corev1.RestartPolicyNever),
+		},
+	}
+	job.GenerateName = name.SafeConcatName("remediation", remediation.Name, scan.Name) + "-"
+	created, err := c.jobs.Create(job)
+	if err != nil {
+		return "", err
+	}
+	return created.Name, nil
+}
+
+// applyRemediationDaemonSet creates the DaemonSet of the remediation, or updates it to roll its pods out again. Its
+// pods are restarted when their containers exit, the containers must keep running once the node is remediated.
+func (c *Controller) applyRemediationDaemonSet(remediation *v1.ClusterScanRemediation) (string, error) {
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: getRemediationObjectMeta(remediation),
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{remediationLabel: remediation.Name},
+			},
+			Template: getRemediationPodTemplate(remediation, corev1.RestartPolicyAlways),
+		},
+	}
+	daemonSet.Name = name.SafeConcatName("remediation", remediation.Name)
+	// a new run of the same template still restarts the pods
+	if daemonSet.Spec.Template.Annotations == nil {
+		daemonSet.Spec.Template.Annotations = map[string]string{}
+	}
+	daemonSet.Spec.Template.Annotations["cis.cattle.io/remediated-at"] = time.Now().Format(time.RFC3339)
+
+	existing, err := c.daemonsets.Get(v1.ClusterScanNS, daemonSet.Name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		_, err = c.daemonsets.Create(daemonSet)
+		return daemonSet.Name, err
+	} else if err != nil {
+		return "", err
+	}
+	existing = existing.DeepCopy()
+	existing.Labels = daemonSet.Labels
+	existing.Spec.Template = daemonSet.Spec.Template
+	_, err = c.daemonsets.Update(existing)
+	return daemonSet.Name, err
+}
+
+// recordRemediationExecution appends the execution to the status of the remediation, keeping the last ones
+func (c *Controller) recordRemediationExecution(remediationName string, execution v1.ClusterScanRemediationExecution) error {
+	remediations := c.cisFactory.Cis().V1().ClusterScanRemediation()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		remediation, err := remediations.Get(remediationName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		remediation = remediation.DeepCopy()
+		executions := append(remediation.Status.Executions, execution)
+		if len(executions) > v1.MaxRemediationExecutions {
+			executions = executions[len(executions)-v1.MaxRemediationExecutions:]
+		}
+		remediation.Status.Executions = executions
+		_, err = remediations.UpdateStatus(remediation)
+		return err
+	})
+}
@@ -0,0 +1,64 @@
+package securityscan
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+)
+
+// diffPreviousReport compares the check states of the new report of a scan against its previous
+// report, it returns nil when the scan has no previous report
+func (c *Controller) diffPreviousReport(scan *v1.ClusterScan, states map[string]string) (*v1.ClusterScanReportDiff, error) {
+	previous, err := c.getLatestClusterScanReport(scan)
+	if err != nil || previous == nil {
+		return nil, err
+	}
+	reportJSON, err := previous.Spec.GetReportJSON()
+	if err != nil {
+		return nil, fmt.Errorf("error reading previous report %v: %w", previous.Name, err)
+	}
+	previousStates, err := engine.GetCheckStates(reportJSON)
+	if err != nil {
+		return nil, fmt.Errorf("error reading check states of previous report %v: %w", previous.Name, err)
+	}
+	// a partial scan is compared on its included checks only
+	if len(scan.Spec.IncludeChecks) > 0 {
+		for id := range previousStates {
+			if !engine.IsIncludedCheck(id, scan.Spec.IncludeChecks) {
+				delete(previousStates, id)
+			}
+		}
+	}
+	return engine.DiffCheckStates(previous.Name, previousStates, states), nil
+}
+
+// getLatestClusterScanReport returns the last report owned by the scan for its own profile, nil if it has none
+func (c *Controller) getLatestClusterScanReport(scan *v1.ClusterScan) (*v1.ClusterScanReport, error) {
+	reports, err := c.cisFactory.Cis().V1().ClusterScanReport().Cache().List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("error listing ClusterScanReports: %w", err)
+	}
+	var latest *v1.ClusterScanReport
+	for _, report := range reports {
+		if !isOwnedByScan(report.OwnerReferences, scan) || report.Spec.AdditionalProfile {
+			continue
+		}
+		if latest == nil || latest.CreationTimestamp.Before(&report.CreationTimestamp) {
+			latest = report
+		}
+	}
+	return latest, nil
+}
+
+func isOwnedByScan(owners []metav1.OwnerReference, scan *v1.ClusterScan) bool {
+	for _, owner := range owners {
+		if owner.Kind == "ClusterScan" && owner.UID == scan.UID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package securityscan
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+)
+
+const (
+	// the stderr of a failing report plugin is cut to this many bytes in its error
+	maxReportPluginStderr = 1024
+	// how long the output of a timed out plugin is waited for, when its children keep it open
+	reportPluginWaitDelay = 5 * time.Second
+)
+
+// runReportPlugins pipes the report JSON through the report plugins of the operator, in order. A plugin gets the
+// report on its input and the scan in its environment, and writes the transformed report on its output, which must
+// still be a report. The output of a failing plugin is discarded, the next plugin gets the report of the previous
+// one. Returns the report, the plugins it went through and the errors of the others.
+func (c *Controller) runReportPlugins(ctx context.Context, scan *v1.ClusterScan, profile *v1.ClusterScanProfile, data []byte) ([]byte, []string, []string) {
+	var processedBy, pluginErrors []string
+	for _, plugin := range c.getImageConfig().ReportPlugins {
+		output, err := c.runReportPlugin(ctx, plugin, scan, profile, data)
+		if err != nil {
+			logrus.Errorf("Error running report plugin %v on the report of scan %v: %v", plugin, scan.Name, err)
+			pluginErrors = append(pluginErrors, fmt.Sprintf("%v: %v", plugin, err))
+			continue
+		}
+		data = output
+		processedBy = append(processedBy, plugin)
+	}
+	return data, processedBy, pluginErrors
+}
+
+func (c *Controller) runReportPlugin(ctx context.Context, plugin string, scan *v1.ClusterScan, profile *v1.ClusterScanProfile, data []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.getImageConfig().ReportPluginTimeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, plugin)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = reportPluginWaitDelay
+	cmd.Env = append(os.Environ(),
+		"CIS_SCAN_NAME="+scan.Name,
+		"CIS_SCAN_PROFILE_NAME="+profile.Name,
+		"CIS_BENCHMARK_VERSION="+profile.Spec.BenchmarkVersion,
+		"CIS_CLUSTER_NAME="+c.getImageConfig().ClusterName,
+	)
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("timed out after %v", c.getImageConfig().ReportPluginTimeout)
+		}
+		message := strings.TrimSpace(stderr.String())
+		if len(message) > maxReportPluginStderr {
+			message = message[:maxReportPluginStderr]
+		}
+		if message != "" {
+			return nil, fmt.Errorf("%w: %v", err, message)
+		}
+		return nil, err
+	}
+	output := bytes.TrimSpace(stdout.Bytes())
+	if len(output) == 0 {
+		return nil, fmt.Errorf("empty output")
+	}
+	if _, err := engine.GetCheckStates(output); err != nil {
+		return nil, fmt.Errorf("output is not a report: %w", err)
+	}
+	return output, nil
+}
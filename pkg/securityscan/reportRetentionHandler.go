@@ -0,0 +1,50 @@
+package securityscan
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// report events delete the reports past the retention days of their scan, or of the operator when
+// the scan does not set any or is gone. The other reports are enqueued again for when they expire,
+// the baseline report of a scan is kept.
+func (c *Controller) handleClusterScanReportRetention(ctx context.Context) error {
+	reports := c.cisFactory.Cis().V1().ClusterScanReport()
+
+	reports.OnChange(ctx, c.Name, func(key string, obj *v1.ClusterScanReport) (*v1.ClusterScanReport, error) {
+		if obj == nil || obj.DeletionTimestamp != nil {
+			return obj, nil
+		}
+		if !c.ownsName(getReportScanName(obj)) {
+			return obj, nil
+		}
+		retentionDays := c.getImageConfig().ReportRetentionDays
+		if scan, err := c.scans.Cache().Get(getReportScanName(obj)); err == nil {
+			if scan.Spec.BaselineReportName == obj.Name {
+				return obj, nil
+			}
+			retentionDays = c.getRetentionDays(scan)
+		}
+		if retentionDays <= 0 {
+			return obj, nil
+		}
+		expiry := obj.CreationTimestamp.AddDate(0, 0, retentionDays)
+		if remaining := time.Until(expiry); remaining > 0 {
+			reports.EnqueueAfter(obj.Name, remaining)
+			return obj, nil
+		}
+
+		logrus.Infof("Deleting ClusterScanReport %v created %v, older than %v days", obj.Name, obj.CreationTimestamp.String(), retentionDays)
+		if err := c.deleteClusterScanReportWithRetry(obj.Name); err != nil && !errors.IsNotFound(err) {
+			return obj, fmt.Errorf("error deleting expired ClusterScanReport %v: %w", obj.Name, err)
+		}
+		return obj, nil
+	})
+	return nil
+}
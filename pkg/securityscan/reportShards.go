@@ -0,0 +1,59 @@
+package securityscan
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rancher/wrangler/pkg/name"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+)
+
+// createClusterScanReportShards stores the shards of a sharded report, owned by the report so that
+// they are deleted along with it, and lists them in the status of the report
+func (c *Controller) createClusterScanReportShards(report *v1.ClusterScanReport, shards []v1.ClusterScanReportShardSpec) error {
+	if len(shards) == 0 {
+		return nil
+	}
+	reportShards := c.cisFactory.Cis().V1().ClusterScanReportShard()
+	statuses := make([]v1.ClusterScanReportShardStatus, 0, len(shards))
+	for _, spec := range shards {
+		spec.ReportName = report.Name
+		shard := &v1.ClusterScanReportShard{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name.SafeConcatName(report.Name, "shard", strconv.Itoa(spec.Index)),
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "cis.cattle.io/v1",
+					Kind:       "ClusterScanReport",
+					Name:       report.Name,
+					UID:        report.UID,
+				}},
+			},
+			Spec: spec,
+		}
+		created, err := reportShards.Create(shard)
+		if errors.IsAlreadyExists(err) {
+			// created by an earlier attempt to complete the run
+			created, err = reportShards.Get(shard.Name, metav1.GetOptions{})
+		}
+		if err != nil {
+			return fmt.Errorf("error creating shard %v: %w", shard.Name, err)
+		}
+		statuses = append(statuses, engine.GetShardStatus(created.Name, &created.Spec))
+	}
+
+	reports := c.cisFactory.Cis().V1().ClusterScanReport()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := reports.Get(report.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current.Status.Shards = statuses
+		_, err = reports.UpdateStatus(current)
+		return err
+	})
+}
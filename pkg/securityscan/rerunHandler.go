@@ -0,0 +1,55 @@
+package securityscan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// scan events launch a new run of the complete scans annotated with cis.cattle.io/rerun, and remove
+// the annotation. The annotation is kept on running, cancelled and suspended scans until they can run.
+func (c *Controller) handleClusterScanReruns(ctx context.Context) error {
+	scans := c.cisFactory.Cis().V1().ClusterScan()
+
+	scans.OnChange(ctx, c.Name, func(key string, obj *v1.ClusterScan) (*v1.ClusterScan, error) {
+		if obj == nil || obj.DeletionTimestamp != nil {
+			return obj, nil
+		}
+		if !c.ownsName(obj.Name) {
+			return obj, nil
+		}
+		rerun, ok := obj.Annotations[v1.ClusterScanRerunAnnotation]
+		if !ok {
+			return obj, nil
+		}
+		if rerun == "true" {
+			if obj.Spec.Cancel || isSuspendedScan(obj) || !isRerunnableScan(obj) {
+				return obj, nil
+			}
+			logrus.Infof("Running scan %v again as requested by its %v annotation", obj.Name, v1.ClusterScanRerunAnnotation)
+			if err := c.rerunScan(obj.Name); err != nil {
+				return obj, fmt.Errorf("error running scan %v again: %w", obj.Name, err)
+			}
+		}
+		var updated *v1.ClusterScan
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			scan, err := scans.Get(obj.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			delete(scan.Annotations, v1.ClusterScanRerunAnnotation)
+			updated, err = scans.Update(scan)
+			return err
+		})
+		if err != nil {
+			return obj, fmt.Errorf("error removing the %v annotation of scan %v: %w", v1.ClusterScanRerunAnnotation, obj.Name, err)
+		}
+		return updated, nil
+	})
+	return nil
+}
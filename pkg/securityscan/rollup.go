@@ -0,0 +1,295 @@
+package securityscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	cisctlv1 "github.com/rancher/cis-operator/pkg/generated/controllers/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+	cisscan "github.com/rancher/cis-operator/pkg/securityscan/scan"
+)
+
+// rollupTopFailingChecks is the number of checks listed in the top failing checks of a rollup
+const rollupTopFailingChecks = 10
+
+// scanRollup aggregates the reports of the selected scans over a period
+type scanRollup struct {
+	PeriodStart string `json:"periodStart"`
+	PeriodEnd   string `json:"periodEnd"`
+	Runs        int    `json:"runs"`
+	// summary of every report of the period, oldest first
+	Trend            []rollupRun   `json:"trend"`
+	TopFailingChecks []rollupCheck `json:"topFailingChecks"`
+	// checks failing then passing again during the period, and the mean time it took
+	RemediatedChecks           int   `json:"remediatedChecks"`
+	MeanTimeToRemediateSeconds int64 `json:"meanTimeToRemediateSeconds"`
+	// mean time to remediate per check and per section
+	ChecksMTTR   []rollupMTTR `json:"checksMTTR"`
+	SectionsMTTR []rollupMTTR `json:"sectionsMTTR"`
+}
+
+type rollupMTTR struct {
+	ID                         string `json:"id"`
+	Remediations               int    `json:"remediations"`
+	MeanTimeToRemediateSeconds int64  `json:"meanTimeToRemediateSeconds"`
+}
+
+type rollupRun struct {
+	Report    string                 `json:"report"`
+	Scan      string                 `json:"scan"`
+	Timestamp string                 `json:"timestamp"`
+	Summary   *v1.ClusterScanSummary `json:"summary,omitempty"`
+}
+
+type rollupCheck struct {
+	ID         string `json:"id"`
+	FailedRuns int    `json:"failedRuns"`
+}
+
+// syncScanRollup delivers the rollup of a subscription when it is due and schedules the next one
+func (c *Controller) syncScanRollup(subscriptions cisctlv1.ScanSubscriptionController, obj *v1.ScanSubscription) (*v1.ScanSubscription, error) {
+	schedule, err := cisscan.ParseCronSchedule(obj.Spec.Rollup.CronSchedule, "")
+	if err != nil {
+		message := fmt.Sprintf("invalid rollup cronSchedule: %v", err)
+		if v1.ScanSubscriptionConditionDelivered.IsFalse(obj) && v1.ScanSubscriptionConditionDelivered.GetMessage(obj) == message {
+			return obj, nil
+		}
+		objCopy := obj.DeepCopy()
+		v1.ScanSubscriptionConditionDelivered.False(objCopy)
+		v1.ScanSubscriptionConditionDelivered.Message(objCopy, message)
+		return subscriptions.UpdateStatus(objCopy)
+	}
+	now := time.Now()
+	if obj.Status.NextRollupAt == "" {
+		objCopy := obj.DeepCopy()
+		objCopy.Status.NextRollupAt = schedule.Next(now).Format(time.RFC3339)
+		return subscriptions.UpdateStatus(objCopy)
+	}
+	nextRollupAt, err := time.Parse(time.RFC3339, obj.Status.NextRollupAt)
+	if err != nil {
+		return obj, fmt.Errorf("error parsing nextRollupAt %v of ScanSubscription %v: %w", obj.Status.NextRollupAt, obj.Name, err)
+	}
+	if nextRollupAt.After(now) {
+		subscriptions.EnqueueAfter(obj.Name, nextRollupAt.Sub(now))
+		return obj, nil
+	}
+
+	logrus.Infof("Delivering scan rollup to ScanSubscription %v", obj.Name)
+	start := time.Now()
+	deliveryErr := c.deliverScanRollup(obj, now)
+	c.sinkDeliveryDuration.WithLabelValues(obj.Name, c.getImageConfig().ClusterName).Observe(time.Since(start).Seconds())
+	if deliveryErr != nil {
+		c.numSinkDeliveries.WithLabelValues(obj.Name, "failure", c.getImageConfig().ClusterName).Inc()
+	} else {
+		c.numSinkDeliveries.WithLabelValues(obj.Name, "success", c.getImageConfig().ClusterName).Inc()
+	}
+	var updated *v1.ScanSubscription
+	updateErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		sub, err := subscriptions.Get(obj.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if deliveryErr != nil {
+			v1.ScanSubscriptionConditionDelivered.False(sub)
+			v1.ScanSubscriptionConditionDelivered.Message(sub, fmt.Sprintf("Error delivering rollup: %v", deliveryErr))
+		} else {
+			v1.ScanSubscriptionConditionDelivered.True(sub)
+			v1.ScanSubscriptionConditionDelivered.Message(sub, "")
+			sub.Status.LastNotifiedTimestamp = now.Round(time.Second).Format(time.RFC3339)
+			sub.Status.NextRollupAt = schedule.Next(now).Format(time.RFC3339)
+		}
+		updated, err = subscriptions.UpdateStatus(sub)
+		return err
+	})
+	if deliveryErr != nil {
+		return obj, fmt.Errorf("error delivering rollup to ScanSubscription %v: %w", obj.Name, deliveryErr)
+	}
+	if updateErr != nil {
+		return obj, fmt.Errorf("error updating status of ScanSubscription %v: %w", obj.Name, updateErr)
+	}
+	return updated, nil
+}
+
+func (c *Controller) deliverScanRollup(sub *v1.ScanSubscription, now time.Time) error {
+	if err := c.injectFault("delivering the rollup of ScanSubscription " + sub.Name); err != nil {
+		return err
+	}
+	periodDays := v1.DefaultRollupPeriodDays
+	if c.getImageConfig().RollupPeriodDays > 0 {
+		periodDays = c.getImageConfig().RollupPeriodDays
+	}
+	if sub.Spec.Rollup.PeriodDays > 0 {
+		periodDays = sub.Spec.Rollup.PeriodDays
+	}
+	rollup, err := c.buildScanRollup(sub, now.AddDate(0, 0, -periodDays), now)
+	if err != nil {
+		return err
+	}
+	rollupJSON, err := json.Marshal(rollup)
+	if err != nil {
+		return err
+	}
+	return c.writeSinkConfigMap(sub, map[string]string{
+		"periodStart": rollup.PeriodStart,
+		"periodEnd":   rollup.PeriodEnd,
+		"rollup":      string(rollupJSON),
+	})
+}
+
+// buildScanRollup aggregates the reports created during the period by the scans the subscription selects
+func (c *Controller) buildScanRollup(sub *v1.ScanSubscription, periodStart, periodEnd time.Time) (*scanRollup, error) {
+	selector, err := getScanSubscriptionSelector(sub)
+	if err != nil {
+		return nil, err
+	}
+	reportList, err := c.cisFactory.Cis().V1().ClusterScanReport().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing cluster scan reports: %w", err)
+	}
+	reports := reportList.Items
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].CreationTimestamp.Before(&reports[j].CreationTimestamp)
+	})
+
+	rollup := &scanRollup{
+		PeriodStart:      periodStart.Round(time.Second).Format(time.RFC3339),
+		PeriodEnd:        periodEnd.Round(time.Second).Format(time.RFC3339),
+		Trend:            []rollupRun{},
+		TopFailingChecks: []rollupCheck{},
+	}
+	failedRuns := map[string]int{}
+	// per scan, when the checks failing in its last report started failing
+	failingSince := map[string]map[string]time.Time{}
+	var remediationTime time.Duration
+	checkRemediations := map[string]v1.CheckRemediation{}
+	for _, report := range reports {
+		created := report.CreationTimestamp.Time
+		// the reports of the additional profiles of a scan would count its runs twice
+		if created.Before(periodStart) || created.After(periodEnd) || report.Spec.AdditionalProfile {
+			continue
+		}
+		scanName := getReportScanName(&report)
+		if !c.scanMatchesSelector(scanName, selector) {
+			continue
+		}
+		reportJSON, err := report.Spec.GetReportJSON()
+		if err != nil {
+			logrus.Debugf("Skipping report %v in rollup: %v", report.Name, err)
+			continue
+		}
+		summary, err := engine.GetSummary(reportJSON)
+		if err != nil {
+			logrus.Debugf("Skipping report %v in rollup: %v", report.Name, err)
+			continue
+		}
+		states, err := engine.GetCheckStates(reportJSON)
+		if err != nil {
+			logrus.Debugf("Skipping report %v in rollup: %v", report.Name, err)
+			continue
+		}
+		rollup.Runs++
+		rollup.Trend = append(rollup.Trend, rollupRun{
+			Report:    report.Name,
+			Scan:      scanName,
+			Timestamp: created.UTC().Format(time.RFC3339),
+			Summary:   summary,
+		})
+		if failingSince[scanName] == nil {
+			failingSince[scanName] = map[string]time.Time{}
+		}
+		for id, state := range states {
+			since, failing := failingSince[scanName][id]
+			switch state {
+			case "fail":
+				failedRuns[id]++
+				if !failing {
+					failingSince[scanName][id] = created
+				}
+			case "pass":
+				if failing {
+					rollup.RemediatedChecks++
+					remediationTime += created.Sub(since)
+					delete(failingSince[scanName], id)
+					remediation := checkRemediations[id]
+					remediation.Count++
+					remediation.TotalSeconds += int64(created.Sub(since).Seconds())
+					checkRemediations[id] = remediation
+				}
+			}
+		}
+	}
+	if rollup.RemediatedChecks > 0 {
+		rollup.MeanTimeToRemediateSeconds = int64(remediationTime.Seconds()) / int64(rollup.RemediatedChecks)
+	}
+
+	rollup.ChecksMTTR = getRollupMTTR(checkRemediations)
+	sectionRemediations := map[string]v1.CheckRemediation{}
+	for id, remediation := range checkRemediations {
+		section := sectionRemediations[getCheckSection(id)]
+		section.Count += remediation.Count
+		section.TotalSeconds += remediation.TotalSeconds
+		sectionRemediations[getCheckSection(id)] = section
+	}
+	rollup.SectionsMTTR = getRollupMTTR(sectionRemediations)
+
+	for id, runs := range failedRuns {
+		rollup.TopFailingChecks = append(rollup.TopFailingChecks, rollupCheck{ID: id, FailedRuns: runs})
+	}
+	sort.Slice(rollup.TopFailingChecks, func(i, j int) bool {
+		a, b := rollup.TopFailingChecks[i], rollup.TopFailingChecks[j]
+		if a.FailedRuns != b.FailedRuns {
+			return a.FailedRuns > b.FailedRuns
+		}
+		return a.ID < b.ID
+	})
+	if len(rollup.TopFailingChecks) > rollupTopFailingChecks {
+		rollup.TopFailingChecks = rollup.TopFailingChecks[:rollupTopFailingChecks]
+	}
+	return rollup, nil
+}
+
+// getRollupMTTR returns the mean time to remediate of every check or section, sorted by ID
+func getRollupMTTR(remediations map[string]v1.CheckRemediation) []rollupMTTR {
+	mttr := []rollupMTTR{}
+	for id, remediation := range remediations {
+		mttr = append(mttr, rollupMTTR{
+			ID:                         id,
+			Remediations:               remediation.Count,
+			MeanTimeToRemediateSeconds: remediation.TotalSeconds / int64(remediation.Count),
+		})
+	}
+	sort.Slice(mttr, func(i, j int) bool {
+		return mttr[i].ID < mttr[j].ID
+	})
+	return mttr
+}
+
+// scanMatchesSelector returns whether the scan is selected, the reports of deleted scans only match an empty selector
+func (c *Controller) scanMatchesSelector(scanName string, selector labels.Selector) bool {
+	if selector.Empty() {
+		return true
+	}
+	scan, err := c.scans.Cache().Get(scanName)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(scan.Labels))
+}
+
+// getReportScanName returns the name of the scan owning a report
+func getReportScanName(report *v1.ClusterScanReport) string {
+	for _, ref := range report.OwnerReferences {
+		if ref.Kind == "ClusterScan" {
+			return ref.Name
+		}
+	}
+	return ""
+}
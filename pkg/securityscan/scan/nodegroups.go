@@ -0,0 +1,64 @@
+package scan
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cisoperatorapiv1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// NodeGroupTemplate is a parsed node group dimension template.
+type NodeGroupTemplate struct {
+	tmpl *template.Template
+}
+
+// nodeGroupTemplateData is what the node group dimension templates are rendered with
+type nodeGroupTemplateData struct {
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// ParseNodeGroupTemplate parses the template of a node group dimension, missing labels and annotations render empty.
+func ParseNodeGroupTemplate(dimension cisoperatorapiv1.NodeGroupDimension) (*NodeGroupTemplate, error) {
+	tmpl, err := template.New(dimension.Name).Option("missingkey=zero").Parse(dimension.Template)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeGroupTemplate{tmpl: tmpl}, nil
+}
+
+// Group returns the group of a node in the dimension, empty if the node is left out of it.
+func (t *NodeGroupTemplate) Group(node *corev1.Node) (string, error) {
+	var b strings.Builder
+	err := t.tmpl.Execute(&b, nodeGroupTemplateData{
+		Name:        node.Name,
+		Labels:      node.Labels,
+		Annotations: node.Annotations,
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// ValidateNodeGroupDimensions checks the node group dimensions have unique names and valid templates.
+func ValidateNodeGroupDimensions(dimensions []cisoperatorapiv1.NodeGroupDimension) error {
+	names := map[string]bool{}
+	for _, dimension := range dimensions {
+		if dimension.Name == "" {
+			return fmt.Errorf("missing name of nodeGroupDimension with template %q", dimension.Template)
+		}
+		if names[dimension.Name] {
+			return fmt.Errorf("duplicate nodeGroupDimension %q", dimension.Name)
+		}
+		names[dimension.Name] = true
+		if _, err := ParseNodeGroupTemplate(dimension); err != nil {
+			return fmt.Errorf("invalid template of nodeGroupDimension %q: %w", dimension.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package scan
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron"
+)
+
+// timezonePrefixes set the timezone of a cron expression, as for CronJobs
+var timezonePrefixes = []string{"CRON_TZ=", "TZ="}
+
+// HasTimezonePrefix returns whether the cron expression sets its timezone with a CRON_TZ= or TZ= prefix,
+// deprecated in favor of the timezone of the schedule
+func HasTimezonePrefix(schedule string) bool {
+	schedule = strings.TrimSpace(schedule)
+	for _, prefix := range timezonePrefixes {
+		if strings.HasPrefix(schedule, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCronSchedule parses a standard cron expression evaluated in the given IANA timezone, UTC if
+// empty. As for CronJobs, the expression may instead be prefixed by CRON_TZ=<timezone> or
+// TZ=<timezone>, e.g. "CRON_TZ=Europe/Paris 0 2 * * *" runs at 02:00 Paris time across DST changes.
+func ParseCronSchedule(schedule, timezone string) (cron.Schedule, error) {
+	schedule = strings.TrimSpace(schedule)
+	for _, prefix := range timezonePrefixes {
+		if !strings.HasPrefix(schedule, prefix) {
+			continue
+		}
+		fields := strings.SplitN(schedule, " ", 2)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("missing cron expression after %v", fields[0])
+		}
+		if timezone != "" {
+			return nil, fmt.Errorf("timezone set both by %v and as timezone %q", fields[0], timezone)
+		}
+		timezone = strings.TrimPrefix(fields[0], prefix)
+		schedule = strings.TrimSpace(fields[1])
+	}
+	location := time.UTC
+	if timezone != "" {
+		var err error
+		location, err = time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+		}
+	}
+	cronSchedule, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return nil, err
+	}
+	return &locationSchedule{schedule: cronSchedule, location: location}, nil
+}
+
+// locationSchedule evaluates a cron schedule in a timezone rather than in the one of the given time
+type locationSchedule struct {
+	schedule cron.Schedule
+	location *time.Location
+}
+
+func (s *locationSchedule) Next(t time.Time) time.Time {
+	return s.schedule.Next(t.In(s.location))
+}
@@ -0,0 +1,227 @@
+package scan
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	cisoperatorapiv1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// ValidateClusterScanSpec checks the ClusterScan spec fields that can be validated
+// without looking up any other object.
+func ValidateClusterScanSpec(spec *cisoperatorapiv1.ClusterScanSpec) error {
+	if err := ValidateScheduledScanConfig(spec.ScheduledScanConfig); err != nil {
+		return err
+	}
+	switch spec.ScoreWarning {
+	case "", cisoperatorapiv1.ClusterScanPassOnWarning, cisoperatorapiv1.ClusterScanFailOnWarning:
+	default:
+		return fmt.Errorf("invalid scoreWarning %q, must be one of %q or %q", spec.ScoreWarning, cisoperatorapiv1.ClusterScanPassOnWarning, cisoperatorapiv1.ClusterScanFailOnWarning)
+	}
+	for key, value := range spec.NodeSelector {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid nodeSelector key %q: %v", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid nodeSelector value %q for key %q: %v", value, key, strings.Join(errs, "; "))
+		}
+	}
+	if spec.MaxNodes < 0 {
+		return fmt.Errorf("invalid maxNodes %d, must not be negative", spec.MaxNodes)
+	}
+	for _, nodeName := range spec.NodeNames {
+		if errs := validation.IsDNS1123Subdomain(nodeName); len(errs) > 0 {
+			return fmt.Errorf("invalid nodeNames entry %q: %v", nodeName, strings.Join(errs, "; "))
+		}
+	}
+	if spec.KubeletVersionRange != "" {
+		if _, err := semver.ParseRange(spec.KubeletVersionRange); err != nil {
+			return fmt.Errorf("invalid kubeletVersionRange %q: %w", spec.KubeletVersionRange, err)
+		}
+	}
+	for _, osImage := range spec.OSImages {
+		if strings.TrimSpace(osImage) == "" {
+			return fmt.Errorf("invalid empty osImages entry")
+		}
+	}
+	seenProfiles := map[string]bool{spec.ScanProfileName: true}
+	for _, profileName := range spec.AdditionalProfileNames {
+		if errs := validation.IsDNS1123Subdomain(profileName); len(errs) > 0 {
+			return fmt.Errorf("invalid additionalProfileNames entry %q: %v", profileName, strings.Join(errs, "; "))
+		}
+		if seenProfiles[profileName] {
+			return fmt.Errorf("duplicate additionalProfileNames entry %q", profileName)
+		}
+		seenProfiles[profileName] = true
+	}
+	if spec.PriorityClassName != "" {
+		if errs := validation.IsDNS1123Subdomain(spec.PriorityClassName); len(errs) > 0 {
+			return fmt.Errorf("invalid priorityClassName %q: %v", spec.PriorityClassName, strings.Join(errs, "; "))
+		}
+	}
+	if spec.ServiceAccountName != "" {
+		if errs := validation.IsDNS1123Subdomain(spec.ServiceAccountName); len(errs) > 0 {
+			return fmt.Errorf("invalid serviceAccountName %q: %v", spec.ServiceAccountName, strings.Join(errs, "; "))
+		}
+	}
+	if spec.BaselineReportName != "" {
+		if errs := validation.IsDNS1123Subdomain(spec.BaselineReportName); len(errs) > 0 {
+			return fmt.Errorf("invalid baselineReportName %q: %v", spec.BaselineReportName, strings.Join(errs, "; "))
+		}
+	}
+	for _, id := range spec.IncludeChecks {
+		if id == "" || strings.ContainsAny(id, " \t\n,") {
+			return fmt.Errorf("invalid includeChecks ID %q", id)
+		}
+	}
+	if err := validateContinuousConfig(spec); err != nil {
+		return err
+	}
+	if err := ValidateNodeGroupDimensions(spec.NodeGroupDimensions); err != nil {
+		return err
+	}
+	if err := validateDNS(spec); err != nil {
+		return err
+	}
+	if spec.ScanTimeoutSeconds < 0 {
+		return fmt.Errorf("invalid scanTimeoutSeconds %d, must not be negative", spec.ScanTimeoutSeconds)
+	}
+	if err := validateNodeAction(spec.NodeAction); err != nil {
+		return err
+	}
+	if spec.RetryPolicy != nil {
+		if spec.RetryPolicy.MaxAttempts < 0 {
+			return fmt.Errorf("invalid retryPolicy maxAttempts %d, must not be negative", spec.RetryPolicy.MaxAttempts)
+		}
+		if spec.RetryPolicy.BackoffSeconds < 0 {
+			return fmt.Errorf("invalid retryPolicy backoffSeconds %d, must not be negative", spec.RetryPolicy.BackoffSeconds)
+		}
+	}
+	return nil
+}
+
+// validateNodeAction checks the node action names its checks, and that its key and effect are valid for its action
+func validateNodeAction(action *cisoperatorapiv1.ClusterScanNodeAction) error {
+	if action == nil {
+		return nil
+	}
+	if len(action.TestIDs) == 0 {
+		return fmt.Errorf("invalid nodeAction without testIDs")
+	}
+	for _, id := range action.TestIDs {
+		if id == "" || strings.ContainsAny(id, " \t\n,") {
+			return fmt.Errorf("invalid nodeAction testIDs entry %q", id)
+		}
+	}
+	if action.Key != "" {
+		if errs := validation.IsQualifiedName(action.Key); len(errs) > 0 {
+			return fmt.Errorf("invalid nodeAction key %q: %v", action.Key, strings.Join(errs, "; "))
+		}
+		if !strings.HasPrefix(action.Key, cisoperatorapiv1.NodeActionKeyPrefix) {
+			return fmt.Errorf("invalid nodeAction key %q, must start with %v", action.Key, cisoperatorapiv1.NodeActionKeyPrefix)
+		}
+	}
+	switch action.Action {
+	case "", cisoperatorapiv1.NodeActionTaint:
+		switch action.Effect {
+		case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			return fmt.Errorf("invalid nodeAction effect %q, must be one of %q, %q or %q", action.Effect,
+				corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute)
+		}
+	case cisoperatorapiv1.NodeActionLabel:
+		if action.Effect != "" {
+			return fmt.Errorf("invalid nodeAction effect %q, the %v action has none", action.Effect, cisoperatorapiv1.NodeActionLabel)
+		}
+	default:
+		return fmt.Errorf("invalid nodeAction action %q, must be %q or %q", action.Action, cisoperatorapiv1.NodeActionTaint, cisoperatorapiv1.NodeActionLabel)
+	}
+	return nil
+}
+
+// validateContinuousConfig checks the interval and reference of a continuous scan, which does not run on a
+// cron schedule
+func validateContinuousConfig(spec *cisoperatorapiv1.ClusterScanSpec) error {
+	if spec.Continuous == nil {
+		return nil
+	}
+	if spec.ScheduledScanConfig != nil && spec.ScheduledScanConfig.CronSchedule != "" {
+		return fmt.Errorf("continuous scans run at their intervalMinutes, they cannot have a cronSchedule")
+	}
+	if spec.Continuous.IntervalMinutes < 0 {
+		return fmt.Errorf("invalid continuous intervalMinutes %d, must not be negative", spec.Continuous.IntervalMinutes)
+	}
+	if spec.Continuous.ReferenceScanName != "" {
+		if errs := validation.IsDNS1123Subdomain(spec.Continuous.ReferenceScanName); len(errs) > 0 {
+			return fmt.Errorf("invalid continuous referenceScanName %q: %v", spec.Continuous.ReferenceScanName, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// validateDNS checks the DNS settings of the scan pods, which would otherwise only fail once the
+// node workers are scheduled
+func validateDNS(spec *cisoperatorapiv1.ClusterScanSpec) error {
+	switch spec.DNSPolicy {
+	case "", corev1.DNSClusterFirst, corev1.DNSClusterFirstWithHostNet, corev1.DNSDefault:
+	case corev1.DNSNone:
+		if spec.DNSConfig == nil || len(spec.DNSConfig.Nameservers) == 0 {
+			return fmt.Errorf("dnsConfig with at least one nameserver is required by dnsPolicy %q", corev1.DNSNone)
+		}
+	default:
+		return fmt.Errorf("invalid dnsPolicy %q", spec.DNSPolicy)
+	}
+	if spec.DNSConfig != nil {
+		for _, nameserver := range spec.DNSConfig.Nameservers {
+			if net.ParseIP(nameserver) == nil {
+				return fmt.Errorf("invalid dnsConfig nameserver %q, must be an IP address", nameserver)
+			}
+		}
+	}
+	for _, alias := range spec.HostAliases {
+		if net.ParseIP(alias.IP) == nil {
+			return fmt.Errorf("invalid hostAliases ip %q, must be an IP address", alias.IP)
+		}
+		for _, hostname := range alias.Hostnames {
+			if errs := validation.IsDNS1123Subdomain(hostname); len(errs) > 0 {
+				return fmt.Errorf("invalid hostAliases hostname %q: %v", hostname, strings.Join(errs, "; "))
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateScheduledScanConfig checks the cron schedule and retention of a scheduled scan.
+func ValidateScheduledScanConfig(config *cisoperatorapiv1.ScheduledScanConfig) error {
+	if config == nil {
+		return nil
+	}
+	if config.CronSchedule != "" {
+		if _, err := ParseCronSchedule(config.CronSchedule, config.Timezone); err != nil {
+			return fmt.Errorf("error parsing invalid cron string for schedule: %w", err)
+		}
+	} else if config.Timezone != "" {
+		if _, err := time.LoadLocation(config.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", config.Timezone, err)
+		}
+	}
+	if config.JitterSeconds < 0 {
+		return fmt.Errorf("invalid jitterSeconds %d, must not be negative", config.JitterSeconds)
+	}
+	if config.RetentionCount < 0 {
+		return fmt.Errorf("invalid retentionCount %d, must not be negative", config.RetentionCount)
+	}
+	if config.RetentionDays < 0 {
+		return fmt.Errorf("invalid retentionDays %d, must not be negative", config.RetentionDays)
+	}
+	if config.RegressionBudget != nil && *config.RegressionBudget < 0 {
+		return fmt.Errorf("invalid regressionBudget %d, must not be negative", *config.RegressionBudget)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package scan
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	cisoperatorapiv1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// ValidateVolumes checks the host path volumes of a scan have unique names and only mount
+// absolute paths under one of the allowed host paths.
+func ValidateVolumes(volumes []cisoperatorapiv1.ClusterScanHostPathVolume, allowlist []string) error {
+	names := map[string]bool{}
+	for _, volume := range volumes {
+		if errs := validation.IsDNS1123Label(volume.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid volume name %q: %v", volume.Name, strings.Join(errs, "; "))
+		}
+		if names[volume.Name] {
+			return fmt.Errorf("duplicate volume %q", volume.Name)
+		}
+		names[volume.Name] = true
+		if !path.IsAbs(volume.HostPath) || path.Clean(volume.HostPath) != volume.HostPath {
+			return fmt.Errorf("invalid hostPath %q of volume %q, must be an absolute and clean path", volume.HostPath, volume.Name)
+		}
+		if volume.MountPath != "" && (!path.IsAbs(volume.MountPath) || path.Clean(volume.MountPath) != volume.MountPath) {
+			return fmt.Errorf("invalid mountPath %q of volume %q, must be an absolute and clean path", volume.MountPath, volume.Name)
+		}
+		if !isAllowedHostPath(volume.HostPath, allowlist) {
+			return fmt.Errorf("hostPath %q of volume %q is not allowed by the operator", volume.HostPath, volume.Name)
+		}
+	}
+	return nil
+}
+
+func isAllowedHostPath(hostPath string, allowlist []string) bool {
+	for _, allowed := range allowlist {
+		allowed = path.Clean(allowed)
+		if hostPath == allowed || strings.HasPrefix(hostPath, strings.TrimSuffix(allowed, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package securityscan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/sirupsen/logrus"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// name of the remove handler of the scans, their finalizer is wrangler.cattle.io/cis-scan-cleanup
+const scanCleanupHandlerName = "cis-scan-cleanup"
+
+// scan removals tear down the resources of the current run of the deleted scan, its job, runner pod, daemonsets,
+// service and configmaps, before the finalizer is removed, so that no privileged scan pod outlives its scan, and
+// free its slot for the queued scans. The taints and labels of its node action are removed from the nodes too.
+func (c *Controller) handleClusterScanRemovals(ctx context.Context) error {
+	scans := c.cisFactory.Cis().V1().ClusterScan()
+
+	scans.OnRemove(ctx, scanCleanupHandlerName, func(key string, obj *v1.ClusterScan) (*v1.ClusterScan, error) {
+		if obj == nil {
+			return obj, nil
+		}
+		if !c.ownsName(obj.Name) {
+			// the finalizer is kept for the replica of its shard to remove
+			return obj, generic.ErrSkip
+		}
+		running := v1.ClusterScanConditionCreated.IsTrue(obj) && !v1.ClusterScanConditionComplete.IsTrue(obj)
+		if running {
+			logrus.Infof("Tearing down running scan %v on its deletion", obj.Name)
+		}
+		if err := c.teardownScan(obj); err != nil {
+			return obj, err
+		}
+		if obj.Status.NodeAction != nil && c.getImageConfig().NodeActionsEnabled {
+			if remaining := c.undoNodeAction(obj.Name, obj.Status.NodeAction); len(remaining) > 0 {
+				return obj, fmt.Errorf("error removing the %v of scan %v from %d nodes", describeNodeAction(obj.Status.NodeAction), obj.Name, len(remaining))
+			}
+		}
+		if running {
+			c.releaseScan(obj.Name)
+		}
+		return obj, nil
+	})
+	return nil
+}
@@ -0,0 +1,289 @@
+package securityscan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/rancher/wrangler/pkg/name"
+
+	cisoperatorapi "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io"
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+)
+
+// largest request body read for the metadata of the object created, the larger ones are only accounted by their path
+const maxAccountedBodyBytes = 4 << 20
+
+// scanCosts accounts the requests the operator issues to the API server for the running scans, and the pods their
+// runs create. A request is accounted to a scan when it is on the ClusterScan, on an object of its run by name,
+// selects the objects labelled with the scan, or creates an object labelled with or owned by the scan.
+type scanCosts struct {
+	lock sync.Mutex
+	// by scan name, the scans not running are not accounted
+	runs map[string]*scanRunCost
+}
+
+type scanRunCost struct {
+	// LastRunTimestamp of the run
+	run      string
+	requests map[string]int
+	pods     map[types.UID]bool
+	// names of the objects of the run
+	objects map[string]bool
+	// the runner job of the run
+	runnerName string
+}
+
+func newScanCosts() *scanCosts {
+	return &scanCosts{runs: map[string]*scanRunCost{}}
+}
+
+// startRun starts accounting the run launched with the objects
+func (s *scanCosts) startRun(scan *v1.ClusterScan, objects []runtime.Object) {
+	run := &scanRunCost{
+		run:        scan.Status.LastRunTimestamp,
+		requests:   map[string]int{},
+		pods:       map[types.UID]bool{},
+		objects:    map[string]bool{},
+		runnerName: name.SafeConcatName("security-scan-runner", scan.Name),
+	}
+	run.objects[run.runnerName] = true
+	for _, obj := range objects {
+		if accessor, err := meta.Accessor(obj); err == nil && accessor.GetName() != "" {
+			run.objects[accessor.GetName()] = true
+		}
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.runs[scan.Name] = run
+}
+
+// getRunCost returns the cost of the last run of the scan so far, partial if the run was not accounted from its launch,
+// e.g. after a restart of the operator
+func (s *scanCosts) getRunCost(scan *v1.ClusterScan, now time.Time) *v1.ClusterScanCost {
+	cost := &v1.ClusterScanCost{}
+	if start, err := time.Parse(time.RFC3339, scan.Status.LastRunTimestamp); err == nil {
+		cost.DurationSeconds = int64(now.Sub(start).Seconds())
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	run, ok := s.runs[scan.Name]
+	if !ok || run.run != scan.Status.LastRunTimestamp {
+		cost.Partial = true
+		return cost
+	}
+	for verb, count := range run.requests {
+		cost.APIRequests += count
+		if cost.APIRequestsByVerb == nil {
+			cost.APIRequestsByVerb = map[string]int{}
+		}
+		cost.APIRequestsByVerb[verb] = count
+	}
+	cost.Pods = len(run.pods)
+	return cost
+}
+
+// stopRun stops accounting the scan, once its run is complete, retried, cancelled or the scan deleted
+func (s *scanCosts) stopRun(scanName string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.runs, scanName)
+}
+
+func (s *scanCosts) running() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.runs) > 0
+}
+
+// wrapTransport accounts the requests going through the transport
+func (s *scanCosts) wrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if s.running() {
+			s.account(req)
+		}
+		return rt.RoundTrip(req)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func (s *scanCosts) account(req *http.Request) {
+	resource, objectName := parseRequestPath(req.URL.Path)
+	if resource == "" {
+		return
+	}
+	verb := getRequestVerb(req, objectName)
+	var created *metav1.ObjectMeta
+	if verb == "create" {
+		created = readCreatedObjectMeta(req)
+	}
+	selector := req.URL.Query().Get("labelSelector")
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for scanName, run := range s.runs {
+		accounted := (resource == "clusterscans" && objectName == scanName) || (objectName != "" && run.objects[objectName])
+		if !accounted && selector != "" {
+			accounted = selectsScan(selector, scanName)
+		}
+		if !accounted && created != nil && isOfScan(created, scanName) {
+			accounted = true
+			if created.Name != "" {
+				run.objects[created.Name] = true
+			}
+		}
+		if accounted {
+			run.requests[verb]++
+		}
+	}
+}
+
+// accountPod accounts the pod to the run it belongs to: the pods of its runner job, and the pods of the daemonsets
+// they own, getDaemonSetScan returns the scan of the runner pod owning a daemonset
+func (s *scanCosts) accountPod(pod *corev1.Pod, getDaemonSetScan func(namespace, name string) string) {
+	scanName := pod.Labels[cisoperatorapi.LabelClusterScan]
+	if scanName == "" {
+		for _, owner := range pod.OwnerReferences {
+			if owner.Kind == "DaemonSet" {
+				scanName = getDaemonSetScan(pod.Namespace, owner.Name)
+				break
+			}
+		}
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if run, ok := s.runs[scanName]; ok && scanName != "" {
+		run.pods[pod.UID] = true
+	}
+}
+
+// parseRequestPath returns the resource and the object name of a request to the API server, e.g. jobs and
+// security-scan-runner-scan for /apis/batch/v1/namespaces/cis-operator-system/jobs/security-scan-runner-scan/status
+func parseRequestPath(path string) (resource, objectName string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) >= 3 && parts[0] == "api":
+		parts = parts[2:]
+	case len(parts) >= 4 && parts[0] == "apis":
+		parts = parts[3:]
+	default:
+		return "", ""
+	}
+	if len(parts) > 2 && parts[0] == "namespaces" {
+		parts = parts[2:]
+	}
+	resource = parts[0]
+	if len(parts) > 1 {
+		objectName = parts[1]
+	}
+	return resource, objectName
+}
+
+func getRequestVerb(req *http.Request, objectName string) string {
+	switch req.Method {
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut:
+		return "update"
+	case http.MethodPatch:
+		return "patch"
+	case http.MethodDelete:
+		if objectName == "" {
+			return "deletecollection"
+		}
+		return "delete"
+	}
+	if objectName != "" {
+		return "get"
+	}
+	if req.URL.Query().Get("watch") == "true" {
+		return "watch"
+	}
+	return "list"
+}
+
+// readCreatedObjectMeta returns the metadata of the object a create request sends, nil if it cannot be read
+func readCreatedObjectMeta(req *http.Request) *metav1.ObjectMeta {
+	if req.GetBody == nil || req.ContentLength > maxAccountedBodyBytes {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+	data, err := io.ReadAll(io.LimitReader(body, maxAccountedBodyBytes))
+	if err != nil || !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		// e.g. protobuf
+		return nil
+	}
+	object := struct {
+		Metadata metav1.ObjectMeta `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil
+	}
+	return &object.Metadata
+}
+
+func isOfScan(objectMeta *metav1.ObjectMeta, scanName string) bool {
+	if objectMeta.Labels[cisoperatorapi.LabelClusterScan] == scanName {
+		return true
+	}
+	for _, owner := range objectMeta.OwnerReferences {
+		if owner.Kind == "ClusterScan" && owner.Name == scanName {
+			return true
+		}
+	}
+	return false
+}
+
+func selectsScan(selector, scanName string) bool {
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return false
+	}
+	value, ok := parsed.RequiresExactMatch(cisoperatorapi.LabelClusterScan)
+	return ok && value == scanName
+}
+
+// handleScanCostPods accounts the pods created by the running scans
+func (c *Controller) handleScanCostPods(ctx context.Context) error {
+	pods := c.coreFactory.Core().V1().Pod()
+	pods.OnChange(ctx, c.Name, func(key string, obj *corev1.Pod) (*corev1.Pod, error) {
+		if obj == nil || obj.Namespace != v1.ClusterScanNS || !c.scanCosts.running() {
+			return obj, nil
+		}
+		c.scanCosts.accountPod(obj, func(namespace, name string) string {
+			ds, err := c.daemonsetCache.Get(namespace, name)
+			if err != nil {
+				logrus.Debugf("Error getting daemonset %v of pod %v: %v", name, obj.Name, err)
+				return ""
+			}
+			return engine.GetRunnerPodScan(ds.OwnerReferences, func(podName string) (*corev1.Pod, error) {
+				return c.podCache.Get(namespace, podName)
+			})
+		})
+		return obj, nil
+	})
+	return nil
+}
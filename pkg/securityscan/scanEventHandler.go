@@ -0,0 +1,109 @@
+package securityscan
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/condition"
+	"github.com/rancher/cis-operator/pkg/scanevents"
+)
+
+// scan events export the stages of the runs of the scans to the scan event log. The last stage exported per
+// scan is kept in memory, the stages reached before the operator started are not exported again.
+func (c *Controller) handleScanEventExport(ctx context.Context) error {
+	if c.scanEventExporter == nil {
+		return nil
+	}
+	scans := c.cisFactory.Cis().V1().ClusterScan()
+	startTime := time.Now()
+	var lock sync.Mutex
+	exported := map[string]string{}
+
+	scans.OnChange(ctx, c.Name, func(key string, obj *v1.ClusterScan) (*v1.ClusterScan, error) {
+		if obj == nil || obj.DeletionTimestamp != nil {
+			lock.Lock()
+			delete(exported, key)
+			lock.Unlock()
+			return obj, nil
+		}
+		if !c.ownsName(obj.Name) {
+			return obj, nil
+		}
+		stage, cond := getScanStage(obj)
+		if stage == "" {
+			return obj, nil
+		}
+		run := obj.Status.LastRunTimestamp + "/" + stage
+
+		lock.Lock()
+		last, seen := exported[key]
+		exported[key] = run
+		lock.Unlock()
+		if last == run {
+			return obj, nil
+		}
+		if !seen {
+			if updated, err := time.Parse(time.RFC3339, cond.GetLastUpdated(obj)); err == nil && updated.Before(startTime) {
+				return obj, nil
+			}
+		}
+		if err := c.scanEventExporter.Export(newScanEvent(obj, stage)); err != nil {
+			logrus.Errorf("Error exporting the %v event of scan %v: %v", stage, obj.Name, err)
+		}
+		return obj, nil
+	})
+	return nil
+}
+
+// getScanStage returns the stage the current run of the scan reached, and the condition recording it
+func getScanStage(scan *v1.ClusterScan) (string, condition.Cond) {
+	switch {
+	case v1.ClusterScanConditionCancelled.IsTrue(scan):
+		return scanevents.StageCancelled, v1.ClusterScanConditionCancelled
+	case v1.ClusterScanConditionFailed.IsTrue(scan):
+		return scanevents.StageFailed, v1.ClusterScanConditionFailed
+	case v1.ClusterScanConditionComplete.IsTrue(scan):
+		return scanevents.StageCompleted, v1.ClusterScanConditionComplete
+	case v1.ClusterScanConditionCreated.IsTrue(scan) && scan.Status.LastRunTimestamp != "":
+		return scanevents.StageStarted, v1.ClusterScanConditionCreated
+	}
+	return "", ""
+}
+
+// newScanEvent returns the event of the stage of the scan, with the summary of its results once completed
+func newScanEvent(scan *v1.ClusterScan, stage string) *scanevents.Event {
+	event := scanevents.NewEvent(scan.Name, scan.UID, stage, time.Now())
+	event.Annotations[scanevents.RunAnnotation] = scan.Status.LastRunTimestamp
+	if scan.Status.LastRunScanProfileName != "" {
+		event.Annotations[scanevents.ProfileAnnotation] = scan.Status.LastRunScanProfileName
+	}
+	switch stage {
+	case scanevents.StageFailed:
+		event.Annotations[scanevents.MessageAnnotation] = v1.ClusterScanConditionFailed.GetMessage(scan)
+	case scanevents.StageCancelled:
+		event.Annotations[scanevents.MessageAnnotation] = v1.ClusterScanConditionCancelled.GetMessage(scan)
+	case scanevents.StageCompleted:
+		if scan.Status.Summary != nil {
+			if summary, err := json.Marshal(scan.Status.Summary); err == nil {
+				event.Annotations[scanevents.SummaryAnnotation] = string(summary)
+			}
+		}
+		if scan.Status.ComplianceScore != nil {
+			event.Annotations[scanevents.ComplianceScoreAnnotation] = strconv.FormatFloat(scan.Status.ComplianceScore.Score, 'f', -1, 64)
+		}
+		if len(scan.Status.PostureDrift) > 0 {
+			event.Annotations[scanevents.PostureDriftAnnotation] = strings.Join(scan.Status.PostureDrift, ",")
+		}
+		if len(scan.Status.Trend) > 0 {
+			event.Annotations[scanevents.ReportAnnotation] = scan.Status.Trend[len(scan.Status.Trend)-1].ReportName
+		}
+	}
+	return event
+}
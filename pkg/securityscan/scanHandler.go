@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -21,8 +22,8 @@ import (
 	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
 	cisctlv1 "github.com/rancher/cis-operator/pkg/generated/controllers/cis.cattle.io/v1"
 	cisalert "github.com/rancher/cis-operator/pkg/securityscan/alert"
-	ciscore "github.com/rancher/cis-operator/pkg/securityscan/core"
-	cisjob "github.com/rancher/cis-operator/pkg/securityscan/job"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+	cisscan "github.com/rancher/cis-operator/pkg/securityscan/scan"
 )
 
 var SonobuoyMasterLabel = map[string]string{"run": "sonobuoy-master"}
@@ -33,6 +34,18 @@ func (c *Controller) handleClusterScans(ctx context.Context) error {
 			if obj == nil || obj.DeletionTimestamp != nil {
 				return objects, status, nil
 			}
+			if !c.ownsName(obj.Name) {
+				// run by the replica of its shard, the status is left as is
+				return objects, status, generic.ErrSkip
+			}
+			if obj.Spec.Cancel {
+				// torn down by the cancel handler
+				return objects, obj.Status, nil
+			}
+			if isSuspendedScan(obj) && obj.Status.LastRunTimestamp == "" {
+				// resumed by the scheduled scan handler
+				return objects, obj.Status, nil
+			}
 
 			logrus.Debugf("ClusterScan GENERATING HANDLER: scan=%s/%s@%s, %v, status=%+v", obj.Namespace, obj.Name, obj.Spec.ScanProfileName, obj.ResourceVersion, status.LastRunTimestamp)
 
@@ -44,15 +57,37 @@ func (c *Controller) handleClusterScans(ctx context.Context) error {
 					c.scans.Enqueue(obj.Name)
 					return objects, obj.Status, nil
 				}
+				if obj.Status.NextRetryAt != "" {
+					// a failed run is retried once its backoff has elapsed
+					if retryAt, err := time.Parse(time.RFC3339, obj.Status.NextRetryAt); err == nil && retryAt.After(time.Now()) {
+						c.scans.EnqueueAfter(obj.Name, time.Until(retryAt))
+						return objects, obj.Status, nil
+					}
+				}
 				obj.Status.Conditions = []genericcondition.GenericCondition{}
 				v1.ClusterScanConditionPending.True(obj)
 				v1.ClusterScanConditionPending.Message(obj, "ClusterScan run pending")
-
-				if err := c.isRunnerPodPresent(); err != nil {
-					return objects, obj.Status, fmt.Errorf("Retrying ClusterScan %q since got error: %w", obj.Name, err)
+				if obj.Status.QueuedAt == "" {
+					obj.Status.QueuedAt = time.Now().Round(time.Second).Format(time.RFC3339)
+				}
+				if job, ok := c.getInFlightJob(obj); ok {
+					// launched before the operator restarted, without the run in the status
+					if err := c.adoptScanJob(ctx, obj, job); err != nil {
+						return objects, obj.Status, err
+					}
+					return objects, obj.Status, nil
 				}
 
-				profile, err := c.getClusterScanProfile(ctx, obj)
+				launchStart := time.Now()
+				profile, unsupported, err := c.resolveClusterScanProfile(ctx, obj)
+				if unsupported != nil {
+					v1.ClusterScanConditionUnsupportedVersion.True(obj)
+					if err == nil {
+						v1.ClusterScanConditionUnsupportedVersion.Message(obj, fmt.Sprintf("%v, running ClusterScanProfile %v as allowed by allowClosestMatch", unsupported.Error(), profile.Name))
+					} else {
+						v1.ClusterScanConditionUnsupportedVersion.Message(obj, unsupported.Error())
+					}
+				}
 				if err != nil {
 					v1.ClusterScanConditionFailed.True(obj)
 					message := fmt.Sprintf("Error validating ClusterScanProfile %v, error: %v", obj.Spec.ScanProfileName, err)
@@ -62,75 +97,134 @@ func (c *Controller) handleClusterScans(ctx context.Context) error {
 					return objects, obj.Status, nil
 				}
 
-				if err := c.validateScheduledScanSpec(obj); err != nil {
+				err = cisscan.ValidateClusterScanSpec(&obj.Spec)
+				if err == nil {
+					err = cisscan.ValidateVolumes(obj.Spec.Volumes, c.scanPodConfig.HostPathAllowlist)
+				}
+				if err != nil {
 					v1.ClusterScanConditionFailed.True(obj)
-					message := fmt.Sprintf("Error validating Schedule %v, error: %v", obj.Spec.ScheduledScanConfig.CronSchedule, err)
+					message := fmt.Sprintf("Error validating ClusterScan spec, error: %v", err)
 					v1.ClusterScanConditionFailed.Message(obj, message)
 					logrus.Errorf(message)
 					c.setClusterScanStatusDisplay(obj)
 					return objects, obj.Status, nil
 				}
 
-				if err := c.isRunnerPodPresent(); err != nil {
-					return objects, obj.Status, fmt.Errorf("Retrying ClusterScan %v since got error: %w", obj.Name, err)
+				if obj.Spec.ServiceAccountName != "" {
+					_, err := c.kcs.CoreV1().ServiceAccounts(v1.ClusterScanNS).Get(ctx, obj.Spec.ServiceAccountName, metav1.GetOptions{})
+					if errors.IsNotFound(err) {
+						v1.ClusterScanConditionFailed.True(obj)
+						message := fmt.Sprintf("ServiceAccount %v not found in namespace %v", obj.Spec.ServiceAccountName, v1.ClusterScanNS)
+						v1.ClusterScanConditionFailed.Message(obj, message)
+						logrus.Errorf(message)
+						c.setClusterScanStatusDisplay(obj)
+						return objects, obj.Status, nil
+					} else if err != nil {
+						return objects, obj.Status, fmt.Errorf("Retrying ClusterScan %v, error when looking up ServiceAccount %v: %w", obj.Name, obj.Spec.ServiceAccountName, err)
+					}
 				}
-				//launch new on demand scan
+
+				//launch new on demand scan, once its turn has come in the queue
 				c.mu.Lock()
 				defer c.mu.Unlock()
-				if c.currentScanName != "" {
-					scanfound, err := c.isScanPresent(c.currentScanName)
-					if err != nil {
-						return objects, obj.Status, fmt.Errorf("Retrying ClusterScan %v, error when checking CurrentScan %v is present: %w", obj.Name, c.currentScanName, err)
-					}
-					if !scanfound {
-						logrus.Debugf("Current scan %v gone, reset currentScanName and move on with this scan", c.currentScanName)
-						c.currentScanName = ""
-					} else {
-						//Some scan is running, wait
-						return objects, obj.Status, fmt.Errorf("Retrying ClusterScan %v since another Scan %v is running", obj.Name, c.currentScanName)
-					}
+				admitted, message, err := c.admitScan(obj)
+				if err != nil {
+					return objects, obj.Status, fmt.Errorf("Retrying ClusterScan %v, error when checking the scan queue: %w", obj.Name, err)
+				}
+				if !admitted {
+					v1.ClusterScanConditionPending.Message(obj, message)
+					c.setClusterScanStatusDisplay(obj)
+					c.scans.EnqueueAfter(obj.Name, scanQueueRecheckInterval)
+					return objects, obj.Status, nil
 				}
-				logrus.Infof("Launching a new on demand Job for scan %v to run cis using profile %v", obj.Name, profile.Name)
+				scanLog(obj).WithField("profile", profile.Name).Infof("Launching a new on demand Job for scan %v to run cis using profile %v", obj.Name, profile.Name)
 				benchmark, err := c.getClusterScanBenchmark(profile)
 				if err != nil {
 					v1.ClusterScanConditionReconciling.True(obj)
 					return objects, obj.Status, fmt.Errorf("Error when getting Benchmark: %w", err)
 				}
-				cmMap, err := ciscore.NewConfigMaps(obj, profile, benchmark, c.Name, c.ImageConfig, c.configmaps)
+				scanWindowsNodes, err := c.hasWindowsNodes(ctx)
 				if err != nil {
+					v1.ClusterScanConditionReconciling.True(obj)
+					return objects, obj.Status, fmt.Errorf("Error when looking up windows nodes: %w", err)
+				}
+				sampledNodes, err := c.sampleScanNodes(obj, scanWindowsNodes)
+				if err != nil {
+					v1.ClusterScanConditionReconciling.True(obj)
+					return objects, obj.Status, fmt.Errorf("Error when selecting the nodes of scan: %w", err)
+				}
+				if isCanaryScan(obj) && len(sampledNodes) == 0 {
+					v1.ClusterScanConditionFailed.True(obj)
+					message := fmt.Sprintf("No node in scope of scan %v matches its nodeNames, kubeletVersionRange or osImages", obj.Name)
+					v1.ClusterScanConditionFailed.Message(obj, message)
+					logrus.Errorf(message)
+					c.setClusterScanStatusDisplay(obj)
+					return objects, obj.Status, nil
+				}
+				obj.Status.SampledNodes = sampledNodes
+				if err := c.verifyScanImages(ctx, scanWindowsNodes); err != nil {
 					v1.ClusterScanConditionFailed.True(obj)
-					message := fmt.Sprintf("Error when creating ConfigMaps: %v", err)
+					message := fmt.Sprintf("Error verifying scan images: %v", err)
 					v1.ClusterScanConditionFailed.Message(obj, message)
 					logrus.Errorf(message)
 					c.setClusterScanStatusDisplay(obj)
 					return objects, obj.Status, nil
 				}
-				service, err := ciscore.NewService(obj, profile, c.Name)
+				if err := c.checkWindowsScan(obj, benchmark, scanWindowsNodes); err != nil {
+					v1.ClusterScanConditionFailed.True(obj)
+					message := fmt.Sprintf("Error validating scan for windows nodes: %v", err)
+					v1.ClusterScanConditionFailed.Message(obj, message)
+					logrus.Errorf(message)
+					c.setClusterScanStatusDisplay(obj)
+					return objects, obj.Status, nil
+				}
+				additionalProfiles, err := c.getAdditionalProfiles(obj, profile)
 				if err != nil {
-					v1.ClusterScanConditionReconciling.True(obj)
-					return objects, obj.Status, fmt.Errorf("Error when creating Service: %w", err)
+					v1.ClusterScanConditionFailed.True(obj)
+					message := fmt.Sprintf("Error validating additional ClusterScanProfiles: %v", err)
+					v1.ClusterScanConditionFailed.Message(obj, message)
+					logrus.Errorf(message)
+					c.setClusterScanStatusDisplay(obj)
+					return objects, obj.Status, nil
+				}
+				scanObjects, err := engine.NewScanObjects(&engine.ScanConfig{
+					Scan:             obj,
+					Profile:          sharedRunProfile(profile, additionalProfiles, time.Now()),
+					Benchmark:        benchmark,
+					ControllerName:   c.Name,
+					ImageConfig:      c.getImageConfig(),
+					PodConfig:        c.scanPodConfig,
+					ScanWindowsNodes: scanWindowsNodes,
+				}, c.configmaps)
+				if err != nil {
+					v1.ClusterScanConditionFailed.True(obj)
+					message := fmt.Sprintf("Error when creating scan objects: %v", err)
+					v1.ClusterScanConditionFailed.Message(obj, message)
+					logrus.Errorf(message)
+					c.setClusterScanStatusDisplay(obj)
+					return objects, obj.Status, nil
 				}
 
 				//recheck before launching job
-				if err := c.isRunnerPodPresent(); err != nil {
+				if err := c.checkRunnerCapacity(); err != nil {
 					return objects, obj.Status, fmt.Errorf("Retrying ClusterScan %v since got error: %w", obj.Name, err)
 				}
 
-				objects = append(objects, cisjob.New(obj, profile, benchmark, c.Name, c.ImageConfig, c.configmaps, c.securityScanJobTolerations), cmMap["configcm"], cmMap["plugincm"], cmMap["skipConfigcm"], service)
+				objects = append(objects, scanObjects...)
 
-				if c.ImageConfig.AlertEnabled &&
+				if c.getImageConfig().AlertEnabled &&
 					obj.Spec.ScheduledScanConfig != nil &&
 					obj.Spec.ScheduledScanConfig.ScanAlertRule != nil &&
 					(obj.Spec.ScheduledScanConfig.ScanAlertRule.AlertOnComplete || obj.Spec.ScheduledScanConfig.ScanAlertRule.AlertOnFailure) &&
 					obj.Status.ScanAlertingRuleName == "" {
-					alertRule, err := cisalert.NewPrometheusRule(obj, profile, c.ImageConfig)
+					alertRule, err := cisalert.NewPrometheusRule(obj, profile, c.getImageConfig())
 					if err != nil {
 						v1.ClusterScanConditionReconciling.True(obj)
 						return objects, obj.Status, fmt.Errorf("Error when trying to create a PrometheusRule: %w", err)
 					}
 					ruleCreated, err := c.monitoringClient.PrometheusRules(v1.ClusterScanNS).Create(ctx, alertRule, metav1.CreateOptions{})
 					if err != nil {
-						logrus.Errorf("Alerts will not be sent out for this scan %v due to this error when creating PrometheusRule: %v", obj.Name, err)
+						scanLog(obj).Errorf("Alerts will not be sent out for this scan %v due to this error when creating PrometheusRule: %v", obj.Name, err)
 					} else {
 						obj.Status.ScanAlertingRuleName = ruleCreated.Name
 					}
@@ -139,14 +233,38 @@ func (c *Controller) handleClusterScans(ctx context.Context) error {
 				if v1.ClusterScanConditionFailed.IsTrue(obj) {
 					//clear the earlier failed status
 					v1.ClusterScanConditionFailed.False(obj)
+					v1.ClusterScanConditionFailed.Reason(obj, "")
 				}
 				obj.Status.LastRunTimestamp = time.Now().Round(time.Second).Format(time.RFC3339)
+				recordProfileRename(obj, profile.Name, time.Now())
 				obj.Status.LastRunScanProfileName = profile.Name
+				if obj.Status.NextRetryAt != "" {
+					obj.Status.Attempts++
+					obj.Status.NextRetryAt = ""
+				} else {
+					obj.Status.Attempts = 1
+				}
+				if err := c.setScanEstimate(obj, profile.Spec.BenchmarkVersion, scanWindowsNodes); err != nil {
+					scanLog(obj).Errorf("Error estimating the duration of scan %v: %v", obj.Name, err)
+				}
+				obj.Status.Progress = nil
+				setScanPhase(obj, v1.ClusterScanPhaseLaunching, time.Now())
 				v1.ClusterScanConditionCreated.True(obj)
 				v1.ClusterScanConditionRunCompleted.Unknown(obj)
 				v1.ClusterScanConditionRunCompleted.Message(obj, "Creating Job to run the CIS scan")
 				c.setClusterScanStatusDisplay(obj)
-				c.currentScanName = obj.Name
+				launchEnd := time.Now()
+				if queuedAt, err := time.Parse(time.RFC3339, obj.Status.QueuedAt); err == nil && queuedAt.Before(launchStart) {
+					c.traceScanPhase(obj, spanQueue, queuedAt, launchStart, nil, nil)
+				}
+				c.traceScanPhase(obj, spanLaunch, launchStart, launchEnd, nil, map[string]interface{}{
+					"cis.scan.benchmark":     profile.Spec.BenchmarkVersion,
+					"cis.scan.objects":       len(scanObjects),
+					"cis.scan.windows_nodes": scanWindowsNodes,
+				})
+				obj.Status.QueuedAt = ""
+				c.launchedScans[obj.Name] = true
+				c.scanCosts.startRun(obj, objects)
 				return objects, obj.Status, nil
 			}
 			return objects, obj.Status, nil
@@ -158,26 +276,22 @@ func (c *Controller) handleClusterScans(ctx context.Context) error {
 	return nil
 }
 
-func (c *Controller) isScanPresent(scanName string) (bool, error) {
-	// get the scan being run
-	_, err := c.scans.Get(scanName, metav1.GetOptions{})
-	if err != nil {
-		if errors.IsNotFound(err) {
-			// scan is gone
-			return false, nil
-		}
-		return false, err
-	}
-	return true, nil
+func (c *Controller) getClusterScanProfile(ctx context.Context, scan *v1.ClusterScan) (*v1.ClusterScanProfile, error) {
+	profile, _, err := c.resolveClusterScanProfile(ctx, scan)
+	return profile, err
 }
 
-func (c *Controller) getClusterScanProfile(ctx context.Context, scan *v1.ClusterScan) (*v1.ClusterScanProfile, error) {
+// resolveClusterScanProfile returns the profile the scan runs. When its benchmark does not support the
+// Kubernetes version of the cluster, the returned unsupportedVersionError is also set and the scan only
+// gets a profile if it allows the closest match: its own profile, or the profile of the nearest benchmark
+// in place of the default one.
+func (c *Controller) resolveClusterScanProfile(ctx context.Context, scan *v1.ClusterScan) (*v1.ClusterScanProfile, *unsupportedVersionError, error) {
 	var profileName string
 	var err error
 	clusterscanprofiles := c.cisFactory.Cis().V1().ClusterScanProfile()
 	err = c.refreshClusterKubernetesVersion(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error trying to read cluster's k8s version %w", err)
+		return nil, nil, fmt.Errorf("error trying to read cluster's k8s version %w", err)
 	}
 
 	if scan.Spec.ScanProfileName != "" {
@@ -186,18 +300,29 @@ func (c *Controller) getClusterScanProfile(ctx context.Context, scan *v1.Cluster
 		//pick the default profile by checking the cluster provider
 		profileName, err = c.getDefaultClusterScanProfile(c.ClusterProvider, c.KubernetesVersion)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 	profile, err := clusterscanprofiles.Get(profileName, metav1.GetOptions{})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	err = c.validateClusterScanProfile(profile)
-	if err != nil {
-		return nil, err
+	unsupported, ok := err.(*unsupportedVersionError)
+	if !ok {
+		return profile, nil, err
+	}
+	if !scan.Spec.AllowClosestMatch {
+		return nil, unsupported, err
 	}
-	return profile, nil
+	if scan.Spec.ScanProfileName == "" && unsupported.nearestProfile != "" {
+		profile, err = clusterscanprofiles.Get(unsupported.nearestProfile, metav1.GetOptions{})
+		if err != nil {
+			return nil, unsupported, err
+		}
+	}
+	logrus.Warnf("%v, running ClusterScanProfile %v for scan %v as allowed by allowClosestMatch", unsupported.Error(), profile.Name, scan.Name)
+	return profile, unsupported, nil
 }
 
 func (c *Controller) getClusterScanBenchmark(profile *v1.ClusterScanProfile) (*v1.ClusterScanBenchmark, error) {
@@ -207,14 +332,18 @@ func (c *Controller) getClusterScanBenchmark(profile *v1.ClusterScanProfile) (*v
 
 func (c *Controller) getDefaultClusterScanProfile(clusterprovider string, clusterK8sVersion string) (string, error) {
 	var err error
-	configmaps := c.coreFactory.Core().V1().ConfigMap()
-	cm, err := configmaps.Cache().Get(v1.ClusterScanNS, v1.DefaultClusterScanProfileConfigMap)
+	defaults, err := c.getDefaultProfiles()
 	if err != nil {
-		return "", fmt.Errorf("Configmap to load default ClusterScanProfiles not found: %w", err)
+		return "", err
 	}
-	profileName, ok := cm.Data[clusterprovider]
+	profileName, ok := defaults[clusterprovider]
 	if !ok {
-		profileName = cm.Data["default"]
+		if isManagedClusterProvider(clusterprovider) {
+			// managed control planes can't be audited, so the generic default would
+			// report most checks as N/A; pick the provider's own benchmark instead
+			return c.getManagedClusterScanProfile(clusterprovider)
+		}
+		profileName = defaults["default"]
 	}
 	lines := c.splitLines(profileName)
 	if len(lines) > 1 {
@@ -243,11 +372,72 @@ func (c *Controller) getDefaultClusterScanProfile(clusterprovider string, cluste
 				}
 			}
 		}
-		return cm.Data["default"], nil
+		return defaults["default"], nil
 	}
 	return profileName, nil
 }
 
+// getDefaultProfiles returns the entries of the default profiles ConfigMap, with those of the OperatorConfig taking
+// precedence. The ConfigMap may be missing when the OperatorConfig sets the default entry.
+func (c *Controller) getDefaultProfiles() (map[string]string, error) {
+	config := c.getImageConfig()
+	defaults := map[string]string{}
+	cm, err := c.configMapCache.Get(v1.ClusterScanNS, config.DefaultProfilesConfigMap)
+	if err == nil {
+		for provider, entry := range cm.Data {
+			defaults[provider] = entry
+		}
+	} else if _, ok := config.DefaultProfiles["default"]; !ok {
+		return nil, fmt.Errorf("Configmap %v to load default ClusterScanProfiles not found: %w", config.DefaultProfilesConfigMap, err)
+	}
+	for provider, entry := range config.DefaultProfiles {
+		defaults[provider] = entry
+	}
+	return defaults, nil
+}
+
+func (c *Controller) getManagedClusterScanProfile(clusterprovider string) (string, error) {
+	profiles, err := c.cisFactory.Cis().V1().ClusterScanProfile().Cache().List(labels.Everything())
+	if err != nil {
+		return "", fmt.Errorf("error listing ClusterScanProfiles: %w", err)
+	}
+	// prefer the most recent benchmark revision when several profiles match
+	sort.Slice(profiles, func(i, j int) bool {
+		return isNewerBenchmarkVersion(profiles[i].Spec.BenchmarkVersion, profiles[j].Spec.BenchmarkVersion)
+	})
+	for _, profile := range profiles {
+		benchmark, err := c.getClusterScanBenchmark(profile)
+		if err != nil || !strings.EqualFold(benchmark.Spec.ClusterProvider, clusterprovider) {
+			continue
+		}
+		if err := c.validateClusterScanProfile(profile); err != nil {
+			logrus.Debugf("Skipping ClusterScanProfile %v for provider %v: %v", profile.Name, clusterprovider, err)
+			continue
+		}
+		return profile.Name, nil
+	}
+	return "", fmt.Errorf("no ClusterScanProfile with a %v benchmark is valid for this cluster's K8s version %v", clusterprovider, c.KubernetesVersion)
+}
+
+// isNewerBenchmarkVersion compares the version suffixes of the benchmark versions, e.g. 1.23 of rke2-cis-1.23 is newer
+// than 1.9 of rke2-cis-1.9. The versions without a parsable suffix are compared as strings.
+func isNewerBenchmarkVersion(a, b string) bool {
+	versionA, errA := semver.ParseTolerant(a[strings.LastIndex(a, "-")+1:])
+	versionB, errB := semver.ParseTolerant(b[strings.LastIndex(b, "-")+1:])
+	if errA != nil || errB != nil || versionA.EQ(versionB) {
+		return a > b
+	}
+	return versionA.GT(versionB)
+}
+
+func isManagedClusterProvider(clusterprovider string) bool {
+	switch strings.ToLower(clusterprovider) {
+	case v1.ClusterProviderEKS, v1.ClusterProviderGKE, v1.ClusterProviderAKS:
+		return true
+	}
+	return false
+}
+
 func (c *Controller) splitLines(s string) []string {
 	var lines []string
 	sc := bufio.NewScanner(strings.NewReader(s))
@@ -272,46 +462,52 @@ func (c Controller) validateClusterScanProfile(profile *v1.ClusterScanProfile) e
 		}
 	}
 
+	for _, exemption := range profile.Spec.Exemptions {
+		if exemption.TestID == "" {
+			return fmt.Errorf("ClusterScanProfile %v has an exemption without testID", profile.Name)
+		}
+		if exemption.Expiry != "" {
+			if _, err := v1.ParseExemptionExpiry(exemption.Expiry); err != nil {
+				return fmt.Errorf("ClusterScanProfile %v has an invalid expiry %q for the exemption of test %v, must be a date or RFC3339 time", profile.Name, exemption.Expiry, exemption.TestID)
+			}
+		}
+	}
+
 	// validate cluster's k8s version matches the benchmark's k8s version range
 	clusterK8sToMatch, err := semver.Make(c.KubernetesVersion[1:])
 	if err != nil {
 		return fmt.Errorf("Cluster's k8sVersion is not sem-ver %s %w", c.KubernetesVersion, err)
 	}
-	var k8sRange string
-	if benchmark.Spec.MinKubernetesVersion != "" {
-		k8sRange = ">=" + benchmark.Spec.MinKubernetesVersion
-	}
-	if benchmark.Spec.MaxKubernetesVersion != "" {
-		k8sRange = k8sRange + " <=" + benchmark.Spec.MaxKubernetesVersion
-	}
-	if k8sRange != "" {
+	if k8sRange := benchmarkRange(benchmark); k8sRange != "" {
 		benchmarkK8sRange, err := semver.ParseRange(k8sRange)
 		if err != nil {
 			return fmt.Errorf("Range for Benchmark %s not sem-ver %v, error: %w", benchmark.Name, k8sRange, err)
 		}
 		if !benchmarkK8sRange(clusterK8sToMatch) {
-			return fmt.Errorf("Kubernetes version mismatch, ClusterScanProfile %v is not valid for this cluster's K8s version %v", profile.Name, c.KubernetesVersion)
+			return c.newUnsupportedVersionError(profile, clusterK8sToMatch)
 		}
 	}
 
 	return nil
 }
 
-func (c Controller) isRunnerPodPresent() error {
+// checkRunnerCapacity guards against launching more scan runners than allowed, e.g. if runners
+// of deleted scans are still terminating, and against the runners of CIS v1
+func (c Controller) checkRunnerCapacity() error {
 	v2Jobs, err := c.listScanRunnerJobs(v1.ClusterScanNS)
 	if err != nil {
 		return fmt.Errorf("error listing jobs: %w", err)
 	}
-	if v2Jobs != 0 {
-		return fmt.Errorf("A rancher-cis-benchmark scan runner job is already running")
+	if v2Jobs >= c.getImageConfig().MaxConcurrentScans {
+		return fmt.Errorf("%d rancher-cis-benchmark scan runner jobs are already running", v2Jobs)
 	}
 
 	v2Pods, err := c.listRunnerPods(v1.ClusterScanNS)
 	if err != nil {
 		return fmt.Errorf("error listing pods: %w", err)
 	}
-	if v2Pods != 0 {
-		return fmt.Errorf("A rancher-cis-benchmark runner pod is already running")
+	if v2Pods >= c.getImageConfig().MaxConcurrentScans {
+		return fmt.Errorf("%d rancher-cis-benchmark runner pods are already running", v2Pods)
 	}
 
 	v1Pods, err := c.listRunnerPods(v1.CISV1NS)
@@ -349,8 +545,11 @@ func (c Controller) setClusterScanStatusDisplay(scan *v1.ClusterScan) {
 	failedState := "fail"
 	passedState := "pass"
 	message := ""
+	defer setClusterScanStandardConditions(scan)
 
 	failed := false
+	cancelled := false
+	suspended := false
 	completed := false
 	runCompleted := false
 	pending := false
@@ -369,6 +568,12 @@ func (c Controller) setClusterScanStatusDisplay(scan *v1.ClusterScan) {
 		message = v1.ClusterScanConditionFailed.GetMessage(scan)
 		failed = true
 	}
+	if v1.ClusterScanConditionCancelled.IsTrue(scan) {
+		cancelled = true
+	}
+	if v1.ClusterScanConditionSuspended.IsTrue(scan) {
+		suspended = true
+	}
 	if v1.ClusterScanConditionComplete.IsTrue(scan) {
 		completed = true
 	}
@@ -384,6 +589,9 @@ func (c Controller) setClusterScanStatusDisplay(scan *v1.ClusterScan) {
 	if running {
 		display.State = "running"
 		display.Message = ""
+		if progress := scan.Status.Progress; progress != nil && progress.NodesTotal > 0 {
+			display.Message = fmt.Sprintf("Scanned %d of %d nodes", progress.NodesCompleted, progress.NodesTotal)
+		}
 		display.Transitioning = true
 		display.Error = false
 	}
@@ -393,6 +601,20 @@ func (c Controller) setClusterScanStatusDisplay(scan *v1.ClusterScan) {
 		display.Transitioning = true
 		display.Error = false
 	}
+	if cancelled {
+		display.State = "cancelled"
+		display.Message = v1.ClusterScanConditionCancelled.GetMessage(scan)
+		display.Transitioning = false
+		display.Error = false
+		return
+	}
+	if suspended && !pending && !running && !runCompleted {
+		display.State = "suspended"
+		display.Message = v1.ClusterScanConditionSuspended.GetMessage(scan)
+		display.Transitioning = false
+		display.Error = false
+		return
+	}
 	if failed {
 		display.State = errorState
 		display.Message = message
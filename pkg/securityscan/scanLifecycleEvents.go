@@ -0,0 +1,123 @@
+package securityscan
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/scanevents"
+)
+
+// reasons of the Kubernetes Events recorded against the ClusterScans
+const (
+	EventReasonScanStarted   = "ScanStarted"
+	EventReasonScanCompleted = "ScanCompleted"
+	EventReasonScanFailed    = "ScanFailed"
+	EventReasonScanCancelled = "ScanCancelled"
+	EventReasonReportCreated = "ReportCreated"
+	EventReasonAlertSent     = "AlertSent"
+	EventReasonNodeAction    = "NodeAction"
+)
+
+// recordScanEvent records a Kubernetes Event against the scan, in the default namespace as the scans are
+// cluster-scoped. The Events are best effort, an error is only logged.
+func (c *Controller) recordScanEvent(ctx context.Context, scan *v1.ClusterScan, eventType, reason, message string) {
+	now := metav1.Now()
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: scan.Name + ".",
+			Namespace:    metav1.NamespaceDefault,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion:      v1.SchemeGroupVersion.String(),
+			Kind:            "ClusterScan",
+			Name:            scan.Name,
+			UID:             scan.UID,
+			ResourceVersion: scan.ResourceVersion,
+		},
+		Reason:         reason,
+		Message:        message,
+		Type:           eventType,
+		Source:         corev1.EventSource{Component: c.Name},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	if _, err := c.kcs.CoreV1().Events(metav1.NamespaceDefault).Create(ctx, event, metav1.CreateOptions{}); err != nil {
+		logrus.Errorf("Error recording the %v event of scan %v: %v", reason, scan.Name, err)
+	}
+}
+
+// scan events record an Event against the ClusterScan for every stage its runs reach, as in the scan event log.
+// The last stage recorded per scan is kept in memory, the stages reached before the operator started are not
+// recorded again.
+func (c *Controller) handleScanLifecycleEvents(ctx context.Context) error {
+	scans := c.cisFactory.Cis().V1().ClusterScan()
+	startTime := time.Now()
+	var lock sync.Mutex
+	recorded := map[string]string{}
+
+	scans.OnChange(ctx, c.Name, func(key string, obj *v1.ClusterScan) (*v1.ClusterScan, error) {
+		if obj == nil || obj.DeletionTimestamp != nil {
+			lock.Lock()
+			delete(recorded, key)
+			lock.Unlock()
+			return obj, nil
+		}
+		if !c.ownsName(obj.Name) {
+			return obj, nil
+		}
+		stage, cond := getScanStage(obj)
+		if stage == "" {
+			return obj, nil
+		}
+		run := obj.Status.LastRunTimestamp + "/" + stage
+
+		lock.Lock()
+		last, seen := recorded[key]
+		recorded[key] = run
+		lock.Unlock()
+		if last == run {
+			return obj, nil
+		}
+		if !seen {
+			if updated, err := time.Parse(time.RFC3339, cond.GetLastUpdated(obj)); err == nil && updated.Before(startTime) {
+				return obj, nil
+			}
+		}
+		c.recordScanStage(ctx, obj, stage)
+		return obj, nil
+	})
+	return nil
+}
+
+func (c *Controller) recordScanStage(ctx context.Context, scan *v1.ClusterScan, stage string) {
+	switch stage {
+	case scanevents.StageStarted:
+		c.recordScanEvent(ctx, scan, corev1.EventTypeNormal, EventReasonScanStarted, fmt.Sprintf("Started scan with ClusterScanProfile %v", scan.Status.LastRunScanProfileName))
+	case scanevents.StageCompleted:
+		message := "Completed scan"
+		if summary := scan.Status.Summary; summary != nil {
+			message = fmt.Sprintf("Completed scan: %d checks, %d pass, %d fail, %d skip, %d warn, %d not applicable",
+				summary.Total, summary.Pass, summary.Fail, summary.Skip, summary.Warn, summary.NotApplicable)
+		}
+		c.recordScanEvent(ctx, scan, corev1.EventTypeNormal, EventReasonScanCompleted, message)
+	case scanevents.StageFailed:
+		message := v1.ClusterScanConditionFailed.GetMessage(scan)
+		if message == "" {
+			message = "Scan failed"
+		}
+		if reason := v1.ClusterScanConditionFailed.GetReason(scan); reason != "" {
+			message = reason + ": " + message
+		}
+		c.recordScanEvent(ctx, scan, corev1.EventTypeWarning, EventReasonScanFailed, message)
+	case scanevents.StageCancelled:
+		c.recordScanEvent(ctx, scan, corev1.EventTypeNormal, EventReasonScanCancelled, v1.ClusterScanConditionCancelled.GetMessage(scan))
+	}
+}
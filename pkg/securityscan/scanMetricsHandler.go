@@ -3,13 +3,173 @@ package securityscan
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 )
 
+// scanMetricsRun is the run of a scan whose results a metric series holds
+type scanMetricsRun struct {
+	// UID of the scan and start of the run
+	id string
+	// start of the run, the series only move to later runs
+	startedAt time.Time
+}
+
+// scanMetrics are the values of the metrics of a completed scan run, computed before they are applied at once
+type scanMetrics struct {
+	run                                    scanMetricsRun
+	scanName, scanProfileName, clusterName string
+	summary                                v1.ClusterScanSummary
+	// the series of the scheduled scans move to the profile of their last run
+	scheduled      bool
+	profileRenames []v1.ClusterScanProfileRename
+
+	failureAgeDays map[string]float64
+	mttrSeconds    map[string]float64
+	driftedChecks  map[string]int
+	regressions    *int
+	budgetExceeded *bool
+	postureDrift   *int
+	score          *float64
+	trend          []v1.ClusterScanTrendPoint
+	teamSummaries  map[string]v1.ClusterScanSummary
+	cost           *v1.ClusterScanCost
+}
+
+// getScanMetrics returns the values of the metrics of the last run of a completed scan
+func getScanMetrics(obj *v1.ClusterScan, clusterName string, now time.Time) *scanMetrics {
+	m := &scanMetrics{
+		run:             scanMetricsRun{id: string(obj.UID) + "/" + obj.Status.LastRunTimestamp},
+		scanName:        "manual",
+		scanProfileName: obj.Status.LastRunScanProfileName,
+		clusterName:     clusterName,
+		failureAgeDays:  getFailureAgeBySection(obj.Status.FailingChecks, now),
+		mttrSeconds:     getMTTRBySection(obj.Status.RemediatedChecks),
+		driftedChecks:   obj.Status.DriftedChecks,
+		trend:           obj.Status.Trend,
+		teamSummaries:   obj.Status.TeamSummaries,
+		cost:            obj.Status.Cost,
+	}
+	if startedAt, err := time.Parse(time.RFC3339, obj.Status.LastRunTimestamp); err == nil {
+		m.run.startedAt = startedAt
+	}
+	if isScheduledScan(obj) {
+		m.scanName = obj.Name
+		m.scheduled = true
+		m.profileRenames = obj.Status.ProfileRenames
+	}
+	if obj.Status.Summary != nil {
+		m.summary = *obj.Status.Summary
+	}
+	if obj.Spec.BaselineReportName != "" {
+		regressions := len(obj.Status.Regressions)
+		m.regressions = &regressions
+	}
+	if hasRegressionBudget(obj) {
+		exceeded := v1.ClusterScanConditionRegressionBudgetExceeded.IsTrue(obj)
+		m.budgetExceeded = &exceeded
+	}
+	if obj.Spec.Continuous != nil {
+		postureDrift := len(obj.Status.PostureDrift)
+		m.postureDrift = &postureDrift
+	}
+	if obj.Status.ComplianceScore != nil {
+		m.score = &obj.Status.ComplianceScore.Score
+	}
+	return m
+}
+
+// applyScanMetrics sets the metrics of a scan run, unless its series already hold the results of that run or of a
+// later one, and returns whether it did. The runs of the manual scans share their series: the updates of concurrent
+// runs are applied one at a time, each one entirely, and a run completing after a later one is left out. The series
+// of a scheduled scan move to the profile of its last run, see migrateProfileSeries.
+func (c *Controller) applyScanMetrics(m *scanMetrics) bool {
+	c.metricsMu.Lock()
+	defer c.metricsMu.Unlock()
+
+	seriesKey := m.scanName + "/" + m.scanProfileName + "/" + m.clusterName
+	if last, ok := c.metricsRuns[seriesKey]; ok && (last.id == m.run.id || last.startedAt.After(m.run.startedAt)) {
+		return false
+	}
+	if !c.migrateProfileSeries(m) {
+		return false
+	}
+	c.metricsRuns[seriesKey] = m.run
+
+	scanName, scanProfileName, clusterName := m.scanName, m.scanProfileName, m.clusterName
+	seriesLabels := prometheus.Labels{"scan_name": scanName, "scan_profile_name": scanProfileName, "cluster_name": clusterName}
+	c.numTestsFailed.WithLabelValues(scanName, scanProfileName, clusterName).Set(float64(m.summary.Fail))
+	c.numScansComplete.WithLabelValues(scanName, scanProfileName, clusterName).Inc()
+	c.numTestsTotal.WithLabelValues(scanName, scanProfileName, clusterName).Set(float64(m.summary.Total))
+	c.numTestsPassed.WithLabelValues(scanName, scanProfileName, clusterName).Set(float64(m.summary.Pass))
+	c.numTestsSkipped.WithLabelValues(scanName, scanProfileName, clusterName).Set(float64(m.summary.Skip))
+	c.numTestsNA.WithLabelValues(scanName, scanProfileName, clusterName).Set(float64(m.summary.NotApplicable))
+	c.numTestsWarn.WithLabelValues(scanName, scanProfileName, clusterName).Set(float64(m.summary.Warn))
+	c.numFailureAgeDays.DeletePartialMatch(seriesLabels)
+	for section, days := range m.failureAgeDays {
+		c.numFailureAgeDays.WithLabelValues(scanName, scanProfileName, section, clusterName).Set(days)
+	}
+	c.numCheckMTTRSeconds.DeletePartialMatch(seriesLabels)
+	for section, seconds := range m.mttrSeconds {
+		c.numCheckMTTRSeconds.WithLabelValues(scanName, scanProfileName, section, clusterName).Set(seconds)
+	}
+	c.numDriftedChecks.DeletePartialMatch(seriesLabels)
+	for nodeType, count := range m.driftedChecks {
+		c.numDriftedChecks.WithLabelValues(scanName, scanProfileName, nodeType, clusterName).Set(float64(count))
+	}
+	if m.regressions != nil {
+		c.numRegressions.WithLabelValues(scanName, scanProfileName, clusterName).Set(float64(*m.regressions))
+	} else {
+		c.numRegressions.DeleteLabelValues(scanName, scanProfileName, clusterName)
+	}
+	if m.budgetExceeded != nil {
+		exceeded := 0.0
+		if *m.budgetExceeded {
+			exceeded = 1
+		}
+		c.budgetExceeded.WithLabelValues(scanName, scanProfileName, clusterName).Set(exceeded)
+	} else {
+		c.budgetExceeded.DeleteLabelValues(scanName, scanProfileName, clusterName)
+	}
+	if m.postureDrift != nil {
+		c.numPostureDrift.WithLabelValues(scanName, scanProfileName, clusterName).Set(float64(*m.postureDrift))
+	} else {
+		c.numPostureDrift.DeleteLabelValues(scanName, scanProfileName, clusterName)
+	}
+	if m.score != nil {
+		c.score.WithLabelValues(scanName, scanProfileName, clusterName).Set(*m.score)
+	}
+	c.trendScore.DeletePartialMatch(seriesLabels)
+	c.trendNumTestsFailed.DeletePartialMatch(seriesLabels)
+	for i, point := range m.trend {
+		runsAgo := strconv.Itoa(len(m.trend) - 1 - i)
+		c.trendScore.WithLabelValues(scanName, scanProfileName, runsAgo, clusterName).Set(point.Score)
+		c.trendNumTestsFailed.WithLabelValues(scanName, scanProfileName, runsAgo, clusterName).Set(float64(point.Fail))
+	}
+	for team, summary := range m.teamSummaries {
+		c.numTeamTestsFailed.WithLabelValues(scanName, scanProfileName, team, clusterName).Set(float64(summary.Fail))
+		c.numTeamTestsTotal.WithLabelValues(scanName, scanProfileName, team, clusterName).Set(float64(summary.Total))
+	}
+	if m.cost != nil {
+		c.numAPIRequests.DeletePartialMatch(seriesLabels)
+		for verb, count := range m.cost.APIRequestsByVerb {
+			c.numAPIRequests.WithLabelValues(scanName, scanProfileName, verb, clusterName).Set(float64(count))
+			c.apiRequestsTotal.WithLabelValues(scanName, scanProfileName, verb, clusterName).Add(float64(count))
+		}
+		c.numPods.WithLabelValues(scanName, scanProfileName, clusterName).Set(float64(m.cost.Pods))
+		c.podsTotal.WithLabelValues(scanName, scanProfileName, clusterName).Add(float64(m.cost.Pods))
+		c.runDurationSeconds.WithLabelValues(scanName, scanProfileName, clusterName).Set(float64(m.cost.DurationSeconds))
+	}
+	return true
+}
+
 func (c *Controller) handleClusterScanMetrics(ctx context.Context) error {
 	scans := c.cisFactory.Cis().V1().ClusterScan()
 
@@ -17,38 +177,25 @@ func (c *Controller) handleClusterScanMetrics(ctx context.Context) error {
 		if obj == nil || obj.DeletionTimestamp != nil {
 			return obj, nil
 		}
+		if !c.ownsName(obj.Name) {
+			return obj, nil
+		}
 		if !(v1.ClusterScanConditionAlerted.IsUnknown(obj) && v1.ClusterScanConditionComplete.IsTrue(obj)) {
 			return obj, nil
 		}
 
+		alertStart := time.Now()
 		logrus.Debugf("Updating metrics for scan %v", obj.Name)
-
-		scanName := "manual"
-		if obj.Spec.ScheduledScanConfig != nil && obj.Spec.ScheduledScanConfig.CronSchedule != "" {
-			scanName = obj.Name
+		if !c.applyScanMetrics(getScanMetrics(obj, c.getImageConfig().ClusterName, time.Now())) {
+			logrus.Debugf("Metrics of scan %v already hold the results of its run or of a later one", obj.Name)
 		}
-		scanProfileName := obj.Status.LastRunScanProfileName
-		numTestsFailed := float64(obj.Status.Summary.Fail)
-		numTestsTotal := float64(obj.Status.Summary.Total)
-		numTestsNA := float64(obj.Status.Summary.NotApplicable)
-		numTestsSkip := float64(obj.Status.Summary.Skip)
-		numTestsPass := float64(obj.Status.Summary.Pass)
-		numTestsWarn := float64(obj.Status.Summary.Warn)
-		clusterName := c.ImageConfig.ClusterName
-
-		c.numTestsFailed.WithLabelValues(scanName, scanProfileName, clusterName).Set(numTestsFailed)
-		c.numScansComplete.WithLabelValues(scanName, scanProfileName, clusterName).Inc()
-		c.numTestsTotal.WithLabelValues(scanName, scanProfileName, clusterName).Set(numTestsTotal)
-		c.numTestsPassed.WithLabelValues(scanName, scanProfileName, clusterName).Set(numTestsPass)
-		c.numTestsSkipped.WithLabelValues(scanName, scanProfileName, clusterName).Set(numTestsSkip)
-		c.numTestsNA.WithLabelValues(scanName, scanProfileName, clusterName).Set(numTestsNA)
-		c.numTestsWarn.WithLabelValues(scanName, scanProfileName, clusterName).Set(numTestsWarn)
-
 		logrus.Debugf("Done updating metrics for scan %v", obj.Name)
 
 		if obj.Spec.ScheduledScanConfig != nil {
+			alertSent := false
 			updateErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 				var err error
+				alertSent = false
 				scanObj, err := scans.Get(obj.Name, metav1.GetOptions{})
 				if err != nil {
 					return err
@@ -66,14 +213,24 @@ func (c *Controller) handleClusterScanMetrics(ctx context.Context) error {
 					v1.ClusterScanConditionAlerted.Message(scanObj, "Alerts will not work due to the error creating PrometheusRule, Please check if Monitoring app is installed")
 				} else {
 					v1.ClusterScanConditionAlerted.True(scanObj)
+					alertSent = true
 				}
 				_, err = scans.UpdateStatus(scanObj)
 				return err
 			})
 
+			c.traceScanPhase(obj, spanAlerting, alertStart, time.Now(), updateErr, map[string]interface{}{
+				"cis.alert.sent": alertSent,
+				"cis.alert.rule": obj.Status.ScanAlertingRuleName,
+			})
 			if updateErr != nil {
 				return obj, fmt.Errorf("Retrying, got error %v in updating condition of scan object: %v ", updateErr, obj.Name)
 			}
+			if alertSent {
+				c.recordScanEvent(ctx, obj, corev1.EventTypeNormal, EventReasonAlertSent, fmt.Sprintf("Exported the results of the scan to the alerts of PrometheusRule %v", obj.Status.ScanAlertingRuleName))
+			}
+		} else {
+			c.traceScanPhase(obj, spanAlerting, alertStart, time.Now(), nil, map[string]interface{}{"cis.alert.sent": false})
 		}
 
 		return obj, nil
@@ -0,0 +1,106 @@
+package securityscan
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// scanQueueRecheckInterval is how often a queued scan rechecks its turn, on top of the requeue on every scan completion
+const scanQueueRecheckInterval = 30 * time.Second
+
+// admitScan returns whether a pending scan can be launched: at most MaxConcurrentScans run at once and the
+// queued scans are launched in the order they were queued, both among the scans of the shard of this replica. Otherwise it returns the queue position message.
+// It must be called with c.mu held.
+func (c *Controller) admitScan(obj *v1.ClusterScan) (bool, string, error) {
+	scans, err := c.scans.Cache().List(labels.Everything())
+	if err != nil {
+		return false, "", err
+	}
+	present := map[string]bool{}
+	running := map[string]bool{}
+	queued := []*v1.ClusterScan{obj}
+	for _, scan := range scans {
+		present[scan.Name] = true
+		switch {
+		case scan.Name == obj.Name || scan.DeletionTimestamp != nil || !c.ownsName(scan.Name):
+		case v1.ClusterScanConditionCreated.IsTrue(scan) && !v1.ClusterScanConditionComplete.IsTrue(scan):
+			running[scan.Name] = true
+		case isQueuedScan(scan):
+			queued = append(queued, scan)
+		}
+	}
+	// scans launched but whose status is not in the cache yet
+	for name := range c.launchedScans {
+		if !present[name] {
+			delete(c.launchedScans, name)
+			continue
+		}
+		if name != obj.Name {
+			running[name] = true
+		}
+	}
+
+	sort.SliceStable(queued, func(i, j int) bool {
+		return queuedBefore(queued[i], queued[j])
+	})
+	position := 0
+	for position < len(queued) && queued[position].Name != obj.Name {
+		position++
+	}
+	freeSlots := c.getImageConfig().MaxConcurrentScans - len(running)
+	if position < freeSlots {
+		return true, "", nil
+	}
+	return false, fmt.Sprintf("ClusterScan queued at position %d of %d, waiting for %d running scans to finish", position+1, len(queued), len(running)), nil
+}
+
+// isQueuedScan returns whether a scan waits for its turn to run, the scans waiting for a retry backoff don't
+func isQueuedScan(scan *v1.ClusterScan) bool {
+	if scan.Status.LastRunTimestamp != "" || v1.ClusterScanConditionCreated.IsTrue(scan) || !v1.ClusterScanConditionPending.IsTrue(scan) {
+		return false
+	}
+	if scan.Status.NextRetryAt != "" {
+		if retryAt, err := time.Parse(time.RFC3339, scan.Status.NextRetryAt); err == nil && retryAt.After(time.Now()) {
+			return false
+		}
+	}
+	return true
+}
+
+// queuedBefore orders the queued scans by queue time, then creation time and name
+func queuedBefore(a, b *v1.ClusterScan) bool {
+	if a.Status.QueuedAt != b.Status.QueuedAt {
+		// scans not queued yet sort last
+		if a.Status.QueuedAt == "" || b.Status.QueuedAt == "" {
+			return b.Status.QueuedAt == ""
+		}
+		return a.Status.QueuedAt < b.Status.QueuedAt
+	}
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
+
+// releaseScan frees the slot of a complete scan and lets the queued scans check their turn
+func (c *Controller) releaseScan(scanName string) {
+	c.mu.Lock()
+	delete(c.launchedScans, scanName)
+	c.mu.Unlock()
+	c.scanCosts.stopRun(scanName)
+
+	scans, err := c.scans.Cache().List(labels.Everything())
+	if err != nil {
+		return
+	}
+	for _, scan := range scans {
+		if isQueuedScan(scan) {
+			c.scans.Enqueue(scan.Name)
+		}
+	}
+}
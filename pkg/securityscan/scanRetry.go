@@ -0,0 +1,70 @@
+package securityscan
+
+import (
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batchctlv1 "github.com/rancher/wrangler/pkg/generated/controllers/batch/v1"
+	"github.com/rancher/wrangler/pkg/genericcondition"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// maxRetryBackoff caps the doubling of the retry backoff
+const maxRetryBackoff = time.Hour
+
+// canRetryScan returns whether the retry policy of a failed scan allows another run
+func canRetryScan(scan *v1.ClusterScan) bool {
+	policy := scan.Spec.RetryPolicy
+	return policy != nil && scan.Status.Attempts < policy.MaxAttempts
+}
+
+// getRetryBackoff returns the delay before the next retry, doubled after every attempt
+func getRetryBackoff(scan *v1.ClusterScan) time.Duration {
+	backoff := time.Duration(v1.DefaultRetryBackoffSeconds) * time.Second
+	if scan.Spec.RetryPolicy.BackoffSeconds > 0 {
+		backoff = time.Duration(scan.Spec.RetryPolicy.BackoffSeconds) * time.Second
+	}
+	for i := 1; i < scan.Status.Attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	return backoff
+}
+
+// retryFailedScan cleans up the failed run and puts the scan back to pending until its retry backoff elapses,
+// the scan handler then launches the next run as for a new scan. The job is nil when it is gone already.
+func (c *Controller) retryFailedScan(jobs batchctlv1.JobController, job *batchv1.Job, scan *v1.ClusterScan) error {
+	if job != nil {
+		if err := c.deleteJob(jobs, job, metav1.DeletePropagationBackground); err != nil {
+			return fmt.Errorf("error deleting job: %w", err)
+		}
+	}
+	if err := c.ensureCleanup(scan); err != nil {
+		return err
+	}
+
+	backoff := getRetryBackoff(scan)
+	scancopy := scan.DeepCopy()
+	failure := v1.ClusterScanConditionFailed.GetMessage(scan)
+	scancopy.Status.Conditions = []genericcondition.GenericCondition{}
+	scancopy.Status.LastRunTimestamp = ""
+	scancopy.Status.Progress = nil
+	scancopy.Status.NextRetryAt = time.Now().Add(backoff).Round(time.Second).Format(time.RFC3339)
+	v1.ClusterScanConditionPending.True(scancopy)
+	v1.ClusterScanConditionPending.Message(scancopy, fmt.Sprintf("Retrying ClusterScan at %v after failed attempt %d of %d: %v",
+		scancopy.Status.NextRetryAt, scan.Status.Attempts, scan.Spec.RetryPolicy.MaxAttempts, failure))
+	c.setClusterScanStatusDisplay(scancopy)
+	if _, err := c.scans.UpdateStatus(scancopy); err != nil {
+		return fmt.Errorf("error updating condition of cluster scan object: %v: %w", scan.Name, err)
+	}
+	scanLog(scan).Infof("Retrying failed scan %v in %v, attempt %d of %d failed: %v", scan.Name, backoff, scan.Status.Attempts, scan.Spec.RetryPolicy.MaxAttempts, failure)
+	c.releaseScan(scan.Name)
+	c.scans.EnqueueAfter(scan.Name, backoff)
+	return nil
+}
@@ -3,11 +3,13 @@ package securityscan
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"sort"
 	"strings"
 	"time"
 
 	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	cisscan "github.com/rancher/cis-operator/pkg/securityscan/scan"
 	"github.com/rancher/wrangler/pkg/genericcondition"
 	"github.com/rancher/wrangler/pkg/name"
 	"github.com/robfig/cron"
@@ -23,10 +25,43 @@ func (c *Controller) handleScheduledClusterScans(ctx context.Context) error {
 		if obj == nil || obj.DeletionTimestamp != nil {
 			return obj, nil
 		}
+		if !c.ownsName(obj.Name) {
+			return obj, nil
+		}
 
-		if obj.Spec.ScheduledScanConfig != nil && obj.Spec.ScheduledScanConfig.CronSchedule == "" {
+		if obj.Spec.ScheduledScanConfig != nil && !isScheduledScan(obj) {
 			return obj, nil
 		}
+		if obj.Spec.Cancel {
+			// no new run until spec.cancel is unset
+			return obj, nil
+		}
+		if isSuspendedScan(obj) {
+			if v1.ClusterScanConditionSuspended.IsTrue(obj) && obj.Status.NextScanAt == "" {
+				return obj, nil
+			}
+			logrus.Infof("scheduledScanHandler: suspending scheduledScan CR %v", obj.Name)
+			objCopy := obj.DeepCopy()
+			v1.ClusterScanConditionSuspended.True(objCopy)
+			v1.ClusterScanConditionSuspended.Message(objCopy, "Scheduled runs are suspended")
+			objCopy.Status.NextScanAt = ""
+			c.setClusterScanStatusDisplay(objCopy)
+			return scheduledScans.UpdateStatus(objCopy)
+		}
+		if v1.ClusterScanConditionSuspended.IsTrue(obj) {
+			// resume from the next scheduled time, the runs missed while suspended are not caught up
+			logrus.Infof("scheduledScanHandler: resuming scheduledScan CR %v", obj.Name)
+			objCopy := obj.DeepCopy()
+			v1.ClusterScanConditionSuspended.False(objCopy)
+			v1.ClusterScanConditionSuspended.Message(objCopy, "")
+			if objCopy.Status.LastRunTimestamp != "" {
+				if err := c.rescheduleScan(objCopy); err != nil {
+					return obj, err
+				}
+			}
+			c.setClusterScanStatusDisplay(objCopy)
+			return scheduledScans.UpdateStatus(objCopy)
+		}
 
 		//if nextScanAt is set then make sure we process only if the time is right
 		if v1.ClusterScanConditionComplete.IsTrue(obj) && obj.Status.LastRunTimestamp != "" && obj.Status.NextScanAt != "" {
@@ -76,22 +111,33 @@ func (c *Controller) handleScheduledClusterScans(ctx context.Context) error {
 	return nil
 }
 
-func (c *Controller) validateScheduledScanSpec(scan *v1.ClusterScan) error {
-	if scan.Spec.ScheduledScanConfig != nil && scan.Spec.ScheduledScanConfig.CronSchedule != "" {
-		_, err := cron.ParseStandard(scan.Spec.ScheduledScanConfig.CronSchedule)
-		if err != nil {
-			return fmt.Errorf("error parsing invalid cron string for schedule: %w", err)
-		}
-	}
-	return nil
+// isScheduledScan returns whether the scan runs on a cron schedule or continuously
+func isScheduledScan(scan *v1.ClusterScan) bool {
+	return scan.Spec.Continuous != nil || (scan.Spec.ScheduledScanConfig != nil && scan.Spec.ScheduledScanConfig.CronSchedule != "")
+}
+
+// isSuspendedScan returns whether the runs of a scheduled scan are suspended
+func isSuspendedScan(scan *v1.ClusterScan) bool {
+	return scan.Spec.Suspend && isScheduledScan(scan)
 }
 
 func (c *Controller) getCronSchedule(scan *v1.ClusterScan) (cron.Schedule, error) {
+	if scan.Spec.Continuous != nil {
+		interval := scan.Spec.Continuous.IntervalMinutes
+		if interval == 0 {
+			interval = v1.DefaultContinuousIntervalMinutes
+		}
+		return cron.Every(time.Duration(interval) * time.Minute), nil
+	}
 	schedule := v1.DefaultCronSchedule
-	if scan.Spec.ScheduledScanConfig != nil && scan.Spec.ScheduledScanConfig.CronSchedule != "" {
-		schedule = scan.Spec.ScheduledScanConfig.CronSchedule
+	timezone := ""
+	if scan.Spec.ScheduledScanConfig != nil {
+		if scan.Spec.ScheduledScanConfig.CronSchedule != "" {
+			schedule = scan.Spec.ScheduledScanConfig.CronSchedule
+		}
+		timezone = scan.Spec.ScheduledScanConfig.Timezone
 	}
-	cronSchedule, err := cron.ParseStandard(schedule)
+	cronSchedule, err := cisscan.ParseCronSchedule(schedule, timezone)
 	if err != nil {
 		return nil, fmt.Errorf("Error parsing invalid cron string for schedule: %w", err)
 	}
@@ -106,6 +152,14 @@ func (c *Controller) getRetentionCount(scan *v1.ClusterScan) int {
 	return retentionCount
 }
 
+// getRetentionDays returns how many days the reports of the scan are kept, 0 keeps them
+func (c *Controller) getRetentionDays(scan *v1.ClusterScan) int {
+	if scan.Spec.ScheduledScanConfig != nil && scan.Spec.ScheduledScanConfig.RetentionDays != 0 {
+		return scan.Spec.ScheduledScanConfig.RetentionDays
+	}
+	return c.getImageConfig().ReportRetentionDays
+}
+
 func (c *Controller) rescheduleScan(scan *v1.ClusterScan) error {
 	scans := c.cisFactory.Cis().V1().ClusterScan()
 	cronSchedule, err := c.getCronSchedule(scan)
@@ -114,15 +168,21 @@ func (c *Controller) rescheduleScan(scan *v1.ClusterScan) error {
 	}
 	now := time.Now()
 	nextScanAt := cronSchedule.Next(now)
+	if scan.Spec.ScheduledScanConfig != nil && scan.Spec.ScheduledScanConfig.JitterSeconds > 0 {
+		nextScanAt = nextScanAt.Add(time.Duration(rand.Int63n(scan.Spec.ScheduledScanConfig.JitterSeconds+1)) * time.Second)
+	}
 	scan.Status.NextScanAt = nextScanAt.Format(time.RFC3339)
 	after := nextScanAt.Sub(now)
 	scans.EnqueueAfter(scan.Name, after)
 	return nil
 }
 
+// purgeOldClusterScanReports deletes the reports of the scan beyond its retention count, and the ones
+// older than its retention days
 func (c *Controller) purgeOldClusterScanReports(obj *v1.ClusterScan) error {
 	reports := c.cisFactory.Cis().V1().ClusterScanReport()
 	retention := c.getRetentionCount(obj)
+	retentionDays := c.getRetentionDays(obj)
 	allClusterScanReportsList, err := reports.List(metav1.ListOptions{})
 	if err != nil {
 		return fmt.Errorf("error listing cluster scans for scheduledScan %v: %w", obj.Name, err)
@@ -133,14 +193,27 @@ func (c *Controller) purgeOldClusterScanReports(obj *v1.ClusterScan) error {
 		if !strings.HasPrefix(cs.Name, name.SafeConcatName("scan-report", obj.Name)+"-") {
 			continue
 		}
+		// the baseline is kept and does not count towards the retention
+		if cs.Name == obj.Spec.BaselineReportName {
+			continue
+		}
 		clusterScanReports = append(clusterScanReports, cs)
 	}
-	if len(clusterScanReports) <= retention {
-		return nil
-	}
 	sort.Slice(clusterScanReports, func(i, j int) bool {
 		return !clusterScanReports[i].CreationTimestamp.Before(&clusterScanReports[j].CreationTimestamp)
 	})
+	if retentionDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -retentionDays)
+		for i, cs := range clusterScanReports {
+			if cs.CreationTimestamp.Time.Before(cutoff) && i < retention {
+				retention = i
+				break
+			}
+		}
+	}
+	if len(clusterScanReports) <= retention {
+		return nil
+	}
 
 	for _, cs := range clusterScanReports[retention:] {
 		logrus.Infof("scheduledScanHandler: purgeOldScans: deleting cs: %v %v", cs.Name, cs.CreationTimestamp.String())
@@ -0,0 +1,125 @@
+package securityscan
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/rancher/wrangler/pkg/name"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/selfcheck"
+)
+
+// runSelfCheck evaluates the operator's own Deployment and RBAC, and the runner job of the scan,
+// against the hardening rules. Errors are logged and skip the affected part, the self-check never
+// holds up the report.
+func (c *Controller) runSelfCheck(ctx context.Context, scan *v1.ClusterScan) []v1.SelfCheckFinding {
+	var findings []v1.SelfCheckFinding
+
+	pod, err := c.getOperatorPod(ctx)
+	if err != nil {
+		logrus.Warnf("Self-check: error getting the operator pod: %v", err)
+	} else {
+		findings = append(findings, selfcheck.CheckPodSpec(c.getOperatorWorkload(ctx, pod), &pod.Spec)...)
+		rbacFindings, err := c.checkOperatorRBAC(ctx, pod.Spec.ServiceAccountName)
+		if err != nil {
+			logrus.Warnf("Self-check: error checking the operator RBAC: %v", err)
+		}
+		findings = append(findings, rbacFindings...)
+	}
+
+	jobName := name.SafeConcatName("security-scan-runner", scan.Name)
+	job, err := c.jobs.Cache().Get(v1.ClusterScanNS, jobName)
+	if err != nil {
+		logrus.Warnf("Self-check: error getting the runner job %v: %v", jobName, err)
+	} else {
+		findings = append(findings, selfcheck.CheckPodSpec(fmt.Sprintf("Job/%v/%v", job.Namespace, job.Name), &job.Spec.Template.Spec)...)
+	}
+	return findings
+}
+
+// getOperatorPod returns the pod the operator runs in, its name is the hostname of the container
+func (c *Controller) getOperatorPod(ctx context.Context) (*corev1.Pod, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	return c.kcs.CoreV1().Pods(c.Namespace).Get(ctx, hostname, metav1.GetOptions{})
+}
+
+// getOperatorWorkload names the Deployment owning the operator pod, through its ReplicaSet, or the
+// pod itself when it has no such owner
+func (c *Controller) getOperatorWorkload(ctx context.Context, pod *corev1.Pod) string {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind != "ReplicaSet" {
+			continue
+		}
+		rs, err := c.kcs.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			logrus.Debugf("Self-check: error getting ReplicaSet %v of the operator pod: %v", ref.Name, err)
+			break
+		}
+		for _, rsRef := range rs.OwnerReferences {
+			if rsRef.Kind == "Deployment" {
+				return fmt.Sprintf("Deployment/%v/%v", pod.Namespace, rsRef.Name)
+			}
+		}
+	}
+	return fmt.Sprintf("Pod/%v/%v", pod.Namespace, pod.Name)
+}
+
+// checkOperatorRBAC checks the roles bound to the service account of the operator
+func (c *Controller) checkOperatorRBAC(ctx context.Context, serviceAccount string) ([]v1.SelfCheckFinding, error) {
+	if serviceAccount == "" {
+		serviceAccount = "default"
+	}
+	var findings []v1.SelfCheckFinding
+	clusterBindings, err := c.kcs.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing ClusterRoleBindings: %w", err)
+	}
+	for _, binding := range clusterBindings.Items {
+		if !selfcheck.BindsServiceAccount(binding.Subjects, c.Namespace, serviceAccount) {
+			continue
+		}
+		role, err := c.kcs.RbacV1().ClusterRoles().Get(ctx, binding.RoleRef.Name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return findings, fmt.Errorf("error getting ClusterRole %v: %w", binding.RoleRef.Name, err)
+		}
+		findings = append(findings, selfcheck.CheckRoleRules("ClusterRoleBinding/"+binding.Name, role.Name, role.Rules, true)...)
+	}
+
+	bindings, err := c.kcs.RbacV1().RoleBindings(c.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return findings, fmt.Errorf("error listing RoleBindings: %w", err)
+	}
+	for _, binding := range bindings.Items {
+		if !selfcheck.BindsServiceAccount(binding.Subjects, c.Namespace, serviceAccount) {
+			continue
+		}
+		object := fmt.Sprintf("RoleBinding/%v/%v", binding.Namespace, binding.Name)
+		if binding.RoleRef.Kind == "ClusterRole" {
+			role, err := c.kcs.RbacV1().ClusterRoles().Get(ctx, binding.RoleRef.Name, metav1.GetOptions{})
+			if err == nil {
+				findings = append(findings, selfcheck.CheckRoleRules(object, role.Name, role.Rules, false)...)
+			} else if !errors.IsNotFound(err) {
+				return findings, fmt.Errorf("error getting ClusterRole %v: %w", binding.RoleRef.Name, err)
+			}
+			continue
+		}
+		role, err := c.kcs.RbacV1().Roles(binding.Namespace).Get(ctx, binding.RoleRef.Name, metav1.GetOptions{})
+		if err == nil {
+			findings = append(findings, selfcheck.CheckRoleRules(object, role.Name, role.Rules, false)...)
+		} else if !errors.IsNotFound(err) {
+			return findings, fmt.Errorf("error getting Role %v: %w", binding.RoleRef.Name, err)
+		}
+	}
+	return findings, nil
+}
@@ -0,0 +1,166 @@
+// Package selfcheck evaluates the workloads and RBAC of the operator itself against hardening
+// rules, so that the reports also tell whether the scanner is deployed the way it asks the
+// cluster to be.
+package selfcheck
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+const (
+	RulePrivileged             = "privileged-container"
+	RulePrivilegeEscalation    = "privilege-escalation"
+	RuleRunAsNonRoot           = "run-as-non-root"
+	RuleReadOnlyRootFilesystem = "read-only-root-filesystem"
+	RuleDropCapabilities       = "drop-all-capabilities"
+	RuleHostNamespaces         = "host-namespaces"
+	RuleResourceLimits         = "resource-limits"
+	RuleImageTag               = "mutable-image-tag"
+	RuleClusterAdmin           = "cluster-admin-binding"
+	RuleWildcardPermissions    = "wildcard-permissions"
+	RuleSecretsAccess          = "cluster-wide-secrets-access"
+)
+
+// ownAPIGroup is the API group of the operator, full access to its own resources is expected
+const ownAPIGroup = "cis.cattle.io"
+
+// CheckPodSpec returns the findings of a pod spec, object names the workload in the findings,
+// e.g. Deployment/cis-operator-system/cis-operator
+func CheckPodSpec(object string, spec *corev1.PodSpec) []v1.SelfCheckFinding {
+	var findings []v1.SelfCheckFinding
+	add := func(rule, format string, args ...interface{}) {
+		findings = append(findings, v1.SelfCheckFinding{
+			Rule:    rule,
+			Object:  object,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+	if spec.HostNetwork || spec.HostPID || spec.HostIPC {
+		add(RuleHostNamespaces, "pod shares the host network, PID or IPC namespace")
+	}
+	podNonRoot := spec.SecurityContext != nil && spec.SecurityContext.RunAsNonRoot != nil && *spec.SecurityContext.RunAsNonRoot
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		sc := container.SecurityContext
+		if sc == nil {
+			sc = &corev1.SecurityContext{}
+		}
+		if sc.Privileged != nil && *sc.Privileged {
+			add(RulePrivileged, "container %v is privileged", container.Name)
+		}
+		if sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+			add(RulePrivilegeEscalation, "container %v does not set allowPrivilegeEscalation to false", container.Name)
+		}
+		nonRoot := podNonRoot
+		if sc.RunAsNonRoot != nil {
+			nonRoot = *sc.RunAsNonRoot
+		}
+		if !nonRoot {
+			add(RuleRunAsNonRoot, "container %v does not run as non-root", container.Name)
+		}
+		if sc.ReadOnlyRootFilesystem == nil || !*sc.ReadOnlyRootFilesystem {
+			add(RuleReadOnlyRootFilesystem, "container %v has a writable root filesystem", container.Name)
+		}
+		if !dropsAllCapabilities(sc.Capabilities) {
+			add(RuleDropCapabilities, "container %v does not drop ALL capabilities", container.Name)
+		}
+		if container.Resources.Limits.Cpu().IsZero() || container.Resources.Limits.Memory().IsZero() {
+			add(RuleResourceLimits, "container %v has no cpu or memory limit", container.Name)
+		}
+		if isMutableImage(container.Image) {
+			add(RuleImageTag, "container %v runs image %v without a pinned tag", container.Name, container.Image)
+		}
+	}
+	return findings
+}
+
+// CheckRoleRules returns the findings of the rules of a role bound to the operator, clusterWide is
+// set for the roles bound with a ClusterRoleBinding
+func CheckRoleRules(object, roleName string, rules []rbacv1.PolicyRule, clusterWide bool) []v1.SelfCheckFinding {
+	if roleName == "cluster-admin" {
+		return []v1.SelfCheckFinding{{
+			Rule:    RuleClusterAdmin,
+			Object:  object,
+			Message: "the operator is bound to cluster-admin",
+		}}
+	}
+	var findings []v1.SelfCheckFinding
+	for _, rule := range rules {
+		if len(rule.NonResourceURLs) > 0 {
+			continue
+		}
+		if contains(rule.APIGroups, "*") ||
+			(!isOwnAPIGroup(rule.APIGroups) && (contains(rule.Resources, "*") || contains(rule.Verbs, "*"))) {
+			findings = append(findings, v1.SelfCheckFinding{
+				Rule:    RuleWildcardPermissions,
+				Object:  object,
+				Message: fmt.Sprintf("role %v grants %v on %v in API groups %v", roleName, strings.Join(rule.Verbs, ","), strings.Join(rule.Resources, ","), strings.Join(rule.APIGroups, ",")),
+			})
+			continue
+		}
+		if clusterWide && contains(rule.APIGroups, "") && len(rule.ResourceNames) == 0 &&
+			(contains(rule.Resources, "secrets") || contains(rule.Resources, "*")) &&
+			(contains(rule.Verbs, "get") || contains(rule.Verbs, "list") || contains(rule.Verbs, "watch") || contains(rule.Verbs, "*")) {
+			findings = append(findings, v1.SelfCheckFinding{
+				Rule:    RuleSecretsAccess,
+				Object:  object,
+				Message: fmt.Sprintf("role %v can read the secrets of every namespace", roleName),
+			})
+		}
+	}
+	return findings
+}
+
+// BindsServiceAccount returns whether the subjects include the service account
+func BindsServiceAccount(subjects []rbacv1.Subject, namespace, name string) bool {
+	for _, subject := range subjects {
+		if subject.Kind == rbacv1.ServiceAccountKind && subject.Namespace == namespace && subject.Name == name {
+			return true
+		}
+		if subject.Kind == rbacv1.GroupKind && (subject.Name == "system:serviceaccounts" || subject.Name == "system:serviceaccounts:"+namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+func dropsAllCapabilities(capabilities *corev1.Capabilities) bool {
+	if capabilities == nil {
+		return false
+	}
+	for _, capability := range capabilities.Drop {
+		if strings.EqualFold(string(capability), "ALL") {
+			return true
+		}
+	}
+	return false
+}
+
+// isMutableImage returns whether the image is neither pinned by digest nor by a tag other than latest
+func isMutableImage(image string) bool {
+	if strings.Contains(image, "@sha256:") {
+		return false
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	i := strings.LastIndex(name, ":")
+	return i < 0 || name[i+1:] == "latest"
+}
+
+func isOwnAPIGroup(groups []string) bool {
+	return len(groups) == 1 && groups[0] == ownAPIGroup
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package securityscan
+
+import (
+	"hash/fnv"
+)
+
+// ownsName returns whether the object of the given name is handled by this replica of the operator: with ShardCount
+// replicas, each one handles the scans whose name hashes to its ShardIndex, along with their jobs, pods, reports and
+// metrics, and likewise the subscriptions, campaigns, templates and profiles by their own name. A single replica
+// handles them all.
+func (c *Controller) ownsName(name string) bool {
+	config := c.getImageConfig()
+	if config.ShardCount <= 1 {
+		return true
+	}
+	return getShard(name, config.ShardCount) == config.ShardIndex
+}
+
+// getShard returns the shard of the object of the given name out of shardCount, by the FNV-1a hash of the name
+func getShard(name string, shardCount int) int {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return int(h.Sum32() % uint32(shardCount))
+}
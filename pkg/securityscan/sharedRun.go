@@ -0,0 +1,64 @@
+package securityscan
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// additionalProfileReport is the report derived for one of the additionalProfileNames of a scan, and its shards
+type additionalProfileReport struct {
+	report *v1.ClusterScanReport
+	shards []v1.ClusterScanReportShardSpec
+}
+
+// getAdditionalProfiles returns the additional profiles of the scan, which must run the benchmark of its profile
+func (c *Controller) getAdditionalProfiles(scan *v1.ClusterScan, profile *v1.ClusterScanProfile) ([]*v1.ClusterScanProfile, error) {
+	var profiles []*v1.ClusterScanProfile
+	for _, profileName := range scan.Spec.AdditionalProfileNames {
+		additional, err := c.cisFactory.Cis().V1().ClusterScanProfile().Get(profileName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error getting additional ClusterScanProfile %v: %w", profileName, err)
+		}
+		if additional.Spec.BenchmarkVersion != profile.Spec.BenchmarkVersion {
+			return nil, fmt.Errorf("additional ClusterScanProfile %v runs benchmark %v, not %v of ClusterScanProfile %v",
+				profileName, additional.Spec.BenchmarkVersion, profile.Spec.BenchmarkVersion, profile.Name)
+		}
+		profiles = append(profiles, additional)
+	}
+	return profiles, nil
+}
+
+// sharedRunProfile returns the profile the node checks of a scan with additional profiles run: its own profile,
+// skipping only the tests that all the profiles skip, the reports then apply the skips of their profile
+func sharedRunProfile(profile *v1.ClusterScanProfile, additional []*v1.ClusterScanProfile, now time.Time) *v1.ClusterScanProfile {
+	if len(additional) == 0 {
+		return profile
+	}
+	skipCounts := map[string]int{}
+	for _, p := range append([]*v1.ClusterScanProfile{profile}, additional...) {
+		skipped := map[string]bool{}
+		for _, testID := range p.Spec.ActiveSkipTests(now) {
+			if !skipped[testID] {
+				skipped[testID] = true
+				skipCounts[testID]++
+			}
+		}
+	}
+	var sharedSkips []string
+	for testID, count := range skipCounts {
+		if count == len(additional)+1 {
+			sharedSkips = append(sharedSkips, testID)
+		}
+	}
+	sort.Strings(sharedSkips)
+	runProfile := profile.DeepCopy()
+	runProfile.Spec.SkipTests = sharedSkips
+	runProfile.Spec.DefaultSkipTests = nil
+	runProfile.Spec.Exemptions = nil
+	return runProfile
+}
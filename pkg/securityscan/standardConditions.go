@@ -0,0 +1,83 @@
+package securityscan
+
+import (
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/condition"
+)
+
+// reasons of the standard conditions of the ClusterScans
+const (
+	scanReasonCreated       = "Created"
+	scanReasonPending       = "Pending"
+	scanReasonRunning       = "Running"
+	scanReasonReporting     = "Reporting"
+	scanReasonCancelled     = "Cancelled"
+	scanReasonSuspended     = "Suspended"
+	scanReasonFailed        = "Failed"
+	scanReasonPassed        = "Passed"
+	scanReasonChecksFailed  = "ChecksFailed"
+	scanReasonReportMissing = "ReportMissing"
+)
+
+// setClusterScanStandardConditions sets the Ready, Progressing, Reconciling and Stalled conditions of the scan from
+// its display state, the way kstatus and the Flux health checks read them: a running scan is progressing, a failed
+// one with no retry coming is stalled, and a scan is ready once its last run completed, whatever its checks found.
+// The observed generation is set once the scan stalls, so that its failure is reported for the current spec.
+func setClusterScanStandardConditions(scan *v1.ClusterScan) {
+	display := scan.Status.Display
+	ready, progressing, stalled := false, false, false
+	reason, message := scanReasonCreated, ""
+	if display != nil {
+		message = display.Message
+		switch display.State {
+		case "":
+			progressing = true
+		case "pending":
+			progressing = true
+			reason = scanReasonPending
+		case "running":
+			progressing = true
+			reason = scanReasonRunning
+			if progress := scan.Status.Progress; progress != nil && progress.Phase != "" {
+				reason = progress.Phase
+			}
+		case "reporting":
+			progressing = true
+			reason = scanReasonReporting
+		case "cancelled":
+			reason = scanReasonCancelled
+		case "suspended":
+			ready = true
+			reason = scanReasonSuspended
+		case "error":
+			stalled = true
+			reason = scanReasonFailed
+			if failedReason := v1.ClusterScanConditionFailed.GetReason(scan); failedReason != "" {
+				reason = failedReason
+			}
+			if scan.Status.Summary == nil && v1.ClusterScanConditionComplete.IsTrue(scan) && !v1.ClusterScanConditionFailed.IsTrue(scan) {
+				reason = scanReasonReportMissing
+			}
+		case "fail":
+			ready = true
+			reason = scanReasonChecksFailed
+		case "pass":
+			ready = true
+			reason = scanReasonPassed
+		}
+	}
+
+	setStandardCondition(scan, v1.ClusterScanConditionReady, ready, reason, message)
+	setStandardCondition(scan, v1.ClusterScanConditionProgressing, progressing, reason, message)
+	setStandardCondition(scan, v1.ClusterScanConditionReconciling, progressing, reason, message)
+	setStandardCondition(scan, v1.ClusterScanConditionStalled, stalled, reason, message)
+	if stalled {
+		scan.Status.ObservedGeneration = scan.Generation
+	}
+}
+
+func setStandardCondition(scan *v1.ClusterScan, cond condition.Cond, status bool, reason, message string) {
+	cond.SetStatusBool(scan, status)
+	cond.Reason(scan, reason)
+	cond.Message(scan, message)
+}
@@ -0,0 +1,90 @@
+package securityscan
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+)
+
+const (
+	// the annotation the kubelet sets on the mirror pods of its static pods
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+	staticPodNamespace  = "kube-system"
+)
+
+// applyStaticPodFallback evaluates the control plane checks of the runner output against the static pods of the
+// nodes in scope of the scan when it has staticPodFallback set, it returns the updated output and the evaluation
+// method of every check, nil if not set
+func (c *Controller) applyStaticPodFallback(ctx context.Context, scan *v1.ClusterScan, outputBytes []byte) ([]byte, map[string]string, error) {
+	if !scan.Spec.StaticPodFallback {
+		return outputBytes, nil, nil
+	}
+	pods, err := c.getStaticPods(ctx, scan)
+	if err != nil {
+		return nil, nil, err
+	}
+	return engine.EvaluateStaticPods(outputBytes, pods)
+}
+
+// getStaticPods returns the command lines of the control plane containers of the mirror pods of the nodes in
+// scope of the scan, the component of a pod is its component label, as set by kubeadm and RKE2, or its container name
+func (c *Controller) getStaticPods(ctx context.Context, scan *v1.ClusterScan) (engine.StaticPods, error) {
+	nodes, err := c.kcs.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set(scan.Spec.NodeSelector).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error %w listing nodes in scope of the scan", err)
+	}
+	inScope := map[string]bool{}
+	for _, node := range nodes.Items {
+		inScope[node.Name] = true
+	}
+	if len(scan.Status.SampledNodes) > 0 {
+		inScope = map[string]bool{}
+		for _, nodeName := range scan.Status.SampledNodes {
+			inScope[nodeName] = true
+		}
+	}
+	podList, err := c.kcs.CoreV1().Pods(staticPodNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("Error %w listing the static pods", err)
+	}
+	pods := engine.StaticPods{}
+	for _, pod := range podList.Items {
+		if _, ok := pod.Annotations[mirrorPodAnnotation]; !ok || !inScope[pod.Spec.NodeName] {
+			continue
+		}
+		for _, component := range engine.StaticPodComponents {
+			container := getComponentContainer(&pod, component)
+			if container == nil {
+				continue
+			}
+			if pods[component] == nil {
+				pods[component] = map[string][]string{}
+			}
+			pods[component][pod.Spec.NodeName] = append(append([]string{}, container.Command...), container.Args...)
+		}
+	}
+	return pods, nil
+}
+
+// getComponentContainer returns the container running the component in the pod, nil if none
+func getComponentContainer(pod *corev1.Pod, component string) *corev1.Container {
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		if container.Name == component || (len(container.Command) > 0 && path.Base(container.Command[0]) == component) {
+			return container
+		}
+	}
+	if pod.Labels["component"] == component && len(pod.Spec.Containers) == 1 {
+		return &pod.Spec.Containers[0]
+	}
+	return nil
+}
@@ -0,0 +1,355 @@
+package securityscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+
+	cisoperatorapi "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io"
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// sinkDeliveryBackoff spaces out the attempts of a failed delivery to a single ScanSubscription, the delivery is
+// dropped after Steps attempts
+var sinkDeliveryBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 30 * time.Second,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
+// how often a delivery waiting for its sink job checks on it
+const sinkJobPollInterval = 5 * time.Second
+
+// subscription events validate the selector so that misconfigured subscriptions are visible before a scan completes
+func (c *Controller) handleScanSubscriptions(ctx context.Context) error {
+	subscriptions := c.cisFactory.Cis().V1().ScanSubscription()
+
+	subscriptions.OnChange(ctx, c.Name, func(key string, obj *v1.ScanSubscription) (*v1.ScanSubscription, error) {
+		if obj == nil || obj.DeletionTimestamp != nil {
+			return obj, nil
+		}
+		if !c.ownsName(obj.Name) {
+			return obj, nil
+		}
+		_, err := getScanSubscriptionSelector(obj)
+		if err == nil {
+			err = validateScanSubscriptionTarget(&obj.Spec.Target)
+		}
+		if err == nil {
+			_, err = c.getSinkConfigMapNamespace(&obj.Spec.Target)
+		}
+		if err != nil {
+			if v1.ScanSubscriptionConditionDelivered.IsFalse(obj) && v1.ScanSubscriptionConditionDelivered.GetMessage(obj) == err.Error() {
+				return obj, nil
+			}
+			objCopy := obj.DeepCopy()
+			v1.ScanSubscriptionConditionDelivered.False(objCopy)
+			v1.ScanSubscriptionConditionDelivered.Message(objCopy, err.Error())
+			return subscriptions.UpdateStatus(objCopy)
+		}
+		if obj.Spec.Rollup != nil {
+			return c.syncScanRollup(subscriptions, obj)
+		}
+		return c.deliverPendingScans(obj)
+	})
+	return nil
+}
+
+// notifyScanSubscriptions records the completed run of a scan as pending in every ScanSubscription selecting it.
+// The subscription handler delivers it from the workqueue of the subscriptions, each retried on its own, so that a
+// slow or failing sink neither delays the scan nor the other sinks, and a delivery in progress survives a restart.
+func (c *Controller) notifyScanSubscriptions(scan *v1.ClusterScan, reportName string) {
+	subscriptions := c.cisFactory.Cis().V1().ScanSubscription()
+	subscriptionList, err := subscriptions.Cache().List(labels.Everything())
+	if err != nil {
+		logrus.Errorf("Error listing ScanSubscriptions to notify about scan %v: %v", scan.Name, err)
+		return
+	}
+	for _, sub := range subscriptionList {
+		selector, err := getScanSubscriptionSelector(sub)
+		if err != nil || sub.Spec.Rollup != nil || !selector.Matches(labels.Set(scan.Labels)) {
+			continue
+		}
+		if sub.Spec.FailureAgeDays > 0 && len(getAgingFailures(scan.Status.FailingChecks, sub.Spec.FailureAgeDays, time.Now())) == 0 {
+			continue
+		}
+		delivery := v1.ScanSubscriptionDelivery{
+			ScanName:         scan.Name,
+			ReportName:       reportName,
+			LastRunTimestamp: scan.Status.LastRunTimestamp,
+		}
+		if err := c.addPendingDelivery(sub.Name, delivery); err != nil {
+			logrus.Errorf("Error recording the delivery of scan %v to ScanSubscription %v: %v", scan.Name, sub.Name, err)
+		}
+	}
+}
+
+// addPendingDelivery records the delivery in the status of the subscription, replacing the one of an earlier run of
+// the same scan
+func (c *Controller) addPendingDelivery(subName string, delivery v1.ScanSubscriptionDelivery) error {
+	subscriptions := c.cisFactory.Cis().V1().ScanSubscription()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		sub, err := subscriptions.Get(subName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		pending := []v1.ScanSubscriptionDelivery{delivery}
+		for _, d := range sub.Status.PendingDeliveries {
+			if d.ScanName != delivery.ScanName {
+				pending = append(pending, d)
+			}
+		}
+		sub.Status.PendingDeliveries = pending
+		_, err = subscriptions.UpdateStatus(sub)
+		return err
+	})
+}
+
+// deliverPendingScans makes the first due delivery of the subscription, the status update recording its outcome
+// enqueues the subscription again for the next one. A delivery whose sink job is still running is checked on again
+// later, without counting as an attempt.
+func (c *Controller) deliverPendingScans(obj *v1.ScanSubscription) (*v1.ScanSubscription, error) {
+	subscriptions := c.cisFactory.Cis().V1().ScanSubscription()
+	now := time.Now()
+	var next time.Duration
+	for _, delivery := range obj.Status.PendingDeliveries {
+		if nextAttemptAt, err := time.Parse(time.RFC3339, delivery.NextAttemptAt); err == nil && nextAttemptAt.After(now) {
+			if wait := nextAttemptAt.Sub(now); next == 0 || wait < next {
+				next = wait
+			}
+			continue
+		}
+		scan, err := c.scans.Cache().Get(delivery.ScanName)
+		if err != nil && !errors.IsNotFound(err) {
+			return obj, err
+		}
+		if errors.IsNotFound(err) || scan.Status.LastRunTimestamp != delivery.LastRunTimestamp {
+			// the scan is gone or ran again, its next run is delivered on its own
+			logrus.Infof("Dropping the delivery of scan %v to ScanSubscription %v, the run is no longer the last one", delivery.ScanName, obj.Name)
+			return c.updatePendingDelivery(obj.Name, delivery, nil, nil)
+		}
+
+		start := time.Now()
+		done, deliveryErr := c.deliverScanNotification(obj, scan, delivery.ReportName)
+		if deliveryErr == nil && !done {
+			if next == 0 || sinkJobPollInterval < next {
+				next = sinkJobPollInterval
+			}
+			continue
+		}
+		c.sinkDeliveryDuration.WithLabelValues(obj.Name, c.getImageConfig().ClusterName).Observe(time.Since(start).Seconds())
+		return c.updatePendingDelivery(obj.Name, delivery, scan, deliveryErr)
+	}
+	if next > 0 {
+		subscriptions.EnqueueAfter(obj.Name, next)
+	}
+	return obj, nil
+}
+
+// updatePendingDelivery records the outcome of an attempt of the delivery: it is removed once delivered, dropped
+// without a scan, or after its last attempt, and its next attempt is scheduled otherwise
+func (c *Controller) updatePendingDelivery(subName string, delivery v1.ScanSubscriptionDelivery, scan *v1.ClusterScan, deliveryErr error) (*v1.ScanSubscription, error) {
+	subscriptions := c.cisFactory.Cis().V1().ScanSubscription()
+	var updated *v1.ScanSubscription
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		sub, err := subscriptions.Get(subName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		var pending []v1.ScanSubscriptionDelivery
+		for _, d := range sub.Status.PendingDeliveries {
+			if d.ScanName != delivery.ScanName || d.LastRunTimestamp != delivery.LastRunTimestamp {
+				pending = append(pending, d)
+				continue
+			}
+			if scan == nil {
+				continue
+			}
+			if deliveryErr == nil {
+				c.numSinkDeliveries.WithLabelValues(sub.Name, "success", c.getImageConfig().ClusterName).Inc()
+				v1.ScanSubscriptionConditionDelivered.True(sub)
+				v1.ScanSubscriptionConditionDelivered.Message(sub, "")
+				sub.Status.LastNotifiedScan = scan.Name
+				sub.Status.LastNotifiedReport = d.ReportName
+				sub.Status.LastNotifiedState = c.getScanState(scan)
+				sub.Status.LastNotifiedTimestamp = time.Now().Round(time.Second).Format(time.RFC3339)
+				continue
+			}
+			d.Attempts++
+			v1.ScanSubscriptionConditionDelivered.False(sub)
+			if d.Attempts >= sinkDeliveryBackoff.Steps {
+				logrus.Errorf("Error notifying ScanSubscription %v about scan %v, giving up after %v attempts: %v", sub.Name, scan.Name, d.Attempts, deliveryErr)
+				c.numSinkDeliveries.WithLabelValues(sub.Name, "failure", c.getImageConfig().ClusterName).Inc()
+				v1.ScanSubscriptionConditionDelivered.Message(sub, fmt.Sprintf("Error delivering notification for scan %v after %v attempts: %v", scan.Name, d.Attempts, deliveryErr))
+				continue
+			}
+			logrus.Warnf("Error notifying ScanSubscription %v about scan %v, attempt %v: %v", sub.Name, scan.Name, d.Attempts, deliveryErr)
+			v1.ScanSubscriptionConditionDelivered.Message(sub, fmt.Sprintf("Error delivering notification for scan %v, retrying: %v", scan.Name, deliveryErr))
+			d.NextAttemptAt = time.Now().Add(getSinkDeliveryDelay(d.Attempts)).Round(time.Second).Format(time.RFC3339)
+			pending = append(pending, d)
+		}
+		sub.Status.PendingDeliveries = pending
+		updated, err = subscriptions.UpdateStatus(sub)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error updating status of ScanSubscription %v: %w", subName, err)
+	}
+	return updated, nil
+}
+
+// getSinkDeliveryDelay returns the delay of the attempt following the failed ones
+func getSinkDeliveryDelay(attempts int) time.Duration {
+	backoff := sinkDeliveryBackoff
+	var delay time.Duration
+	for i := 0; i < attempts; i++ {
+		delay = backoff.Step()
+	}
+	return delay
+}
+
+// deliverScanNotification writes the notification of the scan to the targets of the subscription, done is false
+// while the job writing it to a claim is still running
+func (c *Controller) deliverScanNotification(sub *v1.ScanSubscription, scan *v1.ClusterScan, reportName string) (bool, error) {
+	if err := c.injectFault("delivering scan " + scan.Name + " to ScanSubscription " + sub.Name); err != nil {
+		return false, err
+	}
+	data := map[string]string{
+		"scanName":         scan.Name,
+		"scanProfileName":  scan.Status.LastRunScanProfileName,
+		"reportName":       reportName,
+		"state":            c.getScanState(scan),
+		"lastRunTimestamp": scan.Status.LastRunTimestamp,
+	}
+	if reportName != "" && c.getImageConfig().ReportBaseURL != "" {
+		data["reportURL"] = c.getImageConfig().ReportURL(reportName)
+		data["reportAPIURL"] = c.getImageConfig().ReportAPIURL(reportName)
+	}
+	if hasRegressionBudget(scan) {
+		data["regressionBudget"] = v1.ClusterScanConditionRegressionBudgetExceeded.GetMessage(scan)
+	}
+	if scan.Status.Summary != nil {
+		summary, err := json.Marshal(scan.Status.Summary)
+		if err != nil {
+			return false, err
+		}
+		data["summary"] = string(summary)
+	}
+	if sub.Spec.FailureAgeDays > 0 {
+		agingFailures, err := json.Marshal(getAgingFailures(scan.Status.FailingChecks, sub.Spec.FailureAgeDays, time.Now()))
+		if err != nil {
+			return false, err
+		}
+		data["agingFailures"] = string(agingFailures)
+	}
+	if teamSummaries := getSubscribedTeamSummaries(sub, scan); len(teamSummaries) > 0 {
+		summaries, err := json.Marshal(teamSummaries)
+		if err != nil {
+			return false, err
+		}
+		data["teamSummaries"] = string(summaries)
+	}
+	if err := c.writeSinkConfigMap(sub, data); err != nil {
+		return false, err
+	}
+	return c.writeSinkPVC(sub, scan, reportName, data)
+}
+
+// writeSinkConfigMap replaces the data of the target ConfigMap of a subscription. An existing ConfigMap is only
+// updated if the operator created it, so that a subscription cannot overwrite the ConfigMaps of other workloads.
+func (c *Controller) writeSinkConfigMap(sub *v1.ScanSubscription, data map[string]string) error {
+	target := sub.Spec.Target
+	if target.ConfigMapName == "" {
+		// subscribers watching the ScanSubscription status only
+		return nil
+	}
+	namespace, err := c.getSinkConfigMapNamespace(&target)
+	if err != nil {
+		return err
+	}
+	cm, err := c.configmaps.Get(namespace, target.ConfigMapName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		_, err = c.configmaps.Create(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      target.ConfigMapName,
+				Namespace: namespace,
+				Labels: labels.Set{
+					cisoperatorapi.LabelController: c.Name,
+				},
+			},
+			Data: data,
+		})
+		return err
+	} else if err != nil {
+		return err
+	}
+	if cm.Labels[cisoperatorapi.LabelController] != c.Name {
+		return fmt.Errorf("ConfigMap %v/%v was not created by the operator, it is not overwritten: delete it or choose another configMapName", namespace, target.ConfigMapName)
+	}
+	if equality.Semantic.DeepEqual(cm.Data, data) {
+		// written by an earlier check of the same delivery
+		return nil
+	}
+	cm = cm.DeepCopy()
+	cm.Data = data
+	_, err = c.configmaps.Update(cm)
+	return err
+}
+
+// getSinkConfigMapNamespace returns the namespace of the ConfigMap target of a subscription, which must be the
+// operator namespace or one of the --subscription-configmap-namespaces
+func (c *Controller) getSinkConfigMapNamespace(target *v1.ScanSubscriptionTarget) (string, error) {
+	namespace := target.ConfigMapNamespace
+	if namespace == "" || namespace == v1.ClusterScanNS {
+		return v1.ClusterScanNS, nil
+	}
+	for _, allowed := range c.getImageConfig().SubscriptionNamespaces {
+		if namespace == allowed {
+			return namespace, nil
+		}
+	}
+	return "", fmt.Errorf("configMapNamespace %v is not allowed, the ConfigMap targets must be in the %v namespace or one of --subscription-configmap-namespaces", namespace, v1.ClusterScanNS)
+}
+
+func getScanSubscriptionSelector(sub *v1.ScanSubscription) (labels.Selector, error) {
+	if sub.Spec.ScanSelector == nil {
+		return labels.Everything(), nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(sub.Spec.ScanSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scanSelector: %w", err)
+	}
+	return selector, nil
+}
+
+// getSubscribedTeamSummaries returns the per team rollups of the scan the subscription is interested in
+func getSubscribedTeamSummaries(sub *v1.ScanSubscription, scan *v1.ClusterScan) map[string]v1.ClusterScanSummary {
+	if len(sub.Spec.Teams) == 0 {
+		return scan.Status.TeamSummaries
+	}
+	summaries := map[string]v1.ClusterScanSummary{}
+	for _, team := range sub.Spec.Teams {
+		if summary, ok := scan.Status.TeamSummaries[team]; ok {
+			summaries[team] = summary
+		}
+	}
+	return summaries
+}
+
+// getScanState returns the display state the scan will settle on once complete
+func (c Controller) getScanState(scan *v1.ClusterScan) string {
+	scanCopy := scan.DeepCopy()
+	c.setClusterScanStatusDisplay(scanCopy)
+	return scanCopy.Status.Display.State
+}
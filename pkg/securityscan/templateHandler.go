@@ -0,0 +1,253 @@
+package securityscan
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	cisoperatorctl "github.com/rancher/cis-operator/pkg/generated/controllers/cis.cattle.io"
+	cisscan "github.com/rancher/cis-operator/pkg/securityscan/scan"
+)
+
+const (
+	templateLabel = "cis.cattle.io/template"
+	// key of the kubeconfig in the kubeConfigSecret of a Fleet Cluster
+	fleetKubeConfigKey = "value"
+	// the templates are synced again at this interval, for the newly registered clusters and the drifted scans
+	templateResyncInterval = 5 * time.Minute
+
+	templateActionCreated = "created"
+	templateActionUpdated = "updated"
+	templateActionNone    = "none"
+
+	// the downstream API server is reached through the Rancher proxy, over the cluster-agent tunnel, or directly
+	templateRouteRancherProxy = "rancher-proxy"
+	templateRouteDirect       = "direct"
+	// label of the Fleet Clusters Rancher registers, the id of their Rancher cluster
+	rancherClusterNameLabel = "management.cattle.io/cluster-name"
+)
+
+var (
+	fleetClusterGVR = schema.GroupVersionResource{Group: "fleet.cattle.io", Version: "v1alpha1", Resource: "clusters"}
+	// path of the Rancher proxy to the API server of a downstream cluster
+	rancherProxyPath = regexp.MustCompile(`^/k8s/clusters/([^/]+)/?$`)
+)
+
+// template events, with --fleet-hub, sync the ClusterScan of the template to every Fleet Cluster it selects: it is
+// created when missing, and its spec is set back to the template when it drifted. A template is synced again when
+// its spec changes, or once the resync interval since its last sync is over, it is enqueued for then.
+func (c *Controller) handleClusterScanTemplates(ctx context.Context) error {
+	if !c.getImageConfig().FleetHub {
+		return nil
+	}
+	templates := c.cisFactory.Cis().V1().ClusterScanTemplate()
+
+	templates.OnChange(ctx, c.Name, func(key string, obj *v1.ClusterScanTemplate) (*v1.ClusterScanTemplate, error) {
+		if obj == nil || obj.DeletionTimestamp != nil {
+			return obj, nil
+		}
+		if !c.ownsName(obj.Name) {
+			return obj, nil
+		}
+		if lastSync, err := time.Parse(time.RFC3339, obj.Status.LastSyncTimestamp); err == nil && obj.Status.ObservedGeneration == obj.Generation {
+			if remaining := time.Until(lastSync.Add(templateResyncInterval)); remaining > 0 {
+				templates.EnqueueAfter(obj.Name, remaining)
+				return obj, nil
+			}
+		}
+		templates.EnqueueAfter(obj.Name, templateResyncInterval)
+
+		objCopy := obj.DeepCopy()
+		objCopy.Status.LastSyncTimestamp = time.Now().Round(time.Second).Format(time.RFC3339)
+		objCopy.Status.ObservedGeneration = obj.Generation
+		clusters, err := c.syncClusterScanTemplate(ctx, obj)
+		if err != nil {
+			objCopy.Status.Clusters = nil
+			v1.ClusterScanTemplateConditionSynced.False(objCopy)
+			v1.ClusterScanTemplateConditionSynced.Message(objCopy, err.Error())
+		} else {
+			objCopy.Status.Clusters = clusters
+			var failed []string
+			for _, cluster := range clusters {
+				if cluster.Error != "" {
+					failed = append(failed, cluster.Name)
+				}
+			}
+			if len(failed) > 0 {
+				v1.ClusterScanTemplateConditionSynced.False(objCopy)
+				v1.ClusterScanTemplateConditionSynced.Message(objCopy, fmt.Sprintf("Error syncing clusters %v", failed))
+			} else {
+				v1.ClusterScanTemplateConditionSynced.True(objCopy)
+				v1.ClusterScanTemplateConditionSynced.Message(objCopy, "")
+			}
+		}
+		return templates.UpdateStatus(objCopy)
+	})
+	return nil
+}
+
+// syncClusterScanTemplate syncs the ClusterScan of the template to every Fleet Cluster it selects and returns the
+// outcome per cluster, an error is only returned when the template is invalid or the clusters cannot be listed
+func (c *Controller) syncClusterScanTemplate(ctx context.Context, template *v1.ClusterScanTemplate) ([]v1.ClusterScanTemplateCluster, error) {
+	if err := cisscan.ValidateClusterScanSpec(&template.Spec.Template); err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	selector := labels.Everything()
+	if template.Spec.ClusterSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(template.Spec.ClusterSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid clusterSelector: %w", err)
+		}
+	}
+	var clusters []v1.ClusterScanTemplateCluster
+	for _, namespace := range c.getImageConfig().FleetNamespaces {
+		fleetClusters, err := c.fleetClient.Resource(fleetClusterGVR).Namespace(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: selector.String(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error listing Fleet Clusters in namespace %v: %w", namespace, err)
+		}
+		for i := range fleetClusters.Items {
+			fleetCluster := &fleetClusters.Items[i]
+			cluster := v1.ClusterScanTemplateCluster{Name: fleetCluster.GetNamespace() + "/" + fleetCluster.GetName()}
+			cluster.Action, cluster.Route, err = c.syncDownstreamClusterScan(ctx, fleetCluster, template)
+			if err != nil {
+				cluster.Error = err.Error()
+				logrus.Errorf("Error syncing ClusterScanTemplate %v to cluster %v: %v", template.Name, cluster.Name, err)
+			} else if cluster.Action != templateActionNone {
+				logrus.Infof("ClusterScan of ClusterScanTemplate %v %v in cluster %v", template.Name, cluster.Action, cluster.Name)
+			}
+			clusters = append(clusters, cluster)
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Name < clusters[j].Name
+	})
+	return clusters, nil
+}
+
+// syncDownstreamClusterScan creates the ClusterScan of the template in the downstream cluster, or sets its spec
+// back to the template, through the kubeconfig of the Fleet Cluster, and returns what it did and the route it took
+func (c *Controller) syncDownstreamClusterScan(ctx context.Context, fleetCluster *unstructured.Unstructured, template *v1.ClusterScanTemplate) (string, string, error) {
+	scans, route, err := c.getDownstreamScanClient(ctx, fleetCluster)
+	if err != nil {
+		return "", "", err
+	}
+	action, err := syncClusterScan(scans, template)
+	return action, route, err
+}
+
+// syncClusterScan creates the ClusterScan of the template with scans, or sets its spec back to the template
+func syncClusterScan(scans scanClient, template *v1.ClusterScanTemplate) (string, error) {
+	scanName := template.Spec.ScanName
+	if scanName == "" {
+		scanName = template.Name
+	}
+	existing, err := scans.Get(scanName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		scan := &v1.ClusterScan{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   scanName,
+				Labels: map[string]string{templateLabel: template.Name},
+			},
+			Spec: *template.Spec.Template.DeepCopy(),
+		}
+		if _, err := scans.Create(scan); err != nil {
+			return "", fmt.Errorf("error creating ClusterScan %v: %w", scanName, err)
+		}
+		return templateActionCreated, nil
+	} else if err != nil {
+		return "", fmt.Errorf("error getting ClusterScan %v: %w", scanName, err)
+	}
+	if existing.Labels[templateLabel] != template.Name {
+		return "", fmt.Errorf("ClusterScan %v exists and is not managed by the template", scanName)
+	}
+	if equality.Semantic.DeepEqual(existing.Spec, template.Spec.Template) {
+		return templateActionNone, nil
+	}
+	existing = existing.DeepCopy()
+	existing.Spec = *template.Spec.Template.DeepCopy()
+	if _, err := scans.Update(existing); err != nil {
+		return "", fmt.Errorf("error updating ClusterScan %v: %w", scanName, err)
+	}
+	return templateActionUpdated, nil
+}
+
+// getDownstreamScanClient returns a ClusterScan client of the downstream cluster, built from the kubeConfigSecret of
+// its Fleet Cluster, in the namespace of the Fleet Cluster unless kubeConfigSecretNamespace is set. The secret must
+// be in one of the Fleet namespaces the operator is allowed to read secrets in. The route to the downstream API server
+// is returned with it.
+func (c *Controller) getDownstreamScanClient(ctx context.Context, fleetCluster *unstructured.Unstructured) (scanClient, string, error) {
+	secretName, _, _ := unstructured.NestedString(fleetCluster.Object, "spec", "kubeConfigSecret")
+	if secretName == "" {
+		return nil, "", fmt.Errorf("the Fleet Cluster has no kubeConfigSecret")
+	}
+	secretNamespace, _, _ := unstructured.NestedString(fleetCluster.Object, "spec", "kubeConfigSecretNamespace")
+	if secretNamespace == "" {
+		secretNamespace = fleetCluster.GetNamespace()
+	}
+	if !slices.Contains(c.getImageConfig().FleetNamespaces, secretNamespace) {
+		return nil, "", fmt.Errorf("the kubeConfigSecret namespace %v is not one of --fleet-namespaces", secretNamespace)
+	}
+	secret, err := c.kcs.CoreV1().Secrets(secretNamespace).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, "", fmt.Errorf("error getting kubeConfigSecret %v/%v: %w", secretNamespace, secretName, err)
+	}
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(secret.Data[fleetKubeConfigKey])
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading kubeConfigSecret %v/%v: %w", secretNamespace, secretName, err)
+	}
+	route, err := checkRancherProxy(cfg, fleetCluster)
+	if err != nil {
+		return nil, "", fmt.Errorf("kubeConfigSecret %v/%v: %w", secretNamespace, secretName, err)
+	}
+	factory, err := cisoperatorctl.NewFactoryFromConfig(cfg)
+	if err != nil {
+		return nil, "", err
+	}
+	return factory.Cis().V1().ClusterScan(), route, nil
+}
+
+// checkRancherProxy returns the route of the kubeconfig of a Fleet Cluster. When it points at the Rancher proxy of a
+// downstream cluster, which goes through its cluster-agent tunnel, the proxied cluster must be the Rancher cluster
+// of the Fleet Cluster, and a token is needed to authenticate to Rancher.
+func checkRancherProxy(cfg *rest.Config, fleetCluster *unstructured.Unstructured) (string, error) {
+	host, err := url.Parse(cfg.Host)
+	if err != nil {
+		return "", fmt.Errorf("invalid server %q: %w", cfg.Host, err)
+	}
+	match := rancherProxyPath.FindStringSubmatch(host.Path)
+	if match == nil {
+		return templateRouteDirect, nil
+	}
+	if clusterName := fleetCluster.GetLabels()[rancherClusterNameLabel]; clusterName != "" && clusterName != match[1] {
+		return "", fmt.Errorf("the server is the Rancher proxy of cluster %v, not of cluster %v of the Fleet Cluster", match[1], clusterName)
+	}
+	if cfg.BearerToken == "" && cfg.BearerTokenFile == "" {
+		return "", fmt.Errorf("the server is the Rancher proxy of cluster %v, which needs a Rancher token", match[1])
+	}
+	return templateRouteRancherProxy, nil
+}
+
+// scanClient is the part of the ClusterScan controller used on the downstream clusters, whose caches are never started
+type scanClient interface {
+	Get(name string, options metav1.GetOptions) (*v1.ClusterScan, error)
+	Create(*v1.ClusterScan) (*v1.ClusterScan, error)
+	Update(*v1.ClusterScan) (*v1.ClusterScan, error)
+}
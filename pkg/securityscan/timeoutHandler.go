@@ -0,0 +1,54 @@
+package securityscan
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rancher/wrangler/pkg/name"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// scan events fail the running scans that exceeded their scanTimeoutSeconds, the job handler then
+// completes them and cleans up their job, pods and daemonsets as for any other failed run
+func (c *Controller) handleClusterScanTimeouts(ctx context.Context) error {
+	scans := c.cisFactory.Cis().V1().ClusterScan()
+
+	scans.OnChange(ctx, c.Name, func(key string, obj *v1.ClusterScan) (*v1.ClusterScan, error) {
+		if obj == nil || obj.DeletionTimestamp != nil {
+			return obj, nil
+		}
+		if !c.ownsName(obj.Name) {
+			return obj, nil
+		}
+		if obj.Spec.ScanTimeoutSeconds <= 0 || !v1.ClusterScanConditionCreated.IsTrue(obj) || !v1.ClusterScanConditionRunCompleted.IsUnknown(obj) {
+			return obj, nil
+		}
+		startTime, err := time.Parse(time.RFC3339, obj.Status.LastRunTimestamp)
+		if err != nil {
+			return obj, nil
+		}
+		timeout := time.Duration(obj.Spec.ScanTimeoutSeconds) * time.Second
+		if remaining := time.Until(startTime.Add(timeout)); remaining > 0 {
+			scans.EnqueueAfter(obj.Name, remaining)
+			return obj, nil
+		}
+
+		scanLog(obj).Infof("Marking ClusterScanConditionFailed for scan: %v, timed out after %v", obj.Name, timeout)
+		scanCopy := obj.DeepCopy()
+		v1.ClusterScanConditionRunCompleted.True(scanCopy)
+		setScanPhase(scanCopy, v1.ClusterScanPhaseReporting, time.Now())
+		v1.ClusterScanConditionFailed.True(scanCopy)
+		v1.ClusterScanConditionFailed.Reason(scanCopy, v1.ClusterScanReasonTimeout)
+		v1.ClusterScanConditionFailed.Message(scanCopy, fmt.Sprintf("ClusterScan did not complete within %v seconds", obj.Spec.ScanTimeoutSeconds))
+		c.setClusterScanStatusDisplay(scanCopy)
+		updated, err := scans.UpdateStatus(scanCopy)
+		if err != nil {
+			return obj, fmt.Errorf("error updating condition of timed out cluster scan object: %v: %w", obj.Name, err)
+		}
+		c.jobs.Enqueue(v1.ClusterScanNS, name.SafeConcatName("security-scan-runner", obj.Name))
+		return updated, nil
+	})
+	return nil
+}
@@ -0,0 +1,122 @@
+package securityscan
+
+import (
+	"errors"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/tracing"
+)
+
+// names of the spans of a scan run, the phases are children of the span of the whole run
+const (
+	spanScanRun  = "ClusterScan run"
+	spanQueue    = "queue"
+	spanLaunch   = "launch"
+	spanJob      = "job"
+	spanReport   = "report"
+	spanNotify   = "notify"
+	spanAlerting = "alert dispatch"
+)
+
+// traceScanPhase records the span of a phase of the last run of the scan, from start to end, failed with err if not
+// nil. Nothing is recorded without an OTLP endpoint or before the run is launched.
+func (c *Controller) traceScanPhase(scan *v1.ClusterScan, name string, start, end time.Time, err error, attributes map[string]interface{}) {
+	if c.tracer == nil || scan.Status.LastRunTimestamp == "" {
+		return
+	}
+	traceID := tracing.RunTraceID(string(scan.UID), scan.Status.LastRunTimestamp)
+	span := tracing.Span{
+		TraceID:      traceID,
+		SpanID:       tracing.NewSpanID(),
+		ParentSpanID: tracing.RootSpanID(traceID),
+		Name:         name,
+		Start:        start,
+		End:          end,
+		Attributes:   scanSpanAttributes(scan),
+	}
+	for key, value := range attributes {
+		span.Attributes[key] = value
+	}
+	if err != nil {
+		span.Error = err.Error()
+	}
+	c.tracer.Record(span)
+}
+
+// traceScanRun records the span of the whole last run of the scan, from its launch to its completion at end
+func (c *Controller) traceScanRun(scan *v1.ClusterScan, end time.Time) {
+	if c.tracer == nil {
+		return
+	}
+	start, err := time.Parse(time.RFC3339, scan.Status.LastRunTimestamp)
+	if err != nil {
+		return
+	}
+	traceID := tracing.RunTraceID(string(scan.UID), scan.Status.LastRunTimestamp)
+	span := tracing.Span{
+		TraceID:    traceID,
+		SpanID:     tracing.RootSpanID(traceID),
+		Name:       spanScanRun,
+		Start:      start,
+		End:        end,
+		Attributes: scanSpanAttributes(scan),
+	}
+	if scan.Status.Summary != nil {
+		span.Attributes["cis.summary.total"] = scan.Status.Summary.Total
+		span.Attributes["cis.summary.fail"] = scan.Status.Summary.Fail
+	}
+	if v1.ClusterScanConditionFailed.IsTrue(scan) {
+		span.Error = v1.ClusterScanConditionFailed.GetMessage(scan)
+		if span.Error == "" {
+			span.Error = "scan run failed"
+		}
+	}
+	c.tracer.Record(span)
+}
+
+// traceScanJob records the span of the job of the last run of the scan, from its creation, or the launch of the run
+// once the job is gone, to the completion of the run
+func (c *Controller) traceScanJob(scan *v1.ClusterScan, job *batchv1.Job) {
+	if c.tracer == nil {
+		return
+	}
+	start, err := time.Parse(time.RFC3339, scan.Status.LastRunTimestamp)
+	attributes := map[string]interface{}{}
+	if job != nil {
+		start = job.CreationTimestamp.Time
+		attributes["k8s.job.name"] = job.Name
+	} else if err != nil {
+		return
+	}
+	end, err := time.Parse(time.RFC3339, v1.ClusterScanConditionRunCompleted.GetLastUpdated(scan))
+	if err != nil {
+		end = time.Now()
+	}
+	if scan.Status.Progress != nil {
+		attributes["cis.nodes.completed"] = scan.Status.Progress.NodesCompleted
+		attributes["cis.nodes.failed"] = scan.Status.Progress.NodesFailed
+	}
+	var runErr error
+	if v1.ClusterScanConditionFailed.IsTrue(scan) {
+		runErr = errors.New(v1.ClusterScanConditionFailed.GetMessage(scan))
+	}
+	c.traceScanPhase(scan, spanJob, start, end, runErr, attributes)
+}
+
+func scanSpanAttributes(scan *v1.ClusterScan) map[string]interface{} {
+	attributes := map[string]interface{}{
+		"cis.scan.name":    scan.Name,
+		"cis.scan.profile": scan.Status.LastRunScanProfileName,
+		"cis.scan.run":     scan.Status.LastRunTimestamp,
+	}
+	if scan.Status.Attempts > 0 {
+		attributes["cis.scan.attempt"] = scan.Status.Attempts
+	}
+	if isScheduledScan(scan) {
+		attributes["cis.scan.scheduled"] = true
+	}
+	return attributes
+}
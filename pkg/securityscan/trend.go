@@ -0,0 +1,26 @@
+package securityscan
+
+import (
+	"time"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// appendTrendPoint adds the summary of the last run to the trend, dropping the oldest runs past the window
+func appendTrendPoint(trend []v1.ClusterScanTrendPoint, summary *v1.ClusterScanSummary, reportName string, now time.Time, window int) []v1.ClusterScanTrendPoint {
+	if window <= 0 || summary == nil {
+		return nil
+	}
+	trend = append(append([]v1.ClusterScanTrendPoint{}, trend...), v1.ClusterScanTrendPoint{
+		Timestamp:  now.Round(time.Second).Format(time.RFC3339),
+		ReportName: reportName,
+		Score:      summary.Score(),
+		Total:      summary.Total,
+		Pass:       summary.Pass,
+		Fail:       summary.Fail,
+	})
+	if len(trend) > window {
+		trend = trend[len(trend)-window:]
+	}
+	return trend
+}
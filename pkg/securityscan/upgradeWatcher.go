@@ -0,0 +1,71 @@
+package securityscan
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// watchKubernetesUpgrades polls the Kubernetes version of the cluster and runs the upgrade scan again
+// whenever it changes, upgrades being when the CIS posture of a cluster is the most likely to drift
+func (c *Controller) watchKubernetesUpgrades(ctx context.Context, version string) {
+	ticker := time.NewTicker(c.getImageConfig().UpgradeCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		newVersion, err := detectKubernetesVersion(ctx, c.kcs)
+		if err != nil {
+			logrus.Errorf("Error detecting the Kubernetes version of the cluster: %v", err)
+			continue
+		}
+		if strings.EqualFold(newVersion, version) {
+			continue
+		}
+		logrus.Infof("Kubernetes upgraded from %v to %v, running ClusterScan %v", version, newVersion, c.getImageConfig().UpgradeScanName)
+		if err := c.rerunScan(c.getImageConfig().UpgradeScanName); err != nil {
+			logrus.Errorf("Error running ClusterScan %v after the Kubernetes upgrade: %v", c.getImageConfig().UpgradeScanName, err)
+			continue
+		}
+		version = newVersion
+	}
+}
+
+// isRerunnableScan returns whether the last run of the scan is complete
+func isRerunnableScan(scan *v1.ClusterScan) bool {
+	return scan.Status.LastRunTimestamp != "" && v1.ClusterScanConditionComplete.IsTrue(scan)
+}
+
+// rerunScan resets the status of a complete scan, so that it is launched again as a new run
+func (c *Controller) rerunScan(scanName string) error {
+	scans := c.cisFactory.Cis().V1().ClusterScan()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		scan, err := scans.Get(scanName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if scan.Spec.Cancel || isSuspendedScan(scan) {
+			return fmt.Errorf("ClusterScan %v is cancelled or suspended", scanName)
+		}
+		if !isRerunnableScan(scan) {
+			logrus.Infof("ClusterScan %v is already running or queued, not running it again", scanName)
+			return nil
+		}
+		scan.Status.Conditions = []genericcondition.GenericCondition{}
+		scan.Status.LastRunTimestamp = ""
+		scan.Status.NextScanAt = ""
+		_, err = scans.UpdateStatus(scan)
+		return err
+	})
+}
@@ -0,0 +1,131 @@
+package securityscan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// unsupportedVersionError is returned when the benchmark of a profile does not cover the Kubernetes version
+// of the cluster, with the ranges of the benchmarks of the cluster provider and the nearest one
+type unsupportedVersionError struct {
+	profile          string
+	version          string
+	supported        []string
+	nearestBenchmark string
+	nearestProfile   string
+}
+
+func (e *unsupportedVersionError) Error() string {
+	msg := fmt.Sprintf("Kubernetes version %v is not supported by ClusterScanProfile %v", e.version, e.profile)
+	if len(e.supported) > 0 {
+		msg += fmt.Sprintf(", supported versions: %v", strings.Join(e.supported, ", "))
+	}
+	if e.nearestProfile != "" {
+		msg += fmt.Sprintf(", nearest benchmark: %v (ClusterScanProfile %v), set allowClosestMatch to run it", e.nearestBenchmark, e.nearestProfile)
+	}
+	return msg
+}
+
+// benchmarkRange returns the semver range of the Kubernetes versions covered by a benchmark, empty if unbounded
+func benchmarkRange(benchmark *v1.ClusterScanBenchmark) string {
+	var k8sRange string
+	if benchmark.Spec.MinKubernetesVersion != "" {
+		k8sRange = ">=" + benchmark.Spec.MinKubernetesVersion
+	}
+	if benchmark.Spec.MaxKubernetesVersion != "" {
+		k8sRange = strings.TrimSpace(k8sRange + " <=" + benchmark.Spec.MaxKubernetesVersion)
+	}
+	return k8sRange
+}
+
+// newUnsupportedVersionError lists the benchmarks of the cluster provider to find the nearest one to the
+// Kubernetes version of the cluster, and a profile running it
+func (c *Controller) newUnsupportedVersionError(profile *v1.ClusterScanProfile, version semver.Version) *unsupportedVersionError {
+	unsupported := &unsupportedVersionError{
+		profile: profile.Name,
+		version: c.KubernetesVersion,
+	}
+	benchmarkList, err := c.cisFactory.Cis().V1().ClusterScanBenchmark().List(metav1.ListOptions{})
+	if err != nil {
+		return unsupported
+	}
+	benchmarks := benchmarkList.Items
+	sort.Slice(benchmarks, func(i, j int) bool {
+		return benchmarks[i].Name > benchmarks[j].Name
+	})
+	nearestDistance := -1
+	for i := range benchmarks {
+		benchmark := &benchmarks[i]
+		if benchmark.Spec.ClusterProvider != "" && !strings.EqualFold(benchmark.Spec.ClusterProvider, c.ClusterProvider) {
+			continue
+		}
+		k8sRange := benchmarkRange(benchmark)
+		if k8sRange == "" {
+			continue
+		}
+		unsupported.supported = append(unsupported.supported, fmt.Sprintf("%v (%v)", benchmark.Name, k8sRange))
+		distance, err := versionDistance(benchmark, version)
+		if err != nil {
+			continue
+		}
+		if nearestDistance < 0 || distance < nearestDistance {
+			nearestDistance = distance
+			unsupported.nearestBenchmark = benchmark.Name
+		}
+	}
+	if unsupported.nearestBenchmark != "" {
+		unsupported.nearestProfile = c.getBenchmarkProfileName(unsupported.nearestBenchmark)
+	}
+	return unsupported
+}
+
+// versionDistance returns how many minor versions the version is away from the range of the benchmark
+func versionDistance(benchmark *v1.ClusterScanBenchmark, version semver.Version) (int, error) {
+	minor := func(v semver.Version) int {
+		return int(v.Major)*1000 + int(v.Minor)
+	}
+	if benchmark.Spec.MinKubernetesVersion != "" {
+		minVersion, err := semver.ParseTolerant(benchmark.Spec.MinKubernetesVersion)
+		if err != nil {
+			return 0, err
+		}
+		if version.LT(minVersion) {
+			return minor(minVersion) - minor(version), nil
+		}
+	}
+	if benchmark.Spec.MaxKubernetesVersion != "" {
+		maxVersion, err := semver.ParseTolerant(benchmark.Spec.MaxKubernetesVersion)
+		if err != nil {
+			return 0, err
+		}
+		if version.GT(maxVersion) {
+			return minor(version) - minor(maxVersion), nil
+		}
+	}
+	return 0, nil
+}
+
+// getBenchmarkProfileName returns the first profile, by name, running the benchmark, empty if none
+func (c *Controller) getBenchmarkProfileName(benchmarkName string) string {
+	profileList, err := c.cisFactory.Cis().V1().ClusterScanProfile().List(metav1.ListOptions{})
+	if err != nil {
+		return ""
+	}
+	var names []string
+	for _, profile := range profileList.Items {
+		if profile.Spec.BenchmarkVersion == benchmarkName {
+			names = append(names, profile.Name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return names[0]
+}
@@ -0,0 +1,265 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// path of the traces on the OTLP/HTTP endpoint of the collectors
+	tracesPath = "/v1/traces"
+	// spans are exported in batches every exportInterval, the ones recorded past maxQueuedSpans are dropped until then
+	exportInterval = 5 * time.Second
+	maxQueuedSpans = 2048
+	exportTimeout  = 10 * time.Second
+	scopeName      = "github.com/rancher/cis-operator"
+	serviceName    = "cis-operator"
+	// OTLP span kind and status codes
+	spanKindInternal = 1
+	statusCodeError  = 2
+)
+
+// Exporter sends the spans to the OTLP/HTTP endpoint of a collector.
+type Exporter struct {
+	url      string
+	headers  map[string]string
+	resource []keyValue
+	client   *http.Client
+
+	lock    sync.Mutex
+	spans   []Span
+	dropped int
+}
+
+// NewExporter returns an exporter to the OTLP/HTTP endpoint, e.g. http://otel-collector.observability:4318, with the
+// comma separated key=value headers, e.g. to authenticate, and the name of the cluster as a resource attribute.
+func NewExporter(endpoint, headers, clusterName string, client *http.Client) (*Exporter, error) {
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		return nil, fmt.Errorf("OTLP endpoint %v is not an http or https URL", endpoint)
+	}
+	parsedHeaders, err := ParseHeaders(headers)
+	if err != nil {
+		return nil, err
+	}
+	e := &Exporter{
+		url:      strings.TrimSuffix(endpoint, "/") + tracesPath,
+		headers:  parsedHeaders,
+		resource: []keyValue{newKeyValue("service.name", serviceName)},
+		client:   client,
+	}
+	if clusterName != "" {
+		e.resource = append(e.resource, newKeyValue("k8s.cluster.name", clusterName))
+	}
+	return e, nil
+}
+
+// ParseHeaders parses comma separated key=value headers, as in OTEL_EXPORTER_OTLP_HEADERS
+func ParseHeaders(headers string) (map[string]string, error) {
+	parsed := map[string]string{}
+	for _, header := range strings.Split(headers, ",") {
+		if strings.TrimSpace(header) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(header, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("OTLP header %q is not key=value", header)
+		}
+		parsed[key] = strings.TrimSpace(value)
+	}
+	return parsed, nil
+}
+
+// Record queues the span for the next export, nothing is recorded on a nil exporter
+func (e *Exporter) Record(span Span) {
+	if e == nil {
+		return
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if len(e.spans) >= maxQueuedSpans {
+		e.dropped++
+		return
+	}
+	e.spans = append(e.spans, span)
+}
+
+// Run exports the queued spans every exportInterval until the context is done, then exports the last ones.
+func (e *Exporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			exportCtx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+			e.flush(exportCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			e.flush(ctx)
+		}
+	}
+}
+
+// flush exports the queued spans, they are dropped if the collector refuses them
+func (e *Exporter) flush(ctx context.Context) {
+	e.lock.Lock()
+	spans, dropped := e.spans, e.dropped
+	e.spans, e.dropped = nil, 0
+	e.lock.Unlock()
+	if dropped > 0 {
+		logrus.Warnf("Dropped %d spans over the limit of %d spans queued between exports", dropped, maxQueuedSpans)
+	}
+	if len(spans) == 0 {
+		return
+	}
+	if err := e.export(ctx, spans); err != nil {
+		logrus.Errorf("Error exporting %d spans to %v: %v", len(spans), e.url, err)
+	}
+}
+
+func (e *Exporter) export(ctx context.Context, spans []Span) error {
+	request := exportRequest{ResourceSpans: []resourceSpans{{
+		Resource:   resource{Attributes: e.resource},
+		ScopeSpans: []scopeSpans{{Scope: scope{Name: scopeName}}},
+	}}}
+	for _, span := range spans {
+		request.ResourceSpans[0].ScopeSpans[0].Spans = append(request.ResourceSpans[0].ScopeSpans[0].Spans, newOTLPSpan(span))
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("collector returned %v: %s", resp.Status, bytes.TrimSpace(message))
+	}
+	return nil
+}
+
+// the subset of the OTLP ExportTraceServiceRequest written, in the JSON encoding of OTLP/HTTP: the IDs are hex and
+// the 64 bit integers strings
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Status            *status    `json:"status,omitempty"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+func newOTLPSpan(span Span) otlpSpan {
+	s := otlpSpan{
+		TraceID:           span.TraceID.String(),
+		SpanID:            span.SpanID.String(),
+		Name:              span.Name,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+	}
+	if !span.ParentSpanID.IsZero() {
+		s.ParentSpanID = span.ParentSpanID.String()
+	}
+	keys := make([]string, 0, len(span.Attributes))
+	for key := range span.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s.Attributes = append(s.Attributes, newKeyValue(key, span.Attributes[key]))
+	}
+	if span.Error != "" {
+		s.Status = &status{Code: statusCodeError, Message: span.Error}
+	}
+	return s
+}
+
+func newKeyValue(key string, value interface{}) keyValue {
+	kv := keyValue{Key: key}
+	switch v := value.(type) {
+	case string:
+		kv.Value.StringValue = &v
+	case bool:
+		kv.Value.BoolValue = &v
+	case int:
+		i := strconv.Itoa(v)
+		kv.Value.IntValue = &i
+	case int64:
+		i := strconv.FormatInt(v, 10)
+		kv.Value.IntValue = &i
+	case float64:
+		kv.Value.DoubleValue = &v
+	default:
+		s := fmt.Sprint(v)
+		kv.Value.StringValue = &s
+	}
+	return kv
+}
@@ -0,0 +1,68 @@
+// Package tracing records the phases of the scan runs as spans and exports them to an OpenTelemetry collector with
+// OTLP over HTTP, in its JSON encoding.
+package tracing
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// TraceID identifies a trace, the spans of a scan run share the one derived from the run
+type TraceID [16]byte
+
+// SpanID identifies a span within its trace, zero for no parent
+type SpanID [8]byte
+
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+func (s SpanID) IsZero() bool {
+	return s == SpanID{}
+}
+
+// RunTraceID returns the trace of a scan run from the UID of the scan and the start of the run, so that the handlers
+// completing the run, in this process or after a restart, add their spans to the trace of its launch.
+func RunTraceID(scanUID, runTimestamp string) TraceID {
+	var id TraceID
+	sum := sha256.Sum256([]byte(scanUID + "/" + runTimestamp))
+	copy(id[:], sum[:])
+	return id
+}
+
+// RootSpanID returns the span of the whole run of the trace, the parent of the spans of its phases
+func RootSpanID(traceID TraceID) SpanID {
+	var id SpanID
+	sum := sha256.Sum256(append(traceID[:], "root"...))
+	copy(id[:], sum[:])
+	return id
+}
+
+// NewSpanID returns a random span ID
+func NewSpanID() SpanID {
+	var id SpanID
+	if _, err := rand.Read(id[:]); err != nil {
+		// the span ID only needs to be unique within its trace
+		copy(id[:], time.Now().String())
+	}
+	return id
+}
+
+// Span is a completed operation of a scan run
+type Span struct {
+	TraceID      TraceID
+	SpanID       SpanID
+	ParentSpanID SpanID
+	Name         string
+	Start, End   time.Time
+	// values are strings, bools, ints and float64s, others are exported as their string
+	Attributes map[string]interface{}
+	// the operation failed with it if not empty
+	Error string
+}
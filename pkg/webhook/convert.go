@@ -0,0 +1,293 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	kbreport "github.com/rancher/security-scan/pkg/kb-summarizer/report"
+	"github.com/sirupsen/logrus"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	v2 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v2"
+)
+
+// serveConvert converts the ClusterScanReports between their v1, storing the results as a JSON blob, and their v2,
+// structuring them
+func (s *Server) serveConvert(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	review := &apiextv1.ConversionReview{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxReviewBytes)).Decode(review); err != nil {
+		http.Error(w, fmt.Sprintf("error decoding ConversionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "ConversionReview without request", http.StatusBadRequest)
+		return
+	}
+	response := &apiextv1.ConversionResponse{
+		UID:    review.Request.UID,
+		Result: metav1.Status{Status: metav1.StatusSuccess},
+	}
+	for _, obj := range review.Request.Objects {
+		converted, err := convertReport(obj.Raw, review.Request.DesiredAPIVersion)
+		if err != nil {
+			logrus.Debugf("Webhook: error converting to %v: %v", review.Request.DesiredAPIVersion, err)
+			response.ConvertedObjects = nil
+			response.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			break
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&apiextv1.ConversionReview{
+		TypeMeta: review.TypeMeta,
+		Response: response,
+	}); err != nil {
+		logrus.Debugf("Webhook: error writing response: %v", err)
+	}
+}
+
+// convertReport converts the JSON of a ClusterScanReport to the desired version
+func convertReport(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return nil, fmt.Errorf("error decoding object: %w", err)
+	}
+	if typeMeta.Kind != "ClusterScanReport" {
+		return nil, fmt.Errorf("unexpected kind %v", typeMeta.Kind)
+	}
+	if typeMeta.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+	switch {
+	case typeMeta.APIVersion == v1.SchemeGroupVersion.String() && desiredAPIVersion == v2.SchemeGroupVersion.String():
+		report := &v1.ClusterScanReport{}
+		if err := json.Unmarshal(raw, report); err != nil {
+			return nil, fmt.Errorf("error decoding ClusterScanReport: %w", err)
+		}
+		converted, err := ConvertReportToV2(report)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(converted)
+	case typeMeta.APIVersion == v2.SchemeGroupVersion.String() && desiredAPIVersion == v1.SchemeGroupVersion.String():
+		report := &v2.ClusterScanReport{}
+		if err := json.Unmarshal(raw, report); err != nil {
+			return nil, fmt.Errorf("error decoding ClusterScanReport: %w", err)
+		}
+		converted, err := ConvertReportToV1(report)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(converted)
+	}
+	return nil, fmt.Errorf("unsupported conversion of ClusterScanReport from %v to %v", typeMeta.APIVersion, desiredAPIVersion)
+}
+
+// ConvertReportToV2 returns the v2 of a v1 ClusterScanReport, with the results of its reportJSON structured.
+func ConvertReportToV2(report *v1.ClusterScanReport) (*v2.ClusterScanReport, error) {
+	converted := &v2.ClusterScanReport{
+		ObjectMeta: *report.ObjectMeta.DeepCopy(),
+		Status:     *report.Status.DeepCopy(),
+	}
+	converted.APIVersion = v2.SchemeGroupVersion.String()
+	converted.Kind = "ClusterScanReport"
+	if report.Spec.ReportEncoding != "" {
+		if converted.Annotations == nil {
+			converted.Annotations = map[string]string{}
+		}
+		converted.Annotations[v2.ReportEncodingAnnotation] = report.Spec.ReportEncoding
+	}
+
+	spec := report.Spec.DeepCopy()
+	reportJSON, err := spec.GetReportJSON()
+	if err != nil {
+		return nil, fmt.Errorf("error reading ClusterScanReport %v: %w", report.Name, err)
+	}
+	var results v2.ClusterScanResults
+	if len(reportJSON) > 0 {
+		r, err := kbreport.Get(reportJSON)
+		if err != nil {
+			return nil, fmt.Errorf("error reading ClusterScanReport %v: %w", report.Name, err)
+		}
+		results = getStructuredResults(r)
+	}
+	converted.Spec = v2.ClusterScanReportSpec{
+		BenchmarkVersion:  spec.BenchmarkVersion,
+		LastRunTimestamp:  spec.LastRunTimestamp,
+		Results:           results,
+		Sharded:           spec.Sharded,
+		NodeSelector:      spec.NodeSelector,
+		NodesInScope:      spec.NodesInScope,
+		PartialCoverage:   spec.PartialCoverage,
+		TotalNodeCount:    spec.TotalNodeCount,
+		ScanProfileName:   spec.ScanProfileName,
+		AdditionalProfile: spec.AdditionalProfile,
+		DurationSeconds:   spec.DurationSeconds,
+		NodeCount:         spec.NodeCount,
+		TeamSummaries:     spec.TeamSummaries,
+		Exemptions:        spec.Exemptions,
+		Drift:             spec.Drift,
+		NodeGroups:        spec.NodeGroups,
+		SelfCheck:         spec.SelfCheck,
+		Diff:              spec.Diff,
+		Attestation:       spec.Attestation,
+		ComplianceScore:   spec.ComplianceScore,
+		Remediations:      spec.Remediations,
+		EvaluationMethods: spec.EvaluationMethods,
+		IncludedChecks:    spec.IncludedChecks,
+		ProcessedBy:       spec.ProcessedBy,
+		PluginErrors:      spec.PluginErrors,
+	}
+	return converted, nil
+}
+
+// ConvertReportToV1 returns the v1 of a v2 ClusterScanReport, with its results encoded in the reportJSON. The
+// reportJSON is compressed again if the v1 it was converted from was.
+func ConvertReportToV1(report *v2.ClusterScanReport) (*v1.ClusterScanReport, error) {
+	converted := &v1.ClusterScanReport{
+		ObjectMeta: *report.ObjectMeta.DeepCopy(),
+		Status:     *report.Status.DeepCopy(),
+	}
+	converted.APIVersion = v1.SchemeGroupVersion.String()
+	converted.Kind = "ClusterScanReport"
+	encoding := converted.Annotations[v2.ReportEncodingAnnotation]
+	delete(converted.Annotations, v2.ReportEncodingAnnotation)
+	if len(converted.Annotations) == 0 {
+		converted.Annotations = nil
+	}
+
+	spec := report.Spec.DeepCopy()
+	converted.Spec = v1.ClusterScanReportSpec{
+		BenchmarkVersion:  spec.BenchmarkVersion,
+		LastRunTimestamp:  spec.LastRunTimestamp,
+		Sharded:           spec.Sharded,
+		NodeSelector:      spec.NodeSelector,
+		NodesInScope:      spec.NodesInScope,
+		PartialCoverage:   spec.PartialCoverage,
+		TotalNodeCount:    spec.TotalNodeCount,
+		ScanProfileName:   spec.ScanProfileName,
+		AdditionalProfile: spec.AdditionalProfile,
+		DurationSeconds:   spec.DurationSeconds,
+		NodeCount:         spec.NodeCount,
+		TeamSummaries:     spec.TeamSummaries,
+		Exemptions:        spec.Exemptions,
+		Drift:             spec.Drift,
+		NodeGroups:        spec.NodeGroups,
+		SelfCheck:         spec.SelfCheck,
+		Diff:              spec.Diff,
+		Attestation:       spec.Attestation,
+		ComplianceScore:   spec.ComplianceScore,
+		Remediations:      spec.Remediations,
+		EvaluationMethods: spec.EvaluationMethods,
+		IncludedChecks:    spec.IncludedChecks,
+		ProcessedBy:       spec.ProcessedBy,
+		PluginErrors:      spec.PluginErrors,
+	}
+	reportJSON, err := json.Marshal(getReportResults(&spec.Results))
+	if err != nil {
+		return nil, fmt.Errorf("error encoding ClusterScanReport %v: %w", report.Name, err)
+	}
+	if err := converted.Spec.SetReportJSON(reportJSON, encoding == v1.ReportEncodingGzip); err != nil {
+		return nil, fmt.Errorf("error encoding ClusterScanReport %v: %w", report.Name, err)
+	}
+	return converted, nil
+}
+
+// getStructuredResults returns the v2 results of a report JSON
+func getStructuredResults(r *kbreport.Report) v2.ClusterScanResults {
+	results := v2.ClusterScanResults{
+		Version: r.Version,
+		Summary: v1.ClusterScanSummary{
+			Total:         r.Total,
+			Pass:          r.Pass,
+			Fail:          r.Fail,
+			Skip:          r.Skip,
+			Warn:          r.Warn,
+			NotApplicable: r.NotApplicable,
+		},
+	}
+	if len(r.Nodes) > 0 {
+		results.Nodes = map[string][]string{}
+		for nodeType, nodes := range r.Nodes {
+			results.Nodes[string(nodeType)] = nodes
+		}
+	}
+	for _, group := range r.Results {
+		if group == nil {
+			continue
+		}
+		g := v2.ClusterScanCheckGroup{ID: group.ID, Description: group.Text}
+		for _, check := range group.Checks {
+			if check == nil {
+				continue
+			}
+			c := v2.ClusterScanCheck{
+				ID:             check.Id,
+				Description:    check.Description,
+				State:          string(check.State),
+				Severity:       v2.GetCheckSeverity(string(check.State), check.Scored),
+				Scored:         check.Scored,
+				Remediation:    check.Remediation,
+				Nodes:          check.Nodes,
+				Audit:          check.Audit,
+				AuditConfig:    check.AuditConfig,
+				ExpectedResult: check.ExpectedResult,
+				TestInfo:       check.TestInfo,
+			}
+			for _, nodeType := range check.NodeType {
+				c.NodeTypes = append(c.NodeTypes, string(nodeType))
+			}
+			g.Checks = append(g.Checks, c)
+		}
+		results.Groups = append(results.Groups, g)
+	}
+	return results
+}
+
+// getReportResults returns the report JSON of v2 results, the severities are derived and left out
+func getReportResults(results *v2.ClusterScanResults) *kbreport.Report {
+	r := &kbreport.Report{
+		Version:       results.Version,
+		Total:         results.Summary.Total,
+		Pass:          results.Summary.Pass,
+		Fail:          results.Summary.Fail,
+		Skip:          results.Summary.Skip,
+		Warn:          results.Summary.Warn,
+		NotApplicable: results.Summary.NotApplicable,
+		Nodes:         map[kbreport.NodeType][]string{},
+	}
+	for nodeType, nodes := range results.Nodes {
+		r.Nodes[kbreport.NodeType(nodeType)] = nodes
+	}
+	for _, group := range results.Groups {
+		g := &kbreport.Group{ID: group.ID, Text: group.Description}
+		for _, check := range group.Checks {
+			c := &kbreport.Check{
+				Id:             check.ID,
+				Description:    check.Description,
+				Remediation:    check.Remediation,
+				State:          kbreport.State(check.State),
+				Nodes:          check.Nodes,
+				Audit:          check.Audit,
+				AuditConfig:    check.AuditConfig,
+				TestInfo:       check.TestInfo,
+				ExpectedResult: check.ExpectedResult,
+				Scored:         check.Scored,
+			}
+			for _, nodeType := range check.NodeTypes {
+				c.NodeType = append(c.NodeType, kbreport.NodeType(nodeType))
+			}
+			g.Checks = append(g.Checks, c)
+		}
+		r.Results = append(r.Results, g)
+	}
+	return r
+}
@@ -0,0 +1,244 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	kbreport "github.com/rancher/security-scan/pkg/kb-summarizer/report"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+)
+
+const (
+	externalDataPath = "/externaldata"
+	// version of the external data provider protocol of Gatekeeper
+	externalDataAPIVersion = "externaldata.gatekeeper.sh/v1beta1"
+	// largest ProviderRequest read, the keys are names of scans and nodes
+	maxProviderRequestBytes = 1 << 20
+)
+
+// ProviderRequest is the request of Gatekeeper to an external data provider
+type ProviderRequest struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Request    struct {
+		Keys []string `json:"keys"`
+	} `json:"request"`
+}
+
+// ProviderResponse is the response of an external data provider, with an item per key of the request
+type ProviderResponse struct {
+	APIVersion string               `json:"apiVersion"`
+	Kind       string               `json:"kind"`
+	Response   ProviderResponseBody `json:"response"`
+}
+
+type ProviderResponseBody struct {
+	Idempotent  bool           `json:"idempotent"`
+	Items       []ProviderItem `json:"items"`
+	SystemError string         `json:"systemError,omitempty"`
+}
+
+type ProviderItem struct {
+	Key   string             `json:"key"`
+	Value *ComplianceVerdict `json:"value,omitempty"`
+	Error string             `json:"error,omitempty"`
+}
+
+// ComplianceVerdict is the outcome of the last report of a scan, for the whole cluster or for one of its nodes
+type ComplianceVerdict struct {
+	ScanName string `json:"scanName"`
+	Node     string `json:"node,omitempty"`
+	// no check failed, on the node for a node verdict, the warnings counting as failures with scoreWarning fail
+	Passed     bool   `json:"passed"`
+	ReportName string `json:"reportName"`
+	// when the report was created, and how long ago
+	ScannedAt  string `json:"scannedAt"`
+	AgeSeconds int64  `json:"ageSeconds"`
+	// failing checks, their IDs for a node verdict
+	Fail            int                            `json:"fail"`
+	FailingChecks   []string                       `json:"failingChecks,omitempty"`
+	ComplianceScore *v1.ClusterScanComplianceScore `json:"complianceScore,omitempty"`
+	PartialCoverage bool                           `json:"partialCoverage,omitempty"`
+}
+
+// serveExternalData serves the compliance verdicts as a Gatekeeper external data provider, for the admission policies
+// requiring e.g. a cluster that passed its CIS scan within 7 days. Each key is the name of a ClusterScan, for the
+// verdict of the cluster, or <scan name>/<node name> for the verdict of a node, from the last report of the scan.
+func (s *Server) serveExternalData(w http.ResponseWriter, r *http.Request) {
+	if s.Scans == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	response := &ProviderResponse{APIVersion: externalDataAPIVersion, Kind: "ProviderResponse"}
+	var request ProviderRequest
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxProviderRequestBytes))
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		response.Response.SystemError = fmt.Sprintf("invalid ProviderRequest: %v", err)
+		writeProviderResponse(w, response)
+		return
+	}
+
+	now := time.Now()
+	reports := map[string]*reportVerdicts{}
+	response.Response.Items = []ProviderItem{}
+	for _, key := range request.Request.Keys {
+		item := ProviderItem{Key: key}
+		scanName, node, _ := strings.Cut(key, "/")
+		verdicts, ok := reports[scanName]
+		if !ok {
+			verdicts, err = s.getReportVerdicts(scanName)
+			if err != nil {
+				item.Error = err.Error()
+				response.Response.Items = append(response.Response.Items, item)
+				continue
+			}
+			reports[scanName] = verdicts
+		}
+		item.Value, err = verdicts.get(node, now)
+		if err != nil {
+			item.Value = nil
+			item.Error = err.Error()
+		}
+		response.Response.Items = append(response.Response.Items, item)
+	}
+	writeProviderResponse(w, response)
+}
+
+// reportVerdicts holds the last report of a scan, and the failing checks of its nodes once a node verdict is asked
+type reportVerdicts struct {
+	scan         *v1.ClusterScan
+	report       *v1.ClusterScanReport
+	reportJSON   []byte
+	summary      *kbreport.Report
+	nodeFailures map[string][]string
+}
+
+// getReportVerdicts returns the last report of the scan, the most recent one not of one of its additional profiles
+func (s *Server) getReportVerdicts(scanName string) (*reportVerdicts, error) {
+	scan, err := s.Scans.Get(scanName)
+	if errors.IsNotFound(err) {
+		return nil, fmt.Errorf("ClusterScan %v not found", scanName)
+	} else if err != nil {
+		return nil, fmt.Errorf("error getting ClusterScan %v: %w", scanName, err)
+	}
+	reports, err := s.Reports.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("error listing ClusterScanReports: %w", err)
+	}
+	var last *v1.ClusterScanReport
+	for _, report := range reports {
+		if report.Spec.AdditionalProfile || !isOwnedByScan(report, scan) {
+			continue
+		}
+		if last == nil || last.CreationTimestamp.Before(&report.CreationTimestamp) {
+			last = report
+		}
+	}
+	if last == nil {
+		return nil, fmt.Errorf("ClusterScan %v has no report yet", scanName)
+	}
+	reportJSON, err := s.getReportJSON(last)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := kbreport.Get(reportJSON)
+	if err != nil || summary == nil {
+		return nil, fmt.Errorf("error reading ClusterScanReport %v: %v", last.Name, err)
+	}
+	return &reportVerdicts{scan: scan, report: last, reportJSON: reportJSON, summary: summary}, nil
+}
+
+func (v *reportVerdicts) get(node string, now time.Time) (*ComplianceVerdict, error) {
+	failOnWarn := v.scan.Spec.ScoreWarning == v1.ClusterScanFailOnWarning
+	verdict := &ComplianceVerdict{
+		ScanName:        v.scan.Name,
+		Node:            node,
+		ReportName:      v.report.Name,
+		ScannedAt:       v.report.CreationTimestamp.UTC().Format(time.RFC3339),
+		AgeSeconds:      int64(now.Sub(v.report.CreationTimestamp.Time).Seconds()),
+		ComplianceScore: v.report.Spec.ComplianceScore,
+		PartialCoverage: v.report.Spec.PartialCoverage,
+	}
+	if node == "" {
+		verdict.Fail = v.summary.Fail
+		if failOnWarn {
+			verdict.Fail += v.summary.Warn
+		}
+		verdict.Passed = verdict.Fail == 0
+		return verdict, nil
+	}
+	if v.nodeFailures == nil {
+		failures, err := engine.GetNodeFailingChecks(v.reportJSON, failOnWarn)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the nodes of ClusterScanReport %v: %w", v.report.Name, err)
+		}
+		v.nodeFailures = failures
+	}
+	failing, ok := v.nodeFailures[node]
+	if !ok {
+		return nil, fmt.Errorf("node %v not in ClusterScanReport %v", node, v.report.Name)
+	}
+	verdict.FailingChecks = failing
+	verdict.Fail = len(failing)
+	verdict.Passed = verdict.Fail == 0
+	return verdict, nil
+}
+
+// getReportJSON returns the report JSON, with the nodes of its shards if it is sharded
+func (s *Server) getReportJSON(report *v1.ClusterScanReport) ([]byte, error) {
+	reportJSON, err := report.Spec.GetReportJSON()
+	if err != nil || !report.Spec.Sharded {
+		return reportJSON, err
+	}
+	if s.Shards == nil {
+		return nil, fmt.Errorf("ClusterScanReport %v is sharded, its shards are not available", report.Name)
+	}
+	shards, err := s.Shards.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("error listing the shards of ClusterScanReport %v: %w", report.Name, err)
+	}
+	var specs []v1.ClusterScanReportShardSpec
+	for _, shard := range shards {
+		if shard.Spec.ReportName == report.Name {
+			specs = append(specs, shard.Spec)
+		}
+	}
+	if len(specs) != len(report.Status.Shards) {
+		return nil, fmt.Errorf("found %d of the %d shards of ClusterScanReport %v", len(specs), len(report.Status.Shards), report.Name)
+	}
+	return engine.MergeReportShards(reportJSON, specs)
+}
+
+func isOwnedByScan(report *v1.ClusterScanReport, scan *v1.ClusterScan) bool {
+	for _, ref := range report.OwnerReferences {
+		if ref.Kind == "ClusterScan" && ref.Name == scan.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func writeProviderResponse(w http.ResponseWriter, response *ProviderResponse) {
+	// not cached by Gatekeeper, the age of a verdict and the last report of a scan change over time
+	response.Response.Idempotent = false
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logrus.Debugf("Webhook: error writing ProviderResponse: %v", err)
+	}
+}
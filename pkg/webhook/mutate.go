@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	admissionv1 "k8s.io/api/admission/v1"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+)
+
+// patchOperation is an operation of the JSONPatch returned to the API server
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// mutate returns the JSONPatch filling the defaults of the ClusterScan created by the request, the operator applies
+// the same defaults to the scans created while the webhook was not registered
+func (s *Server) mutate(request *admissionv1.AdmissionRequest) ([]patchOperation, error) {
+	if request.Operation != admissionv1.Create || request.Kind.Kind != "ClusterScan" {
+		return nil, nil
+	}
+	scan := &v1.ClusterScan{}
+	if err := json.Unmarshal(request.Object.Raw, scan); err != nil {
+		return nil, fmt.Errorf("error decoding ClusterScan: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(request.Object.Raw, &fields); err != nil {
+		return nil, fmt.Errorf("error decoding ClusterScan: %w", err)
+	}
+
+	var patch []patchOperation
+	if spec, ok := fields["spec"]; !ok || string(spec) == "null" {
+		patch = append(patch, patchOperation{Op: "add", Path: "/spec", Value: map[string]interface{}{}})
+	}
+	if scan.Spec.ScanProfileName == "" && s.DefaultProfile != nil {
+		profileName, err := s.DefaultProfile()
+		if err != nil {
+			// the scan fails on the same error once it runs, creating it reports the error in its status
+			logrus.Debugf("Webhook: not defaulting the profile of ClusterScan %v: %v", scan.Name, err)
+		} else if profileName != "" {
+			patch = append(patch, patchOperation{Op: "add", Path: "/spec/scanProfileName", Value: profileName})
+		}
+	}
+	if scan.Spec.ScoreWarning == "" {
+		patch = append(patch, patchOperation{Op: "add", Path: "/spec/scoreWarning", Value: v1.ClusterScanPassOnWarning})
+	}
+	if scan.Spec.ScheduledScanConfig != nil && scan.Spec.ScheduledScanConfig.RetentionCount == 0 {
+		patch = append(patch, patchOperation{Op: "add", Path: "/spec/scheduledScanConfig/retentionCount", Value: v1.DefaultRetention})
+	}
+	return patch, nil
+}
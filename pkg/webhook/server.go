@@ -0,0 +1,156 @@
+// Package webhook serves the admission webhooks of the CIS CRDs, rejecting at apply time the objects the operator
+// would only fail on once it runs them and filling the defaults of the ClusterScans the operator would otherwise only
+// apply when running them, the conversion webhook of the ClusterScanReports, and the compliance verdicts of the scans
+// as a Gatekeeper external data provider.
+package webhook
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	cisctlv1 "github.com/rancher/cis-operator/pkg/generated/controllers/cis.cattle.io/v1"
+)
+
+const (
+	validatePath = "/validate"
+	mutatePath   = "/mutate"
+	convertPath  = "/convert"
+	// largest AdmissionReview read, the API server sends objects of at most a few megabytes
+	maxReviewBytes = 8 << 20
+)
+
+// Server validates the ClusterScans and ClusterScanProfiles against the profiles, benchmarks and reports in its
+// caches, which must be synced before it serves, and defaults the ClusterScans.
+type Server struct {
+	Profiles   cisctlv1.ClusterScanProfileCache
+	Benchmarks cisctlv1.ClusterScanBenchmarkCache
+	Reports    cisctlv1.ClusterScanReportCache
+	// Scans and Shards serve the compliance verdicts to Gatekeeper, the external data provider is disabled if Scans is nil
+	Scans  cisctlv1.ClusterScanCache
+	Shards cisctlv1.ClusterScanReportShardCache
+	// DefaultProfile returns the profile the scans without one run on this cluster, they are not defaulted if nil
+	DefaultProfile func() (string, error)
+}
+
+// NewServer returns the HTTPS server of the webhook
+func NewServer(addr string, handler *Server, tlsConfig *tls.Config) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(validatePath, handler.serveValidate)
+	mux.HandleFunc(mutatePath, handler.serveMutate)
+	mux.HandleFunc(convertPath, handler.serveConvert)
+	return &http.Server{
+		Addr:      addr,
+		Handler:   mux,
+		TLSConfig: tlsConfig,
+	}
+}
+
+// NewExternalDataServer returns the HTTPS server of the Gatekeeper external data provider, on its own listener so that
+// its tlsConfig can require the client certificate of Gatekeeper, which the API server calling the webhooks lacks
+func NewExternalDataServer(addr string, handler *Server, tlsConfig *tls.Config) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(externalDataPath, handler.serveExternalData)
+	return &http.Server{
+		Addr:      addr,
+		Handler:   mux,
+		TLSConfig: tlsConfig,
+	}
+}
+
+func (s *Server) serveValidate(w http.ResponseWriter, r *http.Request) {
+	review, ok := readReview(w, r)
+	if !ok {
+		return
+	}
+	response := &admissionv1.AdmissionResponse{UID: review.Request.UID, Allowed: true}
+	if err := s.validate(review.Request); err != nil {
+		logrus.Debugf("Webhook: rejecting %v %v: %v", review.Request.Kind.Kind, review.Request.Name, err)
+		response.Allowed = false
+		response.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    http.StatusUnprocessableEntity,
+			Reason:  metav1.StatusReasonInvalid,
+			Message: err.Error(),
+		}
+	}
+	writeReview(w, review, response)
+}
+
+func (s *Server) serveMutate(w http.ResponseWriter, r *http.Request) {
+	review, ok := readReview(w, r)
+	if !ok {
+		return
+	}
+	response := &admissionv1.AdmissionResponse{UID: review.Request.UID, Allowed: true}
+	patch, err := s.mutate(review.Request)
+	if err != nil {
+		// the validating webhook rejects the objects that can't be decoded
+		logrus.Debugf("Webhook: not defaulting %v %v: %v", review.Request.Kind.Kind, review.Request.Name, err)
+	} else if len(patch) > 0 {
+		response.Patch, err = json.Marshal(patch)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error encoding patch: %v", err), http.StatusInternalServerError)
+			return
+		}
+		patchType := admissionv1.PatchTypeJSONPatch
+		response.PatchType = &patchType
+	}
+	writeReview(w, review, response)
+}
+
+// validate returns why the object of the request is invalid, nil for a valid one or one the webhook does not validate
+func (s *Server) validate(request *admissionv1.AdmissionRequest) error {
+	if request.Operation != admissionv1.Create && request.Operation != admissionv1.Update {
+		return nil
+	}
+	switch request.Kind.Kind {
+	case "ClusterScan":
+		scan := &v1.ClusterScan{}
+		if err := json.Unmarshal(request.Object.Raw, scan); err != nil {
+			return fmt.Errorf("error decoding ClusterScan: %w", err)
+		}
+		return s.validateClusterScan(scan)
+	case "ClusterScanProfile":
+		profile := &v1.ClusterScanProfile{}
+		if err := json.Unmarshal(request.Object.Raw, profile); err != nil {
+			return fmt.Errorf("error decoding ClusterScanProfile: %w", err)
+		}
+		return s.validateClusterScanProfile(profile)
+	}
+	return nil
+}
+
+// readReview decodes the AdmissionReview of the request, or writes the error and returns false
+func readReview(w http.ResponseWriter, r *http.Request) (*admissionv1.AdmissionReview, bool) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return nil, false
+	}
+	review := &admissionv1.AdmissionReview{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxReviewBytes)).Decode(review); err != nil {
+		http.Error(w, fmt.Sprintf("error decoding AdmissionReview: %v", err), http.StatusBadRequest)
+		return nil, false
+	}
+	if review.Request == nil {
+		http.Error(w, "AdmissionReview without request", http.StatusBadRequest)
+		return nil, false
+	}
+	return review, true
+}
+
+func writeReview(w http.ResponseWriter, review *admissionv1.AdmissionReview, response *admissionv1.AdmissionResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&admissionv1.AdmissionReview{
+		TypeMeta: review.TypeMeta,
+		Response: response,
+	}); err != nil {
+		logrus.Debugf("Webhook: error writing response: %v", err)
+	}
+}
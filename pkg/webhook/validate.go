@@ -0,0 +1,139 @@
+package webhook
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+	cisscan "github.com/rancher/cis-operator/pkg/securityscan/scan"
+)
+
+// validateClusterScan checks the spec of the scan and that its profiles exist. A scan without a profile runs the
+// default one of the cluster, picked when it runs.
+func (s *Server) validateClusterScan(scan *v1.ClusterScan) error {
+	if err := cisscan.ValidateClusterScanSpec(&scan.Spec); err != nil {
+		return fmt.Errorf("invalid ClusterScan %v: %w", scan.Name, err)
+	}
+	if scan.Spec.ScanProfileName == "" {
+		return nil
+	}
+	profile, err := s.getProfile(scan.Spec.ScanProfileName)
+	if err != nil {
+		return err
+	}
+	for _, profileName := range scan.Spec.AdditionalProfileNames {
+		additional, err := s.getProfile(profileName)
+		if err != nil {
+			return err
+		}
+		if additional.Spec.BenchmarkVersion != profile.Spec.BenchmarkVersion {
+			return fmt.Errorf("additional ClusterScanProfile %v runs benchmark %v, it must run benchmark %v of ClusterScanProfile %v",
+				profileName, additional.Spec.BenchmarkVersion, profile.Spec.BenchmarkVersion, profile.Name)
+		}
+	}
+	return nil
+}
+
+// validateClusterScanProfile checks the benchmark of the profile exists, that its exemptions are valid, and that it
+// skips checks of the benchmark, as listed in its last complete report. Without such a report, the skipped IDs are
+// not checked.
+func (s *Server) validateClusterScanProfile(profile *v1.ClusterScanProfile) error {
+	if profile.Spec.BenchmarkVersion == "" {
+		return fmt.Errorf("ClusterScanProfile %v has no benchmarkVersion", profile.Name)
+	}
+	_, err := s.Benchmarks.Get(profile.Spec.BenchmarkVersion)
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("ClusterScanBenchmark %v of ClusterScanProfile %v not found, list the benchmarks with kubectl get clusterscanbenchmarks",
+			profile.Spec.BenchmarkVersion, profile.Name)
+	} else if err != nil {
+		return fmt.Errorf("error getting ClusterScanBenchmark %v: %w", profile.Spec.BenchmarkVersion, err)
+	}
+	for _, exemption := range profile.Spec.Exemptions {
+		if exemption.TestID == "" {
+			return fmt.Errorf("exemption without testID in ClusterScanProfile %v", profile.Name)
+		}
+		if exemption.Expiry != "" {
+			if _, err := v1.ParseExemptionExpiry(exemption.Expiry); err != nil {
+				return fmt.Errorf("invalid expiry of the exemption of test %v: %w", exemption.TestID, err)
+			}
+		}
+	}
+
+	checkIDs, reportName, err := s.getBenchmarkCheckIDs(profile.Spec.BenchmarkVersion)
+	if err != nil || checkIDs == nil {
+		return err
+	}
+	unknown := map[string]bool{}
+	for _, testID := range profile.Spec.SkipTests {
+		if !checkIDs[testID] {
+			unknown[testID] = true
+		}
+	}
+	for _, exemption := range profile.Spec.Exemptions {
+		if !checkIDs[exemption.TestID] {
+			unknown[exemption.TestID] = true
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(unknown))
+	for id := range unknown {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return fmt.Errorf("%v are not checks of benchmark %v, its checks are listed in ClusterScanReport %v",
+		strings.Join(ids, ", "), profile.Spec.BenchmarkVersion, reportName)
+}
+
+func (s *Server) getProfile(profileName string) (*v1.ClusterScanProfile, error) {
+	profile, err := s.Profiles.Get(profileName)
+	if errors.IsNotFound(err) {
+		return nil, fmt.Errorf("ClusterScanProfile %v not found, list the profiles with kubectl get clusterscanprofiles", profileName)
+	} else if err != nil {
+		return nil, fmt.Errorf("error getting ClusterScanProfile %v: %w", profileName, err)
+	}
+	return profile, nil
+}
+
+// getBenchmarkCheckIDs returns the check IDs of the benchmark from its most recent report covering all its checks,
+// and the name of the report, nil if it has none
+func (s *Server) getBenchmarkCheckIDs(benchmarkVersion string) (map[string]bool, string, error) {
+	reports, err := s.Reports.List(labels.Everything())
+	if err != nil {
+		return nil, "", fmt.Errorf("error listing ClusterScanReports: %w", err)
+	}
+	var latest *v1.ClusterScanReport
+	for _, report := range reports {
+		if report.Spec.BenchmarkVersion != benchmarkVersion || len(report.Spec.IncludedChecks) > 0 {
+			continue
+		}
+		if latest == nil || latest.CreationTimestamp.Before(&report.CreationTimestamp) {
+			latest = report
+		}
+	}
+	if latest == nil {
+		return nil, "", nil
+	}
+	reportJSON, err := latest.Spec.GetReportJSON()
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading ClusterScanReport %v: %w", latest.Name, err)
+	}
+	states, err := engine.GetCheckStates(reportJSON)
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading ClusterScanReport %v: %w", latest.Name, err)
+	}
+	if len(states) == 0 {
+		return nil, "", nil
+	}
+	checkIDs := map[string]bool{}
+	for id := range states {
+		checkIDs[id] = true
+	}
+	return checkIDs, latest.Name, nil
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/urfave/cli"
+
+	cisoperatorapiv1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	"github.com/rancher/cis-operator/pkg/fips"
+	"github.com/rancher/cis-operator/pkg/reportapi"
+	cisoperator "github.com/rancher/cis-operator/pkg/securityscan"
+)
+
+type reportAPIOptions struct {
+	port              string
+	authMode          string
+	tlsCertFile       string
+	tlsKeyFile        string
+	clientCAFile      string
+	oidcIssuerURL     string
+	oidcClientID      string
+	oidcUsernameClaim string
+	tokenAudiences    string
+	manageScans       bool
+	scanManagers      string
+}
+
+func reportAPIFlags(opts *reportAPIOptions) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:        "report-api-port",
+			EnvVar:      "CIS_REPORT_API_PORT",
+			Value:       "",
+			Usage:       "port of the report API, disabled if empty",
+			Destination: &opts.port,
+		},
+		cli.StringFlag{
+			Name:        "report-api-auth",
+			EnvVar:      "CIS_REPORT_API_AUTH",
+			Value:       reportapi.AuthModeTokenReview,
+			Usage:       "authentication of the report API: tokenreview, oidc or mtls",
+			Destination: &opts.authMode,
+		},
+		cli.StringFlag{
+			Name:        "report-api-tls-cert",
+			EnvVar:      "CIS_REPORT_API_TLS_CERT",
+			Destination: &opts.tlsCertFile,
+		},
+		cli.StringFlag{
+			Name:        "report-api-tls-key",
+			EnvVar:      "CIS_REPORT_API_TLS_KEY",
+			Destination: &opts.tlsKeyFile,
+		},
+		cli.StringFlag{
+			Name:        "report-api-client-ca",
+			EnvVar:      "CIS_REPORT_API_CLIENT_CA",
+			Usage:       "CA bundle verifying the client certificates in mtls mode",
+			Destination: &opts.clientCAFile,
+		},
+		cli.StringFlag{
+			Name:        "report-api-oidc-issuer-url",
+			EnvVar:      "CIS_REPORT_API_OIDC_ISSUER_URL",
+			Destination: &opts.oidcIssuerURL,
+		},
+		cli.StringFlag{
+			Name:        "report-api-oidc-client-id",
+			EnvVar:      "CIS_REPORT_API_OIDC_CLIENT_ID",
+			Destination: &opts.oidcClientID,
+		},
+		cli.StringFlag{
+			Name:        "report-api-oidc-username-claim",
+			EnvVar:      "CIS_REPORT_API_OIDC_USERNAME_CLAIM",
+			Value:       "sub",
+			Destination: &opts.oidcUsernameClaim,
+		},
+		cli.StringFlag{
+			Name:        "report-api-token-audiences",
+			EnvVar:      "CIS_REPORT_API_TOKEN_AUDIENCES",
+			Usage:       "comma separated audiences the tokens must be issued for in tokenreview mode",
+			Destination: &opts.tokenAudiences,
+		},
+		cli.BoolFlag{
+			Name:        "report-api-scan-management",
+			EnvVar:      "CIS_REPORT_API_SCAN_MANAGEMENT",
+			Usage:       "allow creating scans and running them again through the report API",
+			Destination: &opts.manageScans,
+		},
+		cli.StringFlag{
+			Name:        "report-api-scan-managers",
+			EnvVar:      "CIS_REPORT_API_SCAN_MANAGERS",
+			Usage:       "comma separated users allowed to manage the scans in oidc and mtls modes, none if empty",
+			Destination: &opts.scanManagers,
+		},
+	}
+}
+
+func newReportAPIServer(opts *reportAPIOptions, ctl *cisoperator.Controller, proxy cisoperatorapiv1.ProxyConfig) (*http.Server, error) {
+	handler := &reportapi.Server{
+		Reports:      ctl.ReportCache(),
+		Shards:       ctl.ReportShardCache(),
+		Scans:        ctl.ScanController(),
+		ManageScans:  opts.manageScans,
+		ScanManagers: splitList(opts.scanManagers),
+		ClusterName:  ctl.ImageConfig.ClusterName,
+	}
+	var tlsConfig *tls.Config
+	if opts.tlsCertFile != "" || opts.tlsKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.tlsCertFile, opts.tlsKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading TLS certificate: %w", err)
+		}
+		tlsConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+		fips.ConfigureTLS(tlsConfig)
+	}
+	// the tokens of the tokenreview and oidc modes would otherwise be sent in clear
+	if tlsConfig == nil && opts.authMode != reportapi.AuthModeClientCert {
+		return nil, fmt.Errorf("the %v mode requires a TLS certificate", opts.authMode)
+	}
+
+	switch opts.authMode {
+	case reportapi.AuthModeTokenReview:
+		handler.Authenticator = &reportapi.TokenReviewAuthenticator{
+			Client:    ctl.KubeClient(),
+			Audiences: splitList(opts.tokenAudiences),
+		}
+	case reportapi.AuthModeOIDC:
+		if opts.oidcIssuerURL == "" || opts.oidcClientID == "" {
+			return nil, fmt.Errorf("the oidc mode requires an issuer URL and a client ID")
+		}
+		if ctl.ImageConfig.AirGapped {
+			return nil, fmt.Errorf("the oidc mode discovers the keys of the issuer, which is disabled in air-gapped mode: use the tokenreview or mtls mode")
+		}
+		oidc := reportapi.NewOIDCAuthenticator(opts.oidcIssuerURL, opts.oidcClientID, opts.oidcUsernameClaim)
+		oidc.Client = cisoperator.NewHTTPClient(proxy)
+		handler.Authenticator = oidc
+	case reportapi.AuthModeClientCert:
+		if tlsConfig == nil || opts.clientCAFile == "" {
+			return nil, fmt.Errorf("the mtls mode requires a TLS certificate and a client CA")
+		}
+		ca, err := os.ReadFile(opts.clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client CA: %w", err)
+		}
+		tlsConfig.ClientCAs = x509.NewCertPool()
+		if !tlsConfig.ClientCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificate found in client CA %v", opts.clientCAFile)
+		}
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		handler.Authenticator = reportapi.ClientCertAuthenticator{}
+	default:
+		return nil, fmt.Errorf("unknown report API auth mode %q", opts.authMode)
+	}
+	return reportapi.NewServer(":"+opts.port, handler, tlsConfig), nil
+}
+
+func serveReportAPI(server *http.Server) error {
+	if server.TLSConfig != nil {
+		return server.ListenAndServeTLS("", "")
+	}
+	return server.ListenAndServe()
+}
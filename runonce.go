@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rancher/wrangler/pkg/kubeconfig"
+	"github.com/rancher/wrangler/pkg/signals"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cisoperatorapiv1 "github.com/rancher/cis-operator/pkg/apis/cis.cattle.io/v1"
+	cisclient "github.com/rancher/cis-operator/pkg/client"
+	"github.com/rancher/cis-operator/pkg/securityscan/engine"
+)
+
+const (
+	// run-once exit codes, so that pipelines can tell a failing benchmark from a broken run
+	exitCodeChecksFailed = 1
+	exitCodeError        = 2
+)
+
+// runOnce runs a single scan with the headless engine and exits with its verdict
+func runOnce(c *cli.Context) error {
+	if debug {
+		logrus.SetLevel(logrus.DebugLevel)
+	}
+	profileName := c.String("profile")
+	if profileName == "" {
+		return cli.NewExitError("--profile is required", exitCodeError)
+	}
+
+	ctx, cancel := context.WithTimeout(signals.SetupSignalContext(), c.Duration("timeout"))
+	defer cancel()
+
+	cfg, err := kubeconfig.GetNonInteractiveClientConfig(kubeConfig).ClientConfig()
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("failed to find kubeconfig: %v", err), exitCodeError)
+	}
+
+	imgConfig := getScanImageConfig(false)
+	if err := validateConfig(imgConfig); err != nil {
+		return cli.NewExitError(err.Error(), exitCodeError)
+	}
+
+	cis, err := cisclient.NewForConfig(cfg)
+	if err != nil {
+		return cli.NewExitError(err.Error(), exitCodeError)
+	}
+	profile, err := cis.Profiles.Get(profileName, metav1.GetOptions{})
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("Error fetching ClusterScanProfile %v: %v", profileName, err), exitCodeError)
+	}
+	benchmark, err := cis.Benchmarks.Get(profile.Spec.BenchmarkVersion, metav1.GetOptions{})
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("Error fetching ClusterScanBenchmark %v: %v", profile.Spec.BenchmarkVersion, err), exitCodeError)
+	}
+
+	runner, err := engine.NewRunner(cfg, imgConfig, getScanPodConfig())
+	if err != nil {
+		return cli.NewExitError(err.Error(), exitCodeError)
+	}
+	scan := &cisoperatorapiv1.ClusterScan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("run-once-%v", metav1.Now().Unix()),
+		},
+		Spec: cisoperatorapiv1.ClusterScanSpec{
+			ScanProfileName: profileName,
+		},
+	}
+	logrus.Infof("Running scan %v with profile %v", scan.Name, profileName)
+	result, err := runner.Run(ctx, scan, profile, benchmark)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("Error running scan: %v", err), exitCodeError)
+	}
+
+	if reportFile := c.String("report-file"); reportFile != "" {
+		if err := os.WriteFile(reportFile, result.ReportJSON, 0644); err != nil {
+			return cli.NewExitError(fmt.Sprintf("Error writing report: %v", err), exitCodeError)
+		}
+	}
+	summary, err := json.Marshal(result.Summary)
+	if err != nil {
+		return cli.NewExitError(err.Error(), exitCodeError)
+	}
+	fmt.Println(string(summary))
+
+	if result.Summary.Fail > 0 {
+		return cli.NewExitError(fmt.Sprintf("scan %v failed %d checks", scan.Name, result.Summary.Fail), exitCodeChecksFailed)
+	}
+	logrus.Infof("Scan %v passed", scan.Name)
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rancher/cis-operator/pkg/fips"
+	cisoperator "github.com/rancher/cis-operator/pkg/securityscan"
+	"github.com/rancher/cis-operator/pkg/webhook"
+)
+
+type webhookOptions struct {
+	port        string
+	tlsCertFile string
+	tlsKeyFile  string
+	// the Gatekeeper external data provider, served on its own port to the clients with a certificate of clientCAFile
+	externalDataPort         string
+	externalDataClientCAFile string
+}
+
+func webhookFlags(opts *webhookOptions) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:        "webhook-port",
+			EnvVar:      "CIS_WEBHOOK_PORT",
+			Value:       "",
+			Usage:       "port of the admission webhook of the CIS CRDs, disabled if empty",
+			Destination: &opts.port,
+		},
+		cli.StringFlag{
+			Name:        "webhook-tls-cert",
+			EnvVar:      "CIS_WEBHOOK_TLS_CERT",
+			Destination: &opts.tlsCertFile,
+		},
+		cli.StringFlag{
+			Name:        "webhook-tls-key",
+			EnvVar:      "CIS_WEBHOOK_TLS_KEY",
+			Destination: &opts.tlsKeyFile,
+		},
+		cli.StringFlag{
+			Name:        "webhook-external-data-port",
+			EnvVar:      "CIS_WEBHOOK_EXTERNAL_DATA_PORT",
+			Value:       "",
+			Usage:       "port of the Gatekeeper external data provider, disabled if empty, requires --webhook-external-data-client-ca",
+			Destination: &opts.externalDataPort,
+		},
+		cli.StringFlag{
+			Name:        "webhook-external-data-client-ca",
+			EnvVar:      "CIS_WEBHOOK_EXTERNAL_DATA_CLIENT_CA",
+			Usage:       "CA the client certificates of Gatekeeper must be signed by to query the external data provider",
+			Destination: &opts.externalDataClientCAFile,
+		},
+	}
+}
+
+// newWebhookServer returns the server of the admission webhook, and of the Gatekeeper external data provider if
+// enabled, they must be built before the controller starts and served once it started, for its caches to be synced
+func newWebhookServer(opts *webhookOptions, ctl *cisoperator.Controller) (*http.Server, *http.Server, error) {
+	if opts.tlsCertFile == "" || opts.tlsKeyFile == "" {
+		return nil, nil, fmt.Errorf("the webhook requires a TLS certificate and key")
+	}
+	cert, err := tls.LoadX509KeyPair(opts.tlsCertFile, opts.tlsKeyFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading TLS certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	fips.ConfigureTLS(tlsConfig)
+	handler := &webhook.Server{
+		Profiles:       ctl.ProfileCache(),
+		Benchmarks:     ctl.BenchmarkCache(),
+		Reports:        ctl.ReportCache(),
+		Scans:          ctl.ScanController().Cache(),
+		Shards:         ctl.ReportShardCache(),
+		DefaultProfile: ctl.DefaultClusterScanProfile,
+	}
+	server := webhook.NewServer(":"+opts.port, handler, tlsConfig)
+	if opts.externalDataPort == "" {
+		return server, nil, nil
+	}
+	if opts.externalDataClientCAFile == "" {
+		return nil, nil, fmt.Errorf("the external data provider requires --webhook-external-data-client-ca")
+	}
+	caPEM, err := os.ReadFile(opts.externalDataClientCAFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading external data client CA: %w", err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caPEM) {
+		return nil, nil, fmt.Errorf("no certificate found in external data client CA %v", opts.externalDataClientCAFile)
+	}
+	externalDataTLSConfig := tlsConfig.Clone()
+	externalDataTLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	externalDataTLSConfig.ClientCAs = clientCAs
+	return server, webhook.NewExternalDataServer(":"+opts.externalDataPort, handler, externalDataTLSConfig), nil
+}